@@ -0,0 +1,498 @@
+package vulkanRenderSystem
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"sort"
+	"unsafe"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// maxDescriptorSets is the number of texture descriptor sets that can be
+// allocated at once. Each texture uses one set per swap chain image.
+const maxDescriptorSets = 4096
+
+// vertexStride is the number of float32s in a single vertex
+const vertexStride = 8
+
+// whiteVertex is the vertex color that leaves textures as they are
+var whiteVertex = [4]float32{1, 1, 1, 1}
+
+// opacityVertex is the vertex color that draws textures with the given
+// opacity
+func opacityVertex(opacity float32) [4]float32 {
+	return [4]float32{1, 1, 1, opacity}
+}
+
+// drawCall is a run of indices in a geometryBatch that all use the same
+// texture and shader. The indices are in the buffers of chunk, or the batch's
+// own buffers if it's nil.
+type drawCall struct {
+	texture    *Texture
+	shader     *Shader
+	firstIndex uint32
+	indexCount uint32
+	chunk      *tileChunk
+}
+
+// geometryBatch collects the vertices and indices for a frame, merging
+// consecutive geometry that uses the same texture and shader into a single
+// draw call.
+type geometryBatch struct {
+	vertices vertex
+	indices  []uint32
+	draws    []drawCall
+	// shader is the shader the geometry being added is drawn with
+	shader *Shader
+	// uploads are the chunks that have to be uploaded before drawing
+	uploads []*tileChunk
+}
+
+func (b *geometryBatch) reset() {
+	b.vertices = b.vertices[:0]
+	b.indices = b.indices[:0]
+	b.draws = b.draws[:0]
+	b.uploads = b.uploads[:0]
+	b.shader = nil
+}
+
+// addQuad adds a textured quad. The corners and uvs go clockwise on screen
+// starting from the top left.
+func (b *geometryBatch) addQuad(tex *Texture, corners, uvs [4][2]float32, c [4]float32) {
+	base := uint32(len(b.vertices) / vertexStride)
+	for i := 0; i < 4; i++ {
+		b.vertices = append(b.vertices,
+			corners[i][0], corners[i][1],
+			c[0], c[1], c[2], c[3],
+			uvs[i][0], uvs[i][1],
+		)
+	}
+	b.addIndices(tex, base, base+1, base+2, base+2, base+3, base)
+}
+
+func (b *geometryBatch) addIndices(tex *Texture, idx ...uint32) {
+	if n := len(b.draws); n > 0 && b.draws[n-1].texture == tex && b.draws[n-1].shader == b.shader && b.draws[n-1].chunk == nil {
+		b.draws[n-1].indexCount += uint32(len(idx))
+	} else {
+		b.draws = append(b.draws, drawCall{
+			texture:    tex,
+			shader:     b.shader,
+			firstIndex: uint32(len(b.indices)),
+			indexCount: uint32(len(idx)),
+		})
+	}
+	b.indices = append(b.indices, idx...)
+}
+
+// addTriangles adds triangles of a single color. The whole triangles sample
+// the top left of the texture.
+func (b *geometryBatch) addTriangles(tex *Texture, points [][2]float32, indices []uint32, c [4]float32) {
+	b.addMesh(tex, points, nil, indices, c)
+}
+
+// addMesh adds triangles whose points have their own colors. Points past the
+// end of colors use c.
+func (b *geometryBatch) addMesh(tex *Texture, points [][2]float32, colors [][4]float32, indices []uint32, c [4]float32) {
+	if tex == nil || len(indices) == 0 {
+		return
+	}
+	base := uint32(len(b.vertices) / vertexStride)
+	for i, p := range points {
+		pc := c
+		if i < len(colors) {
+			pc = colors[i]
+		}
+		b.vertices = append(b.vertices, p[0], p[1], pc[0], pc[1], pc[2], pc[3], 0, 0)
+	}
+	idx := make([]uint32, len(indices))
+	for i, index := range indices {
+		idx[i] = base + index
+	}
+	b.addIndices(tex, idx...)
+}
+
+// addChunk adds the draw calls of a chunk, which uses its own buffers.
+func (b *geometryBatch) addChunk(c *tileChunk) {
+	for _, d := range c.draws {
+		d.chunk = c
+		b.draws = append(b.draws, d)
+	}
+}
+
+// addSprite adds a drawable at the given position, rotated in degrees around
+// the position and scaled by scale.
+func (b *geometryBatch) addSprite(d Drawable, x, y, rotation, scaleX, scaleY float32, c [4]float32) {
+	tex := d.Texture()
+	if tex == nil {
+		return
+	}
+	w, h := d.Width()*scaleX, d.Height()*scaleY
+	corners := [4][2]float32{{0, 0}, {w, 0}, {w, h}, {0, h}}
+	newPlacement(x, y, rotation).apply(corners[:])
+	u0, v0, u1, v1 := d.View()
+	b.addQuad(tex, corners, [4][2]float32{{u0, v0}, {u1, v0}, {u1, v1}, {u0, v1}}, c)
+}
+
+// colorToVertex converts a color into the rgba floats used by the vertices,
+// which aren't alpha premultiplied. Nil colors are white.
+func colorToVertex(c color.Color) [4]float32 {
+	if c == nil {
+		return whiteVertex
+	}
+	n := color.NRGBA64Model.Convert(c).(color.NRGBA64)
+	return [4]float32{float32(n.R) / 0xffff, float32(n.G) / 0xffff, float32(n.B) / 0xffff, float32(n.A) / 0xffff}
+}
+
+// hostBuffer is a host visible buffer that is rewritten every frame and grows
+// when the data no longer fits.
+type hostBuffer struct {
+	buffer vk.Buffer
+	memory vk.DeviceMemory
+	size   vk.DeviceSize
+	usage  vk.BufferUsageFlags
+	// name is what the buffer is named for debugging
+	name string
+}
+
+func (r *RenderSystem) writeHostBuffer(b *hostBuffer, data []byte) error {
+	size := vk.DeviceSize(len(data))
+	if size == 0 {
+		return nil
+	}
+	if size > b.size {
+		r.destroyHostBuffer(b)
+		newSize := vk.DeviceSize(1024)
+		for newSize < size {
+			newSize *= 2
+		}
+		var err error
+		b.buffer, b.memory, err = r.createBuffer(newSize, b.usage, vk.MemoryPropertyFlags(vk.MemoryPropertyHostVisibleBit|vk.MemoryPropertyHostCoherentBit))
+		if err != nil {
+			return err
+		}
+		b.size = newSize
+		r.setName(vk.ObjectTypeBuffer, unsafe.Pointer(b.buffer), b.name)
+	}
+	var ptr unsafe.Pointer
+	if res := vk.MapMemory(r.device, b.memory, 0, size, 0, &ptr); res != vk.Success {
+		return errors.New("unable to map host buffer memory")
+	}
+	n := vk.Memcopy(ptr, data)
+	vk.UnmapMemory(r.device, b.memory)
+	if n != len(data) {
+		return errors.New("failed to copy host buffer data")
+	}
+	return nil
+}
+
+func (r *RenderSystem) destroyHostBuffer(b *hostBuffer) {
+	if b.size == 0 {
+		return
+	}
+	vk.DestroyBuffer(r.device, b.buffer, nil)
+	vk.FreeMemory(r.device, b.memory, nil)
+	b.size = 0
+}
+
+// createBatchBuffers sets up the per swap chain image vertex and index buffers
+// the batch is uploaded into.
+func (r *RenderSystem) createBatchBuffers() error {
+	r.batchVertexBuffers = make([]hostBuffer, len(r.images))
+	r.batchIndexBuffers = make([]hostBuffer, len(r.images))
+	for i := range r.images {
+		r.batchVertexBuffers[i].usage = vk.BufferUsageFlags(vk.BufferUsageVertexBufferBit)
+		r.batchIndexBuffers[i].usage = vk.BufferUsageFlags(vk.BufferUsageIndexBufferBit)
+		r.batchVertexBuffers[i].name = fmt.Sprintf("batch vertices %d", i)
+		r.batchIndexBuffers[i].name = fmt.Sprintf("batch indices %d", i)
+	}
+	r.textureSets = make(map[textureSetKey][]vk.DescriptorSet)
+	return nil
+}
+
+func (r *RenderSystem) destroyBatchBuffers() {
+	for i := range r.batchVertexBuffers {
+		r.destroyHostBuffer(&r.batchVertexBuffers[i])
+		r.destroyHostBuffer(&r.batchIndexBuffers[i])
+	}
+	r.batchVertexBuffers, r.batchIndexBuffers = nil, nil
+}
+
+// textureSetKey identifies the descriptor sets of a texture for a layout, when
+// drawn into a target or the screen if it's nil
+type textureSetKey struct {
+	texture *Texture
+	layout  *shaderLayout
+	target  *RenderTarget
+}
+
+// textureSet returns the descriptor set that binds the uniform buffer of the
+// swap chain image and the texture for shaders with the layout, allocating it
+// the first time it's used. Geometry drawn into a target uses the target's
+// uniform buffers.
+func (r *RenderSystem) textureSet(imageIdx uint32, tex *Texture, layout *shaderLayout, target *RenderTarget) (vk.DescriptorSet, error) {
+	key := textureSetKey{tex, layout, target}
+	sets := r.textureSets[key]
+	if sets == nil {
+		sets = make([]vk.DescriptorSet, len(r.images))
+		r.textureSets[key] = sets
+	}
+	if sets[imageIdx] != nil {
+		return sets[imageIdx], nil
+	}
+	var set vk.DescriptorSet
+	if res := vk.AllocateDescriptorSets(r.device, &vk.DescriptorSetAllocateInfo{
+		SType:              vk.StructureTypeDescriptorSetAllocateInfo,
+		DescriptorPool:     r.descriptorPool,
+		DescriptorSetCount: 1,
+		PSetLayouts:        []vk.DescriptorSetLayout{layout.setLayout},
+	}, &set); res != vk.Success {
+		return set, errors.New("unable to allocate texture descriptor set")
+	}
+	var writes []vk.WriteDescriptorSet
+	if layout.uniformBinding >= 0 {
+		uniforms := r.uniformBuffers[imageIdx]
+		if target != nil {
+			uniforms = target.uniformBuffers[imageIdx]
+		}
+		writes = append(writes, vk.WriteDescriptorSet{
+			SType:           vk.StructureTypeWriteDescriptorSet,
+			DstSet:          set,
+			DstBinding:      uint32(layout.uniformBinding),
+			DescriptorType:  vk.DescriptorTypeUniformBuffer,
+			DescriptorCount: 1,
+			PBufferInfo: []vk.DescriptorBufferInfo{{
+				Buffer: uniforms,
+				Offset: 0,
+				Range:  vk.DeviceSize(uniformBufferSize),
+			}},
+		})
+	}
+	if layout.textureBinding >= 0 {
+		writes = append(writes, vk.WriteDescriptorSet{
+			SType:           vk.StructureTypeWriteDescriptorSet,
+			DstSet:          set,
+			DstBinding:      uint32(layout.textureBinding),
+			DescriptorType:  vk.DescriptorTypeCombinedImageSampler,
+			DescriptorCount: 1,
+			PImageInfo: []vk.DescriptorImageInfo{{
+				ImageLayout: vk.ImageLayoutShaderReadOnlyOptimal,
+				ImageView:   tex.view,
+				Sampler:     tex.sampler,
+			}},
+		})
+	}
+	if len(writes) > 0 {
+		vk.UpdateDescriptorSets(r.device, uint32(len(writes)), writes, 0, nil)
+	}
+	sets[imageIdx] = set
+	return set, nil
+}
+
+// releaseTexture frees the descriptor sets of a texture that's being destroyed.
+func (r *RenderSystem) releaseTexture(tex *Texture) {
+	r.releaseSets(func(key textureSetKey) bool { return key.texture == tex })
+}
+
+// releaseSets frees the descriptor sets whose key matches.
+func (r *RenderSystem) releaseSets(match func(textureSetKey) bool) {
+	waited := false
+	for key, sets := range r.textureSets {
+		if !match(key) {
+			continue
+		}
+		if !waited {
+			vk.DeviceWaitIdle(r.device)
+			waited = true
+		}
+		for _, set := range sets {
+			if set != nil {
+				vk.FreeDescriptorSets(r.device, r.descriptorPool, 1, []vk.DescriptorSet{set})
+			}
+		}
+		delete(r.textureSets, key)
+	}
+}
+
+// buildBatch collects the geometry of all levels and entities, in order of
+// their Zindex.
+func (r *RenderSystem) buildBatch() {
+	r.batch.reset()
+	view := r.camera.view(float32(r.swapChainExtent.Width), float32(r.swapChainExtent.Height))
+	appendScene(&r.batch, &r.camera, view, r.entities, r.levels, nil)
+}
+
+// appendScene adds the levels and entities to the batch in order of their
+// Zindex. Entities that draw the skipped texture are left out.
+func appendScene(b *geometryBatch, cam *Camera, view rect, entities []renderEntity, levels []*Level, skip *Texture) {
+	sort.SliceStable(entities, func(i, j int) bool {
+		return entities[i].Zindex < entities[j].Zindex
+	})
+	sort.SliceStable(levels, func(i, j int) bool {
+		return levels[i].Zindex < levels[j].Zindex
+	})
+	li := 0
+	for _, e := range entities {
+		for ; li < len(levels) && levels[li].Zindex <= e.Zindex; li++ {
+			b.shader = nil
+			levels[li].appendTo(b, cam, view)
+		}
+		if e.Hidden || e.Drawable == nil || (skip != nil && e.Drawable.Texture() == skip) {
+			continue
+		}
+		b.shader = e.Shader
+		scale := e.Scale
+		if scale.X == 0 && scale.Y == 0 {
+			scale.X, scale.Y = 1, 1
+		}
+		if s, ok := e.Drawable.(shape); ok {
+			p := newPlacement(e.Position.X, e.Position.Y, e.Rotation)
+			s.appendShape(b, e.Width*scale.X, e.Height*scale.Y, p, e.Color)
+			continue
+		}
+		b.addSprite(e.Drawable, e.Position.X, e.Position.Y, e.Rotation, scale.X, scale.Y, colorToVertex(e.Color))
+	}
+	b.shader = nil
+	for ; li < len(levels); li++ {
+		levels[li].appendTo(b, cam, view)
+	}
+}
+
+// uploadBatch copies the batch into the buffers of the swap chain image.
+func (r *RenderSystem) uploadBatch(imageIdx uint32) error {
+	if err := r.writeHostBuffer(&r.batchVertexBuffers[imageIdx], vertexData(r.batch.vertices)); err != nil {
+		return err
+	}
+	return r.writeHostBuffer(&r.batchIndexBuffers[imageIdx], indexData(r.batch.indices))
+}
+
+// recordCommandBuffer records drawing the render targets and the batch into
+// the command buffer of the swap chain image.
+func (r *RenderSystem) recordCommandBuffer(imageIdx uint32) error {
+	buffer := r.commandBuffers[imageIdx]
+	beginInfo := vk.CommandBufferBeginInfo{
+		SType: vk.StructureTypeCommandBufferBeginInfo,
+		Flags: vk.CommandBufferUsageFlags(vk.CommandBufferUsageOneTimeSubmitBit),
+	}
+	if res := vk.BeginCommandBuffer(buffer, &beginInfo); res != vk.Success {
+		return errors.New("failed to begin recording command buffers")
+	}
+	r.beginProfile(buffer)
+	r.recordChunkCopies(buffer)
+	// targets are drawn first, so the screen can draw what's in them
+	for _, t := range r.targets {
+		if t.Hidden {
+			continue
+		}
+		if err := r.recordTarget(buffer, imageIdx, t); err != nil {
+			return err
+		}
+	}
+	final, err := r.recordEffects(buffer, imageIdx)
+	if err != nil {
+		return err
+	}
+	clearValue := vk.NewClearValue([]float32{0, 0, 0, 1})
+	renderPassInfo := vk.RenderPassBeginInfo{
+		SType:           vk.StructureTypeRenderPassBeginInfo,
+		RenderPass:      r.renderPass,
+		Framebuffer:     r.swapChainFramebuffers[imageIdx],
+		ClearValueCount: 1,
+		PClearValues:    []vk.ClearValue{clearValue},
+	}
+	renderPassInfo.RenderArea.Offset = vk.Offset2D{X: 0, Y: 0}
+	renderPassInfo.RenderArea.Extent = r.swapChainExtent
+	r.beginPass(buffer, "screen", screenLabelColor)
+	vk.CmdBeginRenderPass(buffer, &renderPassInfo, vk.SubpassContentsInline)
+	// with effects the scene is drawn first, and the screen only shows the
+	// output of the last effect
+	if final != nil {
+		if err := r.recordBlit(buffer, imageIdx, final); err != nil {
+			return err
+		}
+	} else if err := r.recordDraws(buffer, imageIdx, &r.batch, r.batchVertexBuffers[imageIdx].buffer, r.batchIndexBuffers[imageIdx].buffer, nil, r.swapChainImageFormat); err != nil {
+		return err
+	}
+	vk.CmdEndRenderPass(buffer)
+	r.endPass(buffer)
+	if err := r.recordCapture(buffer, imageIdx); err != nil {
+		return err
+	}
+	r.recordScreenshot(buffer, imageIdx)
+	r.recordOffscreenReadback(buffer, imageIdx)
+	if vk.EndCommandBuffer(buffer) != vk.Success {
+		return errors.New("failed to record command buffer")
+	}
+	return nil
+}
+
+// recordDraws records the draw calls of a batch into a render pass that has
+// begun, drawing with the camera of the target, or the screen's if it's nil.
+// The vertices and indices are the buffers the batch was uploaded into, and
+// format is the format of the image that's drawn into.
+func (r *RenderSystem) recordDraws(buffer vk.CommandBuffer, imageIdx uint32, b *geometryBatch, vertices, indices vk.Buffer, target *RenderTarget, format vk.Format) error {
+	extent := r.swapChainExtent
+	if target != nil {
+		extent = target.extent
+	}
+	r.beginLabel(buffer, fmt.Sprintf("batch of %d draws", len(b.draws)), batchLabelColor)
+	defer r.endLabel(buffer)
+	setViewport(buffer, extent)
+	var layout *shaderLayout
+	// skip is whether the shader of the draws has no pipeline, and bound is
+	// whether the vertices of a chunk, or of the batch if it's nil, are bound
+	var skip, bound bool
+	var chunk *tileChunk
+	for i, draw := range b.draws {
+		if i == 0 || draw.shader != b.draws[i-1].shader {
+			state := r.drawState(draw.shader)
+			state.format = format
+			if target != nil {
+				state.depth = target.depth
+			}
+			pipeline, err := r.pipeline(state)
+			if err != nil {
+				return err
+			}
+			if skip = pipeline == vk.NullPipeline; !skip {
+				if layout, err = r.layout(state.shader); err != nil {
+					return err
+				}
+				vk.CmdBindPipeline(buffer, vk.PipelineBindPointGraphics, pipeline)
+			}
+		}
+		if skip {
+			continue
+		}
+		if !bound || draw.chunk != chunk {
+			bound, chunk = true, draw.chunk
+			v, idx := vertices, indices
+			if draw.chunk != nil {
+				v, idx = draw.chunk.vertexBuffer.buffer, draw.chunk.indexBuffer.buffer
+			}
+			vk.CmdBindVertexBuffers(buffer, 0, 1, []vk.Buffer{v}, []vk.DeviceSize{0})
+			vk.CmdBindIndexBuffer(buffer, idx, 0, vk.IndexTypeUint32)
+		}
+		set, err := r.textureSet(imageIdx, draw.texture, layout, target)
+		if err != nil {
+			return err
+		}
+		vk.CmdBindDescriptorSets(buffer, vk.PipelineBindPointGraphics, layout.pipelineLayout, 0, 1, []vk.DescriptorSet{set}, 0, nil)
+		vk.CmdDrawIndexed(buffer, draw.indexCount, 1, draw.firstIndex, 0, 0)
+	}
+	return nil
+}
+
+// setViewport sets the viewport and scissor to the whole of an image.
+func setViewport(buffer vk.CommandBuffer, extent vk.Extent2D) {
+	vk.CmdSetViewport(buffer, 0, 1, []vk.Viewport{{
+		Width:    float32(extent.Width),
+		Height:   float32(extent.Height),
+		MinDepth: 0,
+		MaxDepth: 1,
+	}})
+	vk.CmdSetScissor(buffer, 0, 1, []vk.Rect2D{{
+		Extent: extent,
+	}})
+}
@@ -0,0 +1,122 @@
+package vulkanRenderSystem
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"unsafe"
+
+	"github.com/EngoEngine/engo"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// pipelineCacheHeaderSize is the size of the header vulkan starts pipeline
+// cache data with: its length, version, vendor and device ids, and the cache's
+// UUID
+const pipelineCacheHeaderSize = 16 + vk.UuidSize
+
+// pipelineCachePath returns the file the pipeline cache is kept in between
+// runs, in the user's config directory.
+func pipelineCachePath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`/\:*?"<>|`, r) {
+			return '_'
+		}
+		return r
+	}, engo.GetTitle())
+	if name == "" {
+		name = "engo"
+	}
+	return filepath.Join(dir, "vulkanRenderSystem", name, "pipeline.cache"), nil
+}
+
+// createPipelineCache creates the cache all pipelines are created with, filled
+// with the cache saved by an earlier run if it was made by the same device and
+// driver.
+func (r *RenderSystem) createPipelineCache() error {
+	var data []byte
+	if path, err := pipelineCachePath(); err == nil {
+		if data, err = ioutil.ReadFile(path); err != nil || !r.validPipelineCache(data) {
+			data = nil
+		}
+	}
+	info := vk.PipelineCacheCreateInfo{
+		SType:           vk.StructureTypePipelineCacheCreateInfo,
+		InitialDataSize: uint(len(data)),
+	}
+	if len(data) > 0 {
+		info.PInitialData = unsafe.Pointer(&data[0])
+	}
+	if res := vk.CreatePipelineCache(r.device, &info, nil, &r.pipelineCache); res == vk.Success {
+		return nil
+	}
+	// the driver can still refuse the saved data, so start from an empty cache
+	info.InitialDataSize, info.PInitialData = 0, nil
+	if res := vk.CreatePipelineCache(r.device, &info, nil, &r.pipelineCache); res != vk.Success {
+		return errors.New("unable to create pipeline cache")
+	}
+	return nil
+}
+
+// validPipelineCache reports whether the header of the cache data matches the
+// device.
+func (r *RenderSystem) validPipelineCache(data []byte) bool {
+	if len(data) < pipelineCacheHeaderSize {
+		return false
+	}
+	var props vk.PhysicalDeviceProperties
+	vk.GetPhysicalDeviceProperties(r.gpu, &props)
+	props.Deref()
+	return binary.LittleEndian.Uint32(data[0:]) >= pipelineCacheHeaderSize &&
+		binary.LittleEndian.Uint32(data[4:]) == uint32(vk.PipelineCacheHeaderVersionOne) &&
+		binary.LittleEndian.Uint32(data[8:]) == props.VendorID &&
+		binary.LittleEndian.Uint32(data[12:]) == props.DeviceID &&
+		bytes.Equal(data[16:pipelineCacheHeaderSize], props.PipelineCacheUUID[:])
+}
+
+// savePipelineCache writes the pipeline cache to the user's config directory.
+func (r *RenderSystem) savePipelineCache() error {
+	var size uint
+	if res := vk.GetPipelineCacheData(r.device, r.pipelineCache, &size, nil); res != vk.Success {
+		return errors.New("unable to get pipeline cache size")
+	}
+	if size == 0 {
+		return nil
+	}
+	data := make([]byte, size)
+	if res := vk.GetPipelineCacheData(r.device, r.pipelineCache, &size, unsafe.Pointer(&data[0])); res != vk.Success {
+		return errors.New("unable to get pipeline cache data")
+	}
+	path, err := pipelineCachePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	// write next to the cache first, so a crash can't leave half a cache
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data[:size], 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// destroyPipelineCache saves the pipeline cache for the next run and destroys
+// it.
+func (r *RenderSystem) destroyPipelineCache() {
+	if err := r.savePipelineCache(); err != nil {
+		log.Println("[VULKAN RENDER SYSTEM]: unable to save pipeline cache: " + err.Error())
+	}
+	vk.DestroyPipelineCache(r.device, r.pipelineCache, nil)
+}
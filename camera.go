@@ -0,0 +1,52 @@
+package vulkanRenderSystem
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Camera is the view of the world drawn by the RenderSystem
+type Camera struct {
+	// X and Y are the position in the world of the top left corner of the screen
+	X, Y float32
+	// Zoom is how much the world is scaled up. Zero is treated as 1.
+	Zoom float32
+}
+
+// Camera returns the camera of the RenderSystem. Changes to it are used from
+// the next frame on.
+func (r *RenderSystem) Camera() *Camera {
+	return &r.camera
+}
+
+func (c *Camera) zoom() float32 {
+	if c.Zoom == 0 {
+		return 1
+	}
+	return c.Zoom
+}
+
+// viewMatrix moves the camera's position to the top left of the screen
+func (c *Camera) viewMatrix() mgl32.Mat4 {
+	z := c.zoom()
+	return mgl32.Scale3D(z, z, 1).Mul4(mgl32.Translate3D(-c.X, -c.Y, 0))
+}
+
+// rect is an area of the world
+type rect struct {
+	minX, minY, maxX, maxY float32
+}
+
+func (r rect) overlaps(o rect) bool {
+	return r.minX < o.maxX && o.minX < r.maxX && r.minY < o.maxY && o.minY < r.maxY
+}
+
+// moved returns the rect moved by dx and dy
+func (r rect) moved(dx, dy float32) rect {
+	return rect{r.minX + dx, r.minY + dy, r.maxX + dx, r.maxY + dy}
+}
+
+// view returns the area of the world that's on a screen of the given size
+func (c *Camera) view(width, height float32) rect {
+	z := c.zoom()
+	return rect{c.X, c.Y, c.X + width/z, c.Y + height/z}
+}
@@ -0,0 +1,351 @@
+package vulkanRenderSystem
+
+import (
+	"fmt"
+	"unsafe"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// chunkSize is the width and height in tiles of the chunks tile layers are
+// split into. It has to be even so staggered rows and columns line up.
+const chunkSize = 32
+
+// tileChunk is a part of a tile layer whose geometry is kept in device local
+// buffers, so it only has to be uploaded again when its tiles change.
+type tileChunk struct {
+	// x, y, width and height are the tiles of the layer in the chunk
+	x, y, width, height int
+	// bounds is the area of the world the chunk's tiles can cover, without
+	// the layer's offset
+	bounds                    rect
+	vertexBuffer, indexBuffer deviceBuffer
+	draws                     []drawCall
+	// offsetX and offsetY are the layer offset the geometry was built with,
+	// and opacity the layer's opacity
+	offsetX, offsetY float32
+	opacity          float32
+	// animated are the indices of the animated tiles in the layer, and frames
+	// the tile ids they were built with
+	animated []int
+	frames   []uint32
+	// geometry holds the vertices until they're uploaded
+	geometry geometryBatch
+	dirty    bool
+}
+
+// deviceBuffer is a device local buffer that's written through a staging
+// buffer
+type deviceBuffer struct {
+	buffer vk.Buffer
+	memory vk.DeviceMemory
+	size   vk.DeviceSize
+}
+
+// SetTile changes the tile at the given tile coordinates. Changes made to
+// Tiles directly aren't drawn until SetTile is used for a tile in the same
+// chunk.
+func (l *TileLayer) SetTile(x, y int, t Tile) {
+	if x < 0 || y < 0 || x >= l.Width || y >= l.Height {
+		return
+	}
+	l.Tiles[y*l.Width+x] = t
+	if l.chunks != nil {
+		l.chunks[(y/chunkSize)*l.chunksWide+x/chunkSize].dirty = true
+	}
+}
+
+// tileMargins returns how far tiles can reach past the top left and bottom
+// right of their cell, because they're bigger than the map's tiles or offset.
+func (l *Level) tileMargins() (left, top, right, bottom float32) {
+	grow := func(w, h, ox, oy int) {
+		left = min32(left, float32(ox))
+		top = min32(top, float32(oy+l.TileHeight-h))
+		right = max32(right, float32(ox+w-l.TileWidth))
+		bottom = max32(bottom, float32(oy))
+	}
+	for _, ts := range l.Tilesets {
+		grow(ts.TileWidth, ts.TileHeight, ts.OffsetX, ts.OffsetY)
+		for _, img := range ts.tileImages {
+			grow(img.Width, img.Height, ts.OffsetX, ts.OffsetY)
+		}
+	}
+	return
+}
+
+// newChunks splits the layer into chunks, row by row.
+func (l *Level) newChunks(layer *TileLayer) {
+	left, top, right, bottom := l.tileMargins()
+	layer.chunksWide = (layer.Width + chunkSize - 1) / chunkSize
+	layer.chunksHigh = (layer.Height + chunkSize - 1) / chunkSize
+	layer.chunks = make([]*tileChunk, 0, layer.chunksWide*layer.chunksHigh)
+	for cy := 0; cy < layer.chunksHigh; cy++ {
+		for cx := 0; cx < layer.chunksWide; cx++ {
+			c := &tileChunk{
+				x:      cx * chunkSize,
+				y:      cy * chunkSize,
+				width:  chunkSize,
+				height: chunkSize,
+				dirty:  true,
+			}
+			if c.x+c.width > layer.Width {
+				c.width = layer.Width - c.x
+			}
+			if c.y+c.height > layer.Height {
+				c.height = layer.Height - c.y
+			}
+			// the corner tiles are the furthest out in every orientation
+			c.bounds = rect{minX: 1e30, minY: 1e30, maxX: -1e30, maxY: -1e30}
+			for _, t := range [4][2]int{
+				{c.x, c.y},
+				{c.x + c.width - 1, c.y},
+				{c.x, c.y + c.height - 1},
+				{c.x + c.width - 1, c.y + c.height - 1},
+			} {
+				x, y := l.TileToWorld(t[0], t[1])
+				c.bounds.minX = min32(c.bounds.minX, x+left)
+				c.bounds.minY = min32(c.bounds.minY, y+top)
+				c.bounds.maxX = max32(c.bounds.maxX, x+float32(l.TileWidth)+right)
+				c.bounds.maxY = max32(c.bounds.maxY, y+float32(l.TileHeight)+bottom)
+			}
+			layer.chunks = append(layer.chunks, c)
+		}
+	}
+}
+
+// framesChanged reports whether any of the animated tiles of the chunk moved
+// on to another frame since it was built.
+func (l *Level) framesChanged(layer *TileLayer, c *tileChunk) bool {
+	for i, idx := range c.animated {
+		tile := &layer.Tiles[idx]
+		if tile.Tileset.frame(tile.ID, l.elapsed) != c.frames[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// appendChunk adds the tiles of a chunk to the batch, moved by dx and dy.
+// When record is set, the animated tiles are remembered in the chunk.
+func (l *Level) appendChunk(b *geometryBatch, layer *TileLayer, c *tileChunk, dx, dy float32, record bool) {
+	if record {
+		c.animated, c.frames = c.animated[:0], c.frames[:0]
+	}
+	l.tileOrder(c.width, c.height, func(x, y int) {
+		x, y = x+c.x, y+c.y
+		idx := y*layer.Width + x
+		tile := &layer.Tiles[idx]
+		if tile.Empty() {
+			return
+		}
+		id := tile.Tileset.frame(tile.ID, l.elapsed)
+		if _, ok := tile.Tileset.Animations[tile.ID]; ok && record {
+			c.animated = append(c.animated, idx)
+			c.frames = append(c.frames, id)
+		}
+		url, uv, w, h := tile.Tileset.region(id)
+		res, ok := theTextureLoader.images[url]
+		if !ok {
+			return
+		}
+		// tiles that are bigger than the map's tiles are aligned with the
+		// bottom left of their cell
+		px, py := l.TileToWorld(x, y)
+		px += float32(tile.Tileset.OffsetX) + dx
+		py += float32(l.TileHeight-int(h)+tile.Tileset.OffsetY) + dy
+		corners := [4][2]float32{{px, py}, {px + w, py}, {px + w, py + h}, {px, py + h}}
+		b.addQuad(res.Texture, corners, tileUVs(tile, uv), opacityVertex(layer.Opacity))
+	})
+}
+
+// appendTileLayer adds the chunks of the layer that are on screen to the
+// batch. Chunks are only built again when their tiles change. Layers with
+// parallax move with the camera, so their tiles are added to the batch every
+// frame instead.
+func (l *Level) appendTileLayer(b *geometryBatch, cam *Camera, view rect, layer *TileLayer) {
+	if !layer.Visible {
+		return
+	}
+	if layer.chunks == nil {
+		l.newChunks(layer)
+	}
+	static := layer.ParallaxX == 1 && layer.ParallaxY == 1
+	dx, dy := parallax(cam, layer.ParallaxX, layer.ParallaxY)
+	dx += layer.OffsetX
+	dy += layer.OffsetY
+	l.tileOrder(layer.chunksWide, layer.chunksHigh, func(cx, cy int) {
+		c := layer.chunks[cy*layer.chunksWide+cx]
+		if !c.bounds.moved(dx, dy).overlaps(view) {
+			return
+		}
+		if !static {
+			l.appendChunk(b, layer, c, dx, dy, false)
+			return
+		}
+		if c.offsetX != dx || c.offsetY != dy || c.opacity != layer.Opacity || l.framesChanged(layer, c) {
+			c.dirty = true
+		}
+		if c.dirty {
+			c.geometry.reset()
+			l.appendChunk(&c.geometry, layer, c, dx, dy, true)
+			c.draws = c.geometry.draws
+			c.offsetX, c.offsetY = dx, dy
+			c.opacity = layer.Opacity
+			c.dirty = false
+			b.uploads = append(b.uploads, c)
+		}
+		b.addChunk(c)
+	})
+}
+
+// chunkCopy is a copy from the staging buffer of the frame into the buffer of
+// a chunk
+type chunkCopy struct {
+	dst    vk.Buffer
+	region vk.BufferCopy
+}
+
+// createChunkStaging sets up the per frame in flight staging buffers chunks
+// are uploaded through.
+func (r *RenderSystem) createChunkStaging() {
+	r.chunkStaging = make([]hostBuffer, maxFramesInFlight)
+	r.retiredChunks = make([][]deviceBuffer, maxFramesInFlight)
+	for i := range r.chunkStaging {
+		r.chunkStaging[i].usage = vk.BufferUsageFlags(vk.BufferUsageTransferSrcBit)
+		r.chunkStaging[i].name = fmt.Sprintf("tile chunk staging %d", i)
+	}
+}
+
+// destroyChunkStaging destroys the staging buffers and the chunk buffers that
+// are waiting on their frames. The device has to be idle.
+func (r *RenderSystem) destroyChunkStaging() {
+	for i := range r.chunkStaging {
+		r.destroyHostBuffer(&r.chunkStaging[i])
+		r.destroyRetiredChunks(i)
+	}
+}
+
+// destroyRetiredChunks destroys the chunk buffers the frame in flight stopped
+// using. The frame has to have finished.
+func (r *RenderSystem) destroyRetiredChunks(frame int) {
+	for i := range r.retiredChunks[frame] {
+		r.destroyDeviceBuffer(&r.retiredChunks[frame][i])
+	}
+	r.retiredChunks[frame] = r.retiredChunks[frame][:0]
+}
+
+// uploadChunks creates new device local buffers for the chunks that changed
+// in the batches of the frame, and writes their geometry into the frame's
+// staging buffer. The copies are recorded at the start of the frame's command
+// buffer. The old buffers can still be in use by the other frames in flight,
+// so they're destroyed once this frame has finished.
+func (r *RenderSystem) uploadChunks() error {
+	chunks := append([]*tileChunk(nil), r.batch.uploads...)
+	for _, t := range r.targets {
+		if !t.Hidden {
+			chunks = append(chunks, t.batch.uploads...)
+		}
+	}
+	r.chunkCopies = r.chunkCopies[:0]
+	if len(chunks) == 0 {
+		return nil
+	}
+	var data []byte
+	for _, c := range chunks {
+		for _, b := range []*deviceBuffer{&c.vertexBuffer, &c.indexBuffer} {
+			if b.size != 0 {
+				r.retiredChunks[r.currentFrame] = append(r.retiredChunks[r.currentFrame], *b)
+				*b = deviceBuffer{}
+			}
+		}
+		if len(c.geometry.indices) == 0 {
+			continue
+		}
+		vertices := vertexData(c.geometry.vertices)
+		indices := indexData(c.geometry.indices)
+		if err := r.createDeviceBuffer(&c.vertexBuffer, vk.DeviceSize(len(vertices)), vk.BufferUsageVertexBufferBit); err != nil {
+			return err
+		}
+		if err := r.createDeviceBuffer(&c.indexBuffer, vk.DeviceSize(len(indices)), vk.BufferUsageIndexBufferBit); err != nil {
+			return err
+		}
+		r.setName(vk.ObjectTypeBuffer, unsafe.Pointer(c.vertexBuffer.buffer), "tile chunk vertices")
+		r.setName(vk.ObjectTypeBuffer, unsafe.Pointer(c.indexBuffer.buffer), "tile chunk indices")
+		r.chunkCopies = append(r.chunkCopies, chunkCopy{c.vertexBuffer.buffer, vk.BufferCopy{
+			SrcOffset: vk.DeviceSize(len(data)),
+			Size:      c.vertexBuffer.size,
+		}})
+		data = append(data, vertices...)
+		r.chunkCopies = append(r.chunkCopies, chunkCopy{c.indexBuffer.buffer, vk.BufferCopy{
+			SrcOffset: vk.DeviceSize(len(data)),
+			Size:      c.indexBuffer.size,
+		}})
+		data = append(data, indices...)
+		// the draws are kept, the vertices aren't needed anymore
+		c.geometry = geometryBatch{}
+	}
+	return r.writeHostBuffer(&r.chunkStaging[r.currentFrame], data)
+}
+
+// recordChunkCopies records the copies of the chunk uploads of the frame, and
+// makes the draws that follow wait for them.
+func (r *RenderSystem) recordChunkCopies(buffer vk.CommandBuffer) {
+	if len(r.chunkCopies) == 0 {
+		return
+	}
+	staging := r.chunkStaging[r.currentFrame].buffer
+	for _, c := range r.chunkCopies {
+		vk.CmdCopyBuffer(buffer, staging, c.dst, 1, []vk.BufferCopy{c.region})
+	}
+	vk.CmdPipelineBarrier(buffer, vk.PipelineStageFlags(vk.PipelineStageTransferBit), vk.PipelineStageFlags(vk.PipelineStageVertexInputBit), 0, 1, []vk.MemoryBarrier{{
+		SType:         vk.StructureTypeMemoryBarrier,
+		SrcAccessMask: vk.AccessFlags(vk.AccessTransferWriteBit),
+		DstAccessMask: vk.AccessFlags(vk.AccessVertexAttributeReadBit | vk.AccessIndexReadBit),
+	}}, 0, nil, 0, nil)
+	r.chunkCopies = r.chunkCopies[:0]
+}
+
+func (r *RenderSystem) createDeviceBuffer(b *deviceBuffer, size vk.DeviceSize, usage vk.BufferUsageFlagBits) error {
+	var err error
+	b.buffer, b.memory, err = r.createBuffer(size, vk.BufferUsageFlags(vk.BufferUsageTransferDstBit|usage), vk.MemoryPropertyFlags(vk.MemoryPropertyDeviceLocalBit))
+	if err != nil {
+		return err
+	}
+	b.size = size
+	return nil
+}
+
+func (r *RenderSystem) destroyDeviceBuffer(b *deviceBuffer) {
+	if b.size == 0 {
+		return
+	}
+	vk.DestroyBuffer(r.device, b.buffer, nil)
+	vk.FreeMemory(r.device, b.memory, nil)
+	b.size = 0
+}
+
+// releaseLevel frees the chunk buffers of the level's tile layers.
+func (r *RenderSystem) releaseLevel(l *Level) {
+	vk.DeviceWaitIdle(r.device)
+	for _, layer := range l.TileLayers {
+		for _, c := range layer.chunks {
+			r.destroyDeviceBuffer(&c.vertexBuffer)
+			r.destroyDeviceBuffer(&c.indexBuffer)
+		}
+		layer.chunks = nil
+	}
+}
+
+func min32(a, b float32) float32 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max32(a, b float32) float32 {
+	if a > b {
+		return a
+	}
+	return b
+}
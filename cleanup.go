@@ -1,28 +1,65 @@
 package vulkanRenderSystem
 
 import (
+	"errors"
+	"log"
+
 	vk "github.com/vulkan-go/vulkan"
 )
 
 // Cleanup cleans up all the vulkan memory used by the VulkanRenderSystem.
 func (r *RenderSystem) Cleanup() {
+	r.watcher.close()
+	if r.recording != nil {
+		if err := r.StopRecording(); err != nil {
+			log.Println("[VULKAN RENDER SYSTEM]: unable to write the recording:", err)
+		}
+	}
+	levels := r.allLevels()
 	vk.DeviceWaitIdle(r.device)
 	r.cleanupSwapChain()
+	vk.FreeCommandBuffers(r.device, r.commandPool, uint32(len(r.commandBuffers)), r.commandBuffers)
+	r.destroyPipelines()
+	vk.DestroyRenderPass(r.device, r.renderPass, nil)
+	r.destroyPostImages()
+	for len(r.targets) > 0 {
+		r.targets[0].Destroy()
+	}
+	for _, pass := range r.targetPasses {
+		vk.DestroyRenderPass(r.device, pass, nil)
+	}
+	// the loaded files are gone with the device, so they're unloaded for the
+	// next RenderSystem to load them again
 	for _, res := range theTextureLoader.images {
 		res.Texture.Destroy(r.device)
 	}
+	theTextureLoader.images = make(map[string]TextureResource)
+	for _, res := range theLUTLoader.luts {
+		res.LUT.texture.Destroy(r.device)
+		res.LUT.texture = Texture{}
+	}
+	theLUTLoader.luts = make(map[string]LUTResource)
+	r.whiteTexture.Destroy(r.device)
 	vk.DestroyDescriptorPool(r.device, r.descriptorPool, nil)
-	for i := 0; i < len(r.descriptorSetLayouts); i++ {
-		vk.DestroyDescriptorSetLayout(r.device, r.descriptorSetLayouts[i], nil)
+	r.destroyLayouts()
+	r.destroyPipelineCache()
+	r.destroyUniformBuffers()
+	r.destroyBatchBuffers()
+	r.destroyChunkStaging()
+	// the device is idle, so the frame that's read back can be delivered, but
+	// there are no more frames for the ones still asked for
+	if len(r.screenshot.captured) > 0 {
+		r.deliverScreenshot()
+	}
+	for _, done := range r.screenshot.requests {
+		done(nil, errors.New("the RenderSystem was cleaned up before the frame was drawn"))
 	}
-	for i := 0; i < len(r.images); i++ {
-		vk.DestroyBuffer(r.device, r.uniformBuffers[i], nil)
-		vk.FreeMemory(r.device, r.uniformBuffersMemory[i], nil)
+	r.screenshot.requests = nil
+	r.destroyHostBuffer(&r.screenshot.buffer)
+	r.destroyProfiler()
+	for _, l := range levels {
+		r.releaseLevel(l)
 	}
-	vk.DestroyBuffer(r.device, r.indexBuffer, nil)
-	vk.FreeMemory(r.device, r.indexBufferMemory, nil)
-	vk.DestroyBuffer(r.device, r.vertexBuffer, nil)
-	vk.FreeMemory(r.device, r.vertexBufferMemory, nil)
 	for i := 0; i < maxFramesInFlight; i++ {
 		vk.DestroySemaphore(r.device, r.imageAvailableSemaphores[i], nil)
 		vk.DestroySemaphore(r.device, r.renderFinishedSemaphores[i], nil)
@@ -31,6 +68,7 @@ func (r *RenderSystem) Cleanup() {
 	vk.DestroyCommandPool(r.device, r.commandPool, nil)
 	vk.DestroySurface(r.instance, r.surface, nil)
 	vk.DestroyDevice(r.device, nil)
+	r.destroyDebugMessenger()
 	vk.DestroyInstance(r.instance, nil)
 	theRenderSystem = nil
 }
@@ -39,14 +77,13 @@ func (r *RenderSystem) cleanupSwapChain() {
 	for _, framebuffer := range r.swapChainFramebuffers {
 		vk.DestroyFramebuffer(r.device, framebuffer, nil)
 	}
-	vk.FreeCommandBuffers(r.device, r.commandPool, uint32(len(r.commandBuffers)), r.commandBuffers)
-	for _, pipeline := range r.graphicsPipelines {
-		vk.DestroyPipeline(r.device, pipeline, nil)
-	}
-	vk.DestroyPipelineLayout(r.device, r.pipelineLayout, nil)
-	vk.DestroyRenderPass(r.device, r.renderPass, nil)
 	for _, view := range r.swapChainImageViews {
 		vk.DestroyImageView(r.device, view, nil)
 	}
 	vk.DestroySwapchain(r.device, r.swapChain, nil)
+	r.destroyOffscreenImages()
+	// they're gone even if making them again fails, so they aren't destroyed
+	// twice
+	r.swapChainFramebuffers, r.swapChainImageViews = nil, nil
+	r.swapChain = vk.NullSwapchain
 }
@@ -0,0 +1,245 @@
+package vulkanRenderSystem
+
+import (
+	"errors"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"unsafe"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// validationLayer is the layer debug mode enables, if it's installed
+const validationLayer = "VK_LAYER_KHRONOS_validation"
+
+// DebugEnv is the environment variable that turns on debug mode when the
+// RenderSystem has no DebugOptions. It's a comma separated list of the least
+// severe messages to log, one of verbose, info, warning or error, and panic to
+// set PanicOnError, like VULKAN_RENDER_DEBUG=info,panic. Any other value just
+// turns debug mode on.
+const DebugEnv = "VULKAN_RENDER_DEBUG"
+
+// DebugSeverity is how severe a message from the validation layer or the
+// driver is
+type DebugSeverity int
+
+const (
+	// DebugVerbose is everything the layers and driver have to say
+	DebugVerbose DebugSeverity = iota + 1
+	// DebugInfo is information, like which devices and layers are loaded
+	DebugInfo
+	// DebugWarning is something that's likely a bug or slow
+	DebugWarning
+	// DebugError is something invalid
+	DebugError
+)
+
+// DebugOptions turn on the validation layer, if it's installed, and log what
+// it and the driver report. With VK_EXT_debug_utils the objects the
+// RenderSystem creates are named and its passes are labeled, for capture tools
+// like RenderDoc.
+type DebugOptions struct {
+	// Severity is the least severe message that's logged. Zero logs
+	// warnings and errors.
+	Severity DebugSeverity
+	// PanicOnError panics at the end of a frame that had a validation error,
+	// so tests fail fast
+	PanicOnError bool
+}
+
+// debugState is what debug mode has set up
+type debugState struct {
+	opts      DebugOptions
+	messenger vk.DebugUtilsMessenger
+	// utils is whether VK_EXT_debug_utils is enabled
+	utils bool
+	lock  sync.Mutex
+	// validationError is the first validation error since the end of the
+	// last frame
+	validationError string
+}
+
+// debugOptions returns the options debug mode is on with, from the
+// RenderSystem or the environment, or nil if it's off.
+func (r *RenderSystem) debugOptions() *DebugOptions {
+	if r.Debug != nil {
+		return r.Debug
+	}
+	env, ok := os.LookupEnv(DebugEnv)
+	if !ok || env == "" || env == "0" {
+		return nil
+	}
+	opts := &DebugOptions{}
+	for _, field := range strings.Split(env, ",") {
+		switch strings.ToLower(strings.TrimSpace(field)) {
+		case "verbose":
+			opts.Severity = DebugVerbose
+		case "info":
+			opts.Severity = DebugInfo
+		case "warning":
+			opts.Severity = DebugWarning
+		case "error":
+			opts.Severity = DebugError
+		case "panic":
+			opts.PanicOnError = true
+		}
+	}
+	return opts
+}
+
+// enableDebug adds the validation layer and VK_EXT_debug_utils to the
+// instance, if debug mode is on and they're available.
+func (r *RenderSystem) enableDebug(createInfo *vk.InstanceCreateInfo) {
+	opts := r.debugOptions()
+	if opts == nil {
+		return
+	}
+	r.debug = &debugState{opts: *opts}
+	layers := []string{}
+	if hasLayer(validationLayer) {
+		layers = append(layers, validationLayer)
+	} else {
+		log.Println("[VULKAN RENDER SYSTEM]: " + validationLayer + " isn't installed, so vulkan calls won't be validated")
+	}
+	if hasInstanceExtension(vk.ExtDebugUtilsExtensionName, layers...) {
+		r.debug.utils = true
+		createInfo.PpEnabledExtensionNames = safeStrings(append(createInfo.PpEnabledExtensionNames, vk.ExtDebugUtilsExtensionName))
+		createInfo.EnabledExtensionCount = uint32(len(createInfo.PpEnabledExtensionNames))
+	} else {
+		log.Println("[VULKAN RENDER SYSTEM]: " + vk.ExtDebugUtilsExtensionName + " isn't available, so messages won't be logged")
+	}
+	createInfo.PpEnabledLayerNames = safeStrings(layers)
+	createInfo.EnabledLayerCount = uint32(len(layers))
+}
+
+// createDebugMessenger installs the messenger that logs messages from the
+// layers and driver, once the instance is created.
+func (r *RenderSystem) createDebugMessenger() error {
+	if r.debug == nil || !r.debug.utils {
+		return nil
+	}
+	min := r.debug.opts.Severity
+	if min == 0 {
+		min = DebugWarning
+	}
+	var severities vk.DebugUtilsMessageSeverityFlagBits
+	for s, bit := range debugSeverityBits {
+		if s >= min {
+			severities |= bit
+		}
+	}
+	if res := vk.CreateDebugUtilsMessenger(r.instance, &vk.DebugUtilsMessengerCreateInfo{
+		SType:           vk.StructureTypeDebugUtilsMessengerCreateInfo,
+		MessageSeverity: vk.DebugUtilsMessageSeverityFlags(severities),
+		MessageType: vk.DebugUtilsMessageTypeFlags(vk.DebugUtilsMessageTypeGeneralBit |
+			vk.DebugUtilsMessageTypeValidationBit | vk.DebugUtilsMessageTypePerformanceBit),
+		PfnUserCallback: r.debugMessage,
+	}, nil, &r.debug.messenger); res != vk.Success {
+		return errors.New("unable to create the debug messenger")
+	}
+	return nil
+}
+
+// debugSeverityBits are the severities of debug_utils messages
+var debugSeverityBits = map[DebugSeverity]vk.DebugUtilsMessageSeverityFlagBits{
+	DebugVerbose: vk.DebugUtilsMessageSeverityVerboseBit,
+	DebugInfo:    vk.DebugUtilsMessageSeverityInfoBit,
+	DebugWarning: vk.DebugUtilsMessageSeverityWarningBit,
+	DebugError:   vk.DebugUtilsMessageSeverityErrorBit,
+}
+
+// debugMessage logs a message from the layers or driver. It's called from
+// inside vulkan calls, so validation errors are kept to panic on at the end of
+// the frame rather than unwinding through the driver.
+func (r *RenderSystem) debugMessage(severity vk.DebugUtilsMessageSeverityFlagBits, kind vk.DebugUtilsMessageTypeFlags, data *vk.DebugUtilsMessengerCallbackData, _ unsafe.Pointer) vk.Bool32 {
+	data.Deref()
+	level := "info"
+	switch {
+	case severity&vk.DebugUtilsMessageSeverityErrorBit != 0:
+		level = "error"
+	case severity&vk.DebugUtilsMessageSeverityWarningBit != 0:
+		level = "warning"
+	case severity&vk.DebugUtilsMessageSeverityVerboseBit != 0:
+		level = "verbose"
+	}
+	switch {
+	case kind&vk.DebugUtilsMessageTypeFlags(vk.DebugUtilsMessageTypeValidationBit) != 0:
+		level = "validation " + level
+	case kind&vk.DebugUtilsMessageTypeFlags(vk.DebugUtilsMessageTypePerformanceBit) != 0:
+		level = "performance " + level
+	}
+	log.Println("[VULKAN RENDER SYSTEM]: " + level + ": " + data.PMessage)
+	if level == "validation error" {
+		r.debug.lock.Lock()
+		if r.debug.validationError == "" {
+			r.debug.validationError = data.PMessage
+		}
+		r.debug.lock.Unlock()
+	}
+	return vk.Bool32(vk.False)
+}
+
+// checkValidation panics if there was a validation error and PanicOnError is
+// set.
+func (r *RenderSystem) checkValidation() {
+	if r.debug == nil || !r.debug.opts.PanicOnError {
+		return
+	}
+	r.debug.lock.Lock()
+	msg := r.debug.validationError
+	r.debug.validationError = ""
+	r.debug.lock.Unlock()
+	if msg != "" {
+		panic("[VULKAN RENDER SYSTEM] validation error: " + msg)
+	}
+}
+
+// destroyDebugMessenger removes the messenger before the instance is
+// destroyed.
+func (r *RenderSystem) destroyDebugMessenger() {
+	if r.debug != nil && r.debug.messenger != nil {
+		vk.DestroyDebugUtilsMessenger(r.instance, r.debug.messenger, nil)
+	}
+}
+
+// hasLayer returns whether the instance layer is installed.
+func hasLayer(name string) bool {
+	var count uint32
+	if vk.EnumerateInstanceLayerProperties(&count, nil) != vk.Success || count == 0 {
+		return false
+	}
+	layers := make([]vk.LayerProperties, count)
+	vk.EnumerateInstanceLayerProperties(&count, layers)
+	for _, l := range layers {
+		l.Deref()
+		if vk.ToString(l.LayerName[:]) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// hasInstanceExtension returns whether the instance extension is available,
+// from the driver or one of the layers.
+func hasInstanceExtension(name string, layers ...string) bool {
+	for _, layer := range append([]string{""}, layers...) {
+		var count uint32
+		if layer != "" {
+			layer = safeString(layer)
+		}
+		if vk.EnumerateInstanceExtensionProperties(layer, &count, nil) != vk.Success || count == 0 {
+			continue
+		}
+		exts := make([]vk.ExtensionProperties, count)
+		vk.EnumerateInstanceExtensionProperties(layer, &count, exts)
+		for _, ext := range exts {
+			ext.Deref()
+			if vk.ToString(ext.ExtensionName[:]) == name {
+				return true
+			}
+		}
+	}
+	return false
+}
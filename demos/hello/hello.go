@@ -5,6 +5,7 @@ package main
 import (
 	"github.com/EngoEngine/ecs"
 	"github.com/EngoEngine/engo"
+	"github.com/EngoEngine/systems/physics"
 
 	"github.com/Noofbiz/vulkanRenderSystem"
 )
@@ -15,6 +16,8 @@ type DefaultScene struct {
 
 type Guy struct {
 	ecs.BasicEntity
+	vulkanRenderSystem.RenderComponent
+	physics.SpaceComponent
 }
 
 func (d *DefaultScene) Preload() {
@@ -24,6 +27,16 @@ func (d *DefaultScene) Preload() {
 func (d *DefaultScene) Setup(u engo.Updater) {
 	w, _ := u.(*ecs.World)
 	w.AddSystem(&d.renderSystem)
+
+	res, err := engo.Files.Resource("texture.jpg")
+	if err != nil {
+		panic(err)
+	}
+	tex := res.(vulkanRenderSystem.TextureResource).Texture
+	guy := Guy{BasicEntity: ecs.NewBasic()}
+	guy.Drawable = tex
+	guy.Position = engo.Point{X: 100, Y: 100}
+	d.renderSystem.Add(&guy.BasicEntity, &guy.RenderComponent, &guy.SpaceComponent)
 }
 
 func (*DefaultScene) Type() string { return "GameWorld" }
@@ -0,0 +1,137 @@
+package vulkanRenderSystem
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/Noofbiz/vulkanRenderSystem/internal/shaders"
+)
+
+// bloomScale is the size of the images bloom blurs, relative to the screen
+const bloomScale = 0.5
+
+// builtinEffects are the shaders of the built in effects. They're created once,
+// so effects of the same kind share their pipelines.
+var builtinEffects = struct {
+	sync.Mutex
+	shaders map[string]*Shader
+}{shaders: make(map[string]*Shader)}
+
+// builtinPass returns a pass of the built in effect shader name.spv, drawn at
+// the scale.
+func builtinPass(name string, scale float32) effectPass {
+	builtinEffects.Lock()
+	defer builtinEffects.Unlock()
+	s, ok := builtinEffects.shaders[name]
+	if !ok {
+		frag, err := shaders.Asset(name + ".spv")
+		if err == nil {
+			s, err = newEffectShader(frag)
+		}
+		if err != nil {
+			panic("[VULKAN RENDER SYSTEM] unable to create built in effect " + name + ": " + err.Error())
+		}
+		builtinEffects.shaders[name] = s
+	}
+	return effectPass{shader: s, scale: scale}
+}
+
+// NewBlurEffect creates a gaussian blur over radius pixels either side. It
+// blurs horizontally and then vertically. Params[0] is the radius.
+func NewBlurEffect(radius float32) *Effect {
+	return &Effect{
+		Params: []float32{radius},
+		passes: []effectPass{builtinPass("blur", 1), builtinPass("blur", 1)},
+	}
+}
+
+// NewBloomEffect makes what's brighter than the threshold glow. The bright
+// parts are drawn at half size, blurred over radius of those pixels and added
+// back on times intensity. Params are the radius, threshold and intensity.
+func NewBloomEffect(radius, threshold, intensity float32) *Effect {
+	return &Effect{
+		Params: []float32{radius, threshold, intensity},
+		passes: []effectPass{
+			builtinPass("threshold", bloomScale),
+			builtinPass("blur", bloomScale),
+			builtinPass("blur", bloomScale),
+			builtinPass("bloom", 1),
+		},
+	}
+}
+
+// NewVignetteEffect darkens the edges of the screen by strength, from 0 to 1.
+// The darkening starts softness before radius from the center and is full at
+// radius, where 0.5 is the middle of the edges. Params are the strength,
+// radius and softness.
+func NewVignetteEffect(strength, radius, softness float32) *Effect {
+	return &Effect{
+		Params: []float32{strength, radius, softness},
+		passes: []effectPass{builtinPass("vignette", 1)},
+	}
+}
+
+// NewCRTEffect makes the screen look like an old monitor. The screen bulges
+// by curvature, and every other line is darkened by scanlines, from 0 to 1.
+// Params are the curvature and scanlines.
+func NewCRTEffect(curvature, scanlines float32) *Effect {
+	return &Effect{
+		Params: []float32{curvature, scanlines},
+		passes: []effectPass{builtinPass("crt", 1)},
+	}
+}
+
+// NewChromaticAberrationEffect splits the red and blue of the image apart
+// towards the edges of the screen, by offset pixels at the middle of each
+// edge. Params[0] is the offset.
+func NewChromaticAberrationEffect(offset float32) *Effect {
+	return &Effect{
+		Params: []float32{offset},
+		passes: []effectPass{builtinPass("chromatic", 1)},
+	}
+}
+
+// NewPixelateEffect draws the screen in blocks of size pixels. Params[0] is
+// the size.
+func NewPixelateEffect(size float32) *Effect {
+	return &Effect{
+		Params: []float32{size},
+		passes: []effectPass{builtinPass("pixelate", 1)},
+	}
+}
+
+// NewGrayscaleEffect takes the color out of the screen, by amount from 0 to 1.
+// Params[0] is the amount.
+func NewGrayscaleEffect(amount float32) *Effect {
+	return &Effect{
+		Params: []float32{amount},
+		passes: []effectPass{builtinPass("grayscale", 1)},
+	}
+}
+
+// NewGradeEffect color grades the screen with a LUT.
+func NewGradeEffect(lut *LUT) (*Effect, error) {
+	return NewBlendedGradeEffect(lut, lut, 0)
+}
+
+// NewBlendedGradeEffect color grades the screen with a mix of two LUTs, like
+// a day and a night grade. Params[0] is the weight of the second LUT, from 0
+// to 1, and can be changed every frame to move between them. If Params[1] is
+// more than 0, the weight cycles from the first LUT to the second and back
+// every Params[1] seconds instead. Params are the weight and the cycle. Both
+// LUTs have to be loaded.
+func NewBlendedGradeEffect(a, b *LUT, weight float32) (*Effect, error) {
+	for i, l := range [2]*LUT{a, b} {
+		if l == nil {
+			return nil, fmt.Errorf("grade effects need two LUTs, but LUT %d is nil", i)
+		}
+		if l.texture == (Texture{}) {
+			return nil, fmt.Errorf("LUT %d of the grade effect has been destroyed", i)
+		}
+	}
+	return &Effect{
+		Params: []float32{weight, 0},
+		LUTs:   [2]*LUT{a, b},
+		passes: []effectPass{builtinPass("grade", 1)},
+	}, nil
+}
@@ -0,0 +1,252 @@
+// Package golden checks what the RenderSystem draws against reference images
+// in tests. Scenes are rendered headless, so the tests run on machines
+// without a GPU through a software vulkan driver like lavapipe, which can be
+// picked with VK_ICD_FILENAMES.
+//
+// Running the tests with -golden.update writes the reference images instead
+// of checking them. Tests are skipped where vulkan can't be set up, unless
+// they're run with -golden.require.
+package golden
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EngoEngine/ecs"
+
+	"github.com/Noofbiz/vulkanRenderSystem"
+)
+
+var (
+	update  = flag.Bool("golden.update", false, "write the golden reference images instead of checking them")
+	require = flag.Bool("golden.require", false, "fail the golden tests instead of skipping them when vulkan can't be set up")
+)
+
+// unavailableError is returned by Render when the RenderSystem can't be set
+// up, like on machines without a vulkan driver.
+type unavailableError struct {
+	reason interface{}
+}
+
+func (e unavailableError) Error() string {
+	return fmt.Sprintf("vulkan is unavailable: %v", e.reason)
+}
+
+// deliveryFrames is how many more frames are drawn for the last frame to be
+// read back. It's more than the frames the RenderSystem has in flight.
+const deliveryFrames = 8
+
+// setupErr is why the RenderSystem couldn't be set up. A RenderSystem that
+// fails stays the one added, so every later render fails the same way.
+var setupErr error
+
+// Options are how a scene is rendered and compared. Fields that are zero use
+// the defaults, except for Tolerance and Threshold.
+type Options struct {
+	// Width and Height are the size in pixels of the frame, 256 by 256 by
+	// default
+	Width, Height int
+	// Frames is the number of frames rendered, 1 by default
+	Frames int
+	// Step is the fixed timestep in seconds each frame advances the world by,
+	// 1/60 by default
+	Step float32
+	// Tolerance is how far a channel of a pixel can be from the reference,
+	// out of 255, and still match it
+	Tolerance uint8
+	// Threshold is the fraction of the pixels that can mismatch before the
+	// frame fails
+	Threshold float64
+	// Dir is the directory of the reference images, testdata by default.
+	// Frames that fail are written to its failures directory.
+	Dir string
+}
+
+func (o Options) withDefaults() Options {
+	if o.Width == 0 {
+		o.Width = 256
+	}
+	if o.Height == 0 {
+		o.Height = 256
+	}
+	if o.Frames == 0 {
+		o.Frames = 1
+	}
+	if o.Step == 0 {
+		o.Step = 1.0 / 60
+	}
+	if o.Dir == "" {
+		o.Dir = "testdata"
+	}
+	return o
+}
+
+// Scene sets up what's drawn. It's called once the RenderSystem has been
+// added to the world.
+type Scene func(w *ecs.World, r *vulkanRenderSystem.RenderSystem)
+
+// Render renders the scene headless for opts.Frames frames and returns the
+// last one.
+func Render(scene Scene, opts Options) (img image.Image, err error) {
+	opts = opts.withDefaults()
+	if setupErr != nil {
+		return nil, setupErr
+	}
+	r := &vulkanRenderSystem.RenderSystem{
+		Headless: &vulkanRenderSystem.HeadlessOptions{Width: opts.Width, Height: opts.Height},
+	}
+	w := &ecs.World{}
+	if err = addSystem(w, r); err != nil {
+		setupErr = err
+		return nil, err
+	}
+	defer r.Cleanup()
+	defer func() {
+		if v := recover(); v != nil {
+			err = fmt.Errorf("unable to render: %v", v)
+		}
+	}()
+	if scene != nil {
+		scene(w, r)
+	}
+	delivered := false
+	for i := 0; i < opts.Frames; i++ {
+		if i == opts.Frames-1 {
+			r.Screenshot(func(shot image.Image, shotErr error) {
+				img, err, delivered = shot, shotErr, true
+			})
+		}
+		w.Update(opts.Step)
+	}
+	// the last frame is delivered once the GPU has finished it, which takes
+	// a frame or two more. Only the RenderSystem draws them, so the world
+	// doesn't advance.
+	for i := 0; i < deliveryFrames && !delivered; i++ {
+		r.Update(0)
+	}
+	if !delivered {
+		return nil, errors.New("the last frame wasn't read back")
+	}
+	return img, err
+}
+
+// addSystem adds the RenderSystem to the world, which panics when vulkan
+// can't be set up.
+func addSystem(w *ecs.World, r *vulkanRenderSystem.RenderSystem) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = unavailableError{v}
+		}
+	}()
+	w.AddSystem(r)
+	return nil
+}
+
+// Compare returns the number of pixels of img that are further than tolerance
+// from ref in any channel, and an image of ref with them marked in red.
+// Pixels that are only in one of the images mismatch.
+func Compare(img, ref image.Image, tolerance uint8) (int, *image.RGBA) {
+	a, b := img.Bounds(), ref.Bounds()
+	w, h := a.Dx(), a.Dy()
+	if b.Dx() > w {
+		w = b.Dx()
+	}
+	if b.Dy() > h {
+		h = b.Dy()
+	}
+	diff := image.NewRGBA(image.Rect(0, 0, w, h))
+	mismatched := 0
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			p, q := image.Pt(a.Min.X+x, a.Min.Y+y), image.Pt(b.Min.X+x, b.Min.Y+y)
+			if !p.In(a) || !q.In(b) || !matches(img.At(p.X, p.Y), ref.At(q.X, q.Y), tolerance) {
+				mismatched++
+				diff.Set(x, y, color.RGBA{R: 0xff, A: 0xff})
+				continue
+			}
+			// matching pixels are dimmed, so the mismatches stand out
+			c := color.RGBAModel.Convert(ref.At(q.X, q.Y)).(color.RGBA)
+			diff.Set(x, y, color.RGBA{R: c.R / 3, G: c.G / 3, B: c.B / 3, A: 0xff})
+		}
+	}
+	return mismatched, diff
+}
+
+// matches reports whether every channel of a and b is within tolerance.
+func matches(a, b color.Color, tolerance uint8) bool {
+	ca := color.RGBAModel.Convert(a).(color.RGBA)
+	cb := color.RGBAModel.Convert(b).(color.RGBA)
+	for _, d := range [4][2]uint8{{ca.R, cb.R}, {ca.G, cb.G}, {ca.B, cb.B}, {ca.A, cb.A}} {
+		if d[0] > d[1] && d[0]-d[1] > tolerance || d[1] > d[0] && d[1]-d[0] > tolerance {
+			return false
+		}
+	}
+	return true
+}
+
+// Check renders the scene and compares it to the reference image name.png in
+// opts.Dir. The test fails if more than opts.Threshold of the pixels
+// mismatch, and the frame and a diff image are written to the failures
+// directory in opts.Dir. The test is skipped if vulkan can't be set up and
+// -golden.require isn't set.
+func Check(t testing.TB, name string, scene Scene, opts Options) {
+	t.Helper()
+	opts = opts.withDefaults()
+	img, err := Render(scene, opts)
+	if _, ok := err.(unavailableError); ok && !*require {
+		t.Skip(err)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(opts.Dir, name+".png")
+	if *update {
+		if err = vulkanRenderSystem.WritePNG(path, img); err != nil {
+			t.Fatal(err)
+		}
+		t.Logf("updated %s", path)
+		return
+	}
+	ref, err := readPNG(path)
+	if err != nil {
+		t.Fatalf("unable to read the reference image, run with -golden.update to write it: %v", err)
+	}
+	mismatched, diff := Compare(img, ref, opts.Tolerance)
+	total := diff.Bounds().Dx() * diff.Bounds().Dy()
+	if float64(mismatched) <= opts.Threshold*float64(total) {
+		return
+	}
+	failures := filepath.Join(opts.Dir, "failures")
+	if err = vulkanRenderSystem.WritePNG(filepath.Join(failures, name+".png"), img); err == nil {
+		err = vulkanRenderSystem.WritePNG(filepath.Join(failures, name+".diff.png"), diff)
+	}
+	if err != nil {
+		t.Errorf("unable to write the failed frame: %v", err)
+	}
+	if img.Bounds().Size() != ref.Bounds().Size() {
+		t.Errorf("%s is %v, but the reference is %v", name, img.Bounds().Size(), ref.Bounds().Size())
+	}
+	t.Errorf("%d of %d pixels of %s are further than %d from the reference, the frame and a diff are in %s",
+		mismatched, total, name, opts.Tolerance, failures)
+}
+
+// readPNG decodes the .png file at path.
+func readPNG(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	img, err := png.Decode(f)
+	if err != nil {
+		return nil, errors.New("unable to decode " + path + ": " + err.Error())
+	}
+	return img, nil
+}
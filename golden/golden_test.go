@@ -0,0 +1,124 @@
+package golden
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/engo"
+	"github.com/EngoEngine/systems/physics"
+
+	"github.com/Noofbiz/vulkanRenderSystem"
+)
+
+// fill returns a w by h image of a single color.
+func fill(w, h int, c color.RGBA) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.SetRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestCompare(t *testing.T) {
+	gray := color.RGBA{100, 100, 100, 255}
+	ref := fill(4, 4, gray)
+	img := fill(4, 4, gray)
+	if n, _ := Compare(img, ref, 0); n != 0 {
+		t.Errorf("identical images have %d mismatched pixels, want 0", n)
+	}
+
+	img.SetRGBA(1, 2, color.RGBA{102, 100, 98, 255})
+	if n, _ := Compare(img, ref, 2); n != 0 {
+		t.Errorf("a pixel 2 away mismatched at a tolerance of 2")
+	}
+	n, diff := Compare(img, ref, 1)
+	if n != 1 {
+		t.Errorf("got %d mismatched pixels at a tolerance of 1, want 1", n)
+	}
+	if c := diff.RGBAAt(1, 2); c != (color.RGBA{R: 0xff, A: 0xff}) {
+		t.Errorf("the mismatched pixel is %v in the diff, want red", c)
+	}
+	// matching pixels are the reference dimmed
+	if c := diff.RGBAAt(0, 0); c != (color.RGBA{33, 33, 33, 255}) {
+		t.Errorf("a matching pixel is %v in the diff, want the reference dimmed", c)
+	}
+
+	// alpha is compared too
+	img = fill(4, 4, color.RGBA{100, 100, 100, 200})
+	if n, _ := Compare(img, ref, 10); n != 16 {
+		t.Errorf("got %d mismatched pixels for a different alpha, want 16", n)
+	}
+}
+
+func TestCompareSizes(t *testing.T) {
+	ref := fill(4, 4, color.RGBA{A: 255})
+	n, diff := Compare(fill(4, 2, color.RGBA{A: 255}), ref, 0)
+	if n != 8 {
+		t.Errorf("got %d mismatched pixels for a smaller image, want 8", n)
+	}
+	if size := diff.Bounds().Size(); size != image.Pt(4, 4) {
+		t.Errorf("the diff is %v, want the larger of the sizes", size)
+	}
+	if c := diff.RGBAAt(3, 3); c != (color.RGBA{R: 0xff, A: 0xff}) {
+		t.Errorf("a pixel only in the reference is %v in the diff, want red", c)
+	}
+	n, _ = Compare(fill(5, 4, color.RGBA{A: 255}), ref, 0)
+	if n != 4 {
+		t.Errorf("got %d mismatched pixels for a wider image, want 4", n)
+	}
+}
+
+func TestCompareSubImage(t *testing.T) {
+	// images are compared from their top left, wherever their bounds start
+	big := fill(8, 8, color.RGBA{A: 255})
+	for y := 4; y < 8; y++ {
+		for x := 4; x < 8; x++ {
+			big.SetRGBA(x, y, color.RGBA{R: 50, G: 60, B: 70, A: 255})
+		}
+	}
+	ref := fill(4, 4, color.RGBA{R: 50, G: 60, B: 70, A: 255})
+	if n, _ := Compare(big.SubImage(image.Rect(4, 4, 8, 8)), ref, 0); n != 0 {
+		t.Errorf("got %d mismatched pixels for an offset image, want 0", n)
+	}
+	if n, _ := Compare(ref, big.SubImage(image.Rect(4, 4, 8, 8)), 0); n != 0 {
+		t.Errorf("got %d mismatched pixels for an offset reference, want 0", n)
+	}
+}
+
+type stripe struct {
+	ecs.BasicEntity
+	vulkanRenderSystem.RenderComponent
+	physics.SpaceComponent
+}
+
+// TestStripes draws an opaque red rectangle over the left half of the frame
+// and a half transparent blue one over the right half, which is blended with
+// the black it's cleared to. The stripes are the full height of the frame, so
+// the image is the same whichever way up it's read back.
+func TestStripes(t *testing.T) {
+	Check(t, "stripes", func(w *ecs.World, r *vulkanRenderSystem.RenderSystem) {
+		for _, s := range []struct {
+			x float32
+			c color.Color
+		}{
+			{0, color.NRGBA{R: 255, A: 255}},
+			{32, color.NRGBA{B: 255, A: 128}},
+		} {
+			e := stripe{BasicEntity: ecs.NewBasic()}
+			e.RenderComponent = vulkanRenderSystem.RenderComponent{
+				Drawable: vulkanRenderSystem.Rectangle{},
+				Color:    s.c,
+			}
+			e.SpaceComponent = physics.SpaceComponent{
+				Position: engo.Point{X: s.x, Y: 0},
+				Width:    32,
+				Height:   64,
+			}
+			r.Add(&e.BasicEntity, &e.RenderComponent, &e.SpaceComponent)
+		}
+	}, Options{Width: 64, Height: 64, Tolerance: 1})
+}
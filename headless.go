@@ -0,0 +1,110 @@
+package vulkanRenderSystem
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"unsafe"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// headlessFormat is the format of the images headless frames are drawn into
+const headlessFormat = vk.FormatR8g8b8a8Unorm
+
+// HeadlessOptions set up rendering without a window. There's no surface, so
+// any device that can draw is used, including software ones like lavapipe.
+type HeadlessOptions struct {
+	// Width and Height are the size in pixels of the frames
+	Width, Height int
+	// OnFrame, if it's set, is called with every frame once it's drawn. The
+	// image is the callback's to keep.
+	OnFrame func(image.Image)
+}
+
+// offscreenImage is an image a headless frame is drawn into, in place of a
+// swap chain image
+type offscreenImage struct {
+	memory vk.DeviceMemory
+	// readback is what the frame is copied into for OnFrame
+	readback hostBuffer
+}
+
+// createOffscreenImages creates the images headless frames are drawn into, one
+// per frame in flight, in place of a swap chain.
+func (r *RenderSystem) createOffscreenImages() error {
+	if r.Headless.Width <= 0 || r.Headless.Height <= 0 {
+		return errors.New("headless frames need a width and height")
+	}
+	r.swapChainImageFormat = headlessFormat
+	r.swapChainExtent = vk.Extent2D{Width: uint32(r.Headless.Width), Height: uint32(r.Headless.Height)}
+	r.swapChainReadable = true
+	r.images = make([]vk.Image, maxFramesInFlight)
+	r.offscreen = make([]offscreenImage, maxFramesInFlight)
+	size, _, _ := pixelLayout(headlessFormat)
+	for i := range r.images {
+		var err error
+		r.images[i], r.offscreen[i].memory, err = r.createImage(r.swapChainExtent.Width, r.swapChainExtent.Height, headlessFormat,
+			vk.ImageUsageFlags(vk.ImageUsageColorAttachmentBit|vk.ImageUsageTransferSrcBit))
+		if err != nil {
+			return err
+		}
+		b := &r.offscreen[i].readback
+		b.size = vk.DeviceSize(size * r.Headless.Width * r.Headless.Height)
+		b.usage = vk.BufferUsageFlags(vk.BufferUsageTransferDstBit)
+		b.buffer, b.memory, err = r.createBuffer(b.size, b.usage, vk.MemoryPropertyFlags(vk.MemoryPropertyHostVisibleBit|vk.MemoryPropertyHostCoherentBit))
+		if err != nil {
+			b.size = 0
+			return err
+		}
+		r.setName(vk.ObjectTypeImage, unsafe.Pointer(r.images[i]), fmt.Sprintf("headless frame %d", i))
+		r.setName(vk.ObjectTypeBuffer, unsafe.Pointer(b.buffer), fmt.Sprintf("headless readback %d", i))
+	}
+	return nil
+}
+
+// destroyOffscreenImages destroys the images of headless frames.
+func (r *RenderSystem) destroyOffscreenImages() {
+	for i, img := range r.offscreen {
+		vk.DestroyImage(r.device, r.images[i], nil)
+		vk.FreeMemory(r.device, img.memory, nil)
+		r.destroyHostBuffer(&r.offscreen[i].readback)
+	}
+	r.offscreen = nil
+}
+
+// presentLayout is the layout frames are left in once they're drawn: ready to
+// present, or to be copied from when they're drawn offscreen.
+func (r *RenderSystem) presentLayout() vk.ImageLayout {
+	if r.Headless != nil {
+		return vk.ImageLayoutTransferSrcOptimal
+	}
+	return vk.ImageLayoutPresentSrc
+}
+
+// recordOffscreenReadback records copying a headless frame into its readback
+// buffer, if there's a callback for it.
+func (r *RenderSystem) recordOffscreenReadback(buffer vk.CommandBuffer, imageIdx uint32) {
+	if r.Headless == nil || r.Headless.OnFrame == nil {
+		return
+	}
+	recordReadback(buffer, r.images[imageIdx], vk.ImageLayoutTransferSrcOptimal, r.offscreen[imageIdx].readback.buffer, r.swapChainExtent)
+}
+
+// finishOffscreen is what's done with a headless frame once it's submitted,
+// in place of presenting it. It's passed to OnFrame once it's drawn.
+func (r *RenderSystem) finishOffscreen(imageIdx uint32) error {
+	if r.Headless.OnFrame == nil {
+		return nil
+	}
+	vk.WaitForFences(r.device, 1, r.imagesInFlight[imageIdx:imageIdx+1], vk.True, vk.MaxUint64)
+	b := &r.offscreen[imageIdx].readback
+	var data unsafe.Pointer
+	if res := vk.MapMemory(r.device, b.memory, 0, b.size, 0, &data); res != vk.Success {
+		return errors.New("unable to map readback memory")
+	}
+	img := toRGBA((*[1 << 30]byte)(data)[:b.size:b.size], r.swapChainImageFormat, r.swapChainExtent)
+	vk.UnmapMemory(r.device, b.memory)
+	r.Headless.OnFrame(img)
+	return nil
+}
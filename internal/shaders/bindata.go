@@ -1,7 +1,18 @@
 // Code generated by go-bindata. (@generated) DO NOT EDIT.
 // sources:
+// blit.spv
+// bloom.spv
+// blur.spv
+// chromatic.spv
+// crt.spv
 // frag.spv
+// fullscreen.spv
+// grade.spv
+// grayscale.spv
+// pixelate.spv
+// threshold.spv
 // vert.spv
+// vignette.spv
 package shaders
 
 import (
@@ -44,7 +55,92 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _fragSpv = []byte("\x03\x02#\a\x00\x00\x01\x00\a\x00\b\x00\x17\x00\x00\x00\x00\x00\x00\x00\x11\x00\x02\x00\x01\x00\x00\x00\v\x00\x06\x00\x01\x00\x00\x00GLSL.std.450\x00\x00\x00\x00\x0e\x00\x03\x00\x00\x00\x00\x00\x01\x00\x00\x00\x0f\x00\b\x00\x04\x00\x00\x00\x04\x00\x00\x00main\x00\x00\x00\x00\t\x00\x00\x00\x11\x00\x00\x00\x16\x00\x00\x00\x10\x00\x03\x00\x04\x00\x00\x00\a\x00\x00\x00\x03\x00\x03\x00\x02\x00\x00\x00\xc2\x01\x00\x00\x04\x00\t\x00GL_ARB_separate_shader_objects\x00\x00\x05\x00\x04\x00\x04\x00\x00\x00main\x00\x00\x00\x00\x05\x00\x05\x00\t\x00\x00\x00outColor\x00\x00\x00\x00\x05\x00\x05\x00\r\x00\x00\x00texSampler\x00\x00\x05\x00\x06\x00\x11\x00\x00\x00fragTexCoord\x00\x00\x00\x00\x05\x00\x05\x00\x16\x00\x00\x00fragColor\x00\x00\x00G\x00\x04\x00\t\x00\x00\x00\x1e\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\r\x00\x00\x00\"\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\r\x00\x00\x00!\x00\x00\x00\x01\x00\x00\x00G\x00\x04\x00\x11\x00\x00\x00\x1e\x00\x00\x00\x01\x00\x00\x00G\x00\x04\x00\x16\x00\x00\x00\x1e\x00\x00\x00\x00\x00\x00\x00\x13\x00\x02\x00\x02\x00\x00\x00!\x00\x03\x00\x03\x00\x00\x00\x02\x00\x00\x00\x16\x00\x03\x00\x06\x00\x00\x00 \x00\x00\x00\x17\x00\x04\x00\a\x00\x00\x00\x06\x00\x00\x00\x04\x00\x00\x00 \x00\x04\x00\b\x00\x00\x00\x03\x00\x00\x00\a\x00\x00\x00;\x00\x04\x00\b\x00\x00\x00\t\x00\x00\x00\x03\x00\x00\x00\x19\x00\t\x00\n\x00\x00\x00\x06\x00\x00\x00\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x01\x00\x00\x00\x00\x00\x00\x00\x1b\x00\x03\x00\v\x00\x00\x00\n\x00\x00\x00 \x00\x04\x00\f\x00\x00\x00\x00\x00\x00\x00\v\x00\x00\x00;\x00\x04\x00\f\x00\x00\x00\r\x00\x00\x00\x00\x00\x00\x00\x17\x00\x04\x00\x0f\x00\x00\x00\x06\x00\x00\x00\x02\x00\x00\x00 \x00\x04\x00\x10\x00\x00\x00\x01\x00\x00\x00\x0f\x00\x00\x00;\x00\x04\x00\x10\x00\x00\x00\x11\x00\x00\x00\x01\x00\x00\x00\x17\x00\x04\x00\x14\x00\x00\x00\x06\x00\x00\x00\x03\x00\x00\x00 \x00\x04\x00\x15\x00\x00\x00\x01\x00\x00\x00\x14\x00\x00\x00;\x00\x04\x00\x15\x00\x00\x00\x16\x00\x00\x00\x01\x00\x00\x006\x00\x05\x00\x02\x00\x00\x00\x04\x00\x00\x00\x00\x00\x00\x00\x03\x00\x00\x00\xf8\x00\x02\x00\x05\x00\x00\x00=\x00\x04\x00\v\x00\x00\x00\x0e\x00\x00\x00\r\x00\x00\x00=\x00\x04\x00\x0f\x00\x00\x00\x12\x00\x00\x00\x11\x00\x00\x00W\x00\x05\x00\a\x00\x00\x00\x13\x00\x00\x00\x0e\x00\x00\x00\x12\x00\x00\x00>\x00\x03\x00\t\x00\x00\x00\x13\x00\x00\x00\xfd\x00\x01\x008\x00\x01\x00")
+var _blitSpv = []byte("\x03\x02#\a\x00\x00\x01\x00\x00\x00\x00\x00\x13\x00\x00\x00\x00\x00\x00\x00\x11\x00\x02\x00\x01\x00\x00\x00\x0e\x00\x03\x00\x00\x00\x00\x00\x01\x00\x00\x00\x0f\x00\a\x00\x04\x00\x00\x00\x01\x00\x00\x00main\x00\x00\x00\x00\x02\x00\x00\x00\x03\x00\x00\x00\x10\x00\x03\x00\x01\x00\x00\x00\a\x00\x00\x00\x03\x00\x03\x00\x02\x00\x00\x00\xc2\x01\x00\x00\x05\x00\x04\x00\x01\x00\x00\x00main\x00\x00\x00\x00\x05\x00\x05\x00\x02\x00\x00\x00outColor\x00\x00\x00\x00\x05\x00\x04\x00\x04\x00\x00\x00source\x00\x00\x05\x00\x03\x00\x03\x00\x00\x00uv\x00\x00G\x00\x04\x00\x02\x00\x00\x00\x1e\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\x04\x00\x00\x00\"\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\x04\x00\x00\x00!\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\x03\x00\x00\x00\x1e\x00\x00\x00\x00\x00\x00\x00\x13\x00\x02\x00\x05\x00\x00\x00!\x00\x03\x00\x06\x00\x00\x00\x05\x00\x00\x00\x16\x00\x03\x00\a\x00\x00\x00 \x00\x00\x00\x17\x00\x04\x00\b\x00\x00\x00\a\x00\x00\x00\x02\x00\x00\x00\x17\x00\x04\x00\t\x00\x00\x00\a\x00\x00\x00\x04\x00\x00\x00 \x00\x04\x00\n\x00\x00\x00\x03\x00\x00\x00\t\x00\x00\x00;\x00\x04\x00\n\x00\x00\x00\x02\x00\x00\x00\x03\x00\x00\x00\x19\x00\t\x00\v\x00\x00\x00\a\x00\x00\x00\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x01\x00\x00\x00\x00\x00\x00\x00\x1b\x00\x03\x00\f\x00\x00\x00\v\x00\x00\x00 \x00\x04\x00\r\x00\x00\x00\x00\x00\x00\x00\f\x00\x00\x00;\x00\x04\x00\r\x00\x00\x00\x04\x00\x00\x00\x00\x00\x00\x00 \x00\x04\x00\x0e\x00\x00\x00\x01\x00\x00\x00\b\x00\x00\x00;\x00\x04\x00\x0e\x00\x00\x00\x03\x00\x00\x00\x01\x00\x00\x006\x00\x05\x00\x05\x00\x00\x00\x01\x00\x00\x00\x00\x00\x00\x00\x06\x00\x00\x00\xf8\x00\x02\x00\x0f\x00\x00\x00=\x00\x04\x00\f\x00\x00\x00\x10\x00\x00\x00\x04\x00\x00\x00=\x00\x04\x00\b\x00\x00\x00\x11\x00\x00\x00\x03\x00\x00\x00W\x00\x05\x00\t\x00\x00\x00\x12\x00\x00\x00\x10\x00\x00\x00\x11\x00\x00\x00>\x00\x03\x00\x02\x00\x00\x00\x12\x00\x00\x00\xfd\x00\x01\x008\x00\x01\x00")
+
+func blitSpvBytes() ([]byte, error) {
+	return _blitSpv, nil
+}
+
+func blitSpv() (*asset, error) {
+	bytes, err := blitSpvBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "blit.spv", size: 528, mode: os.FileMode(420), modTime: time.Unix(1792327749, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _bloomSpv = []byte("\x03\x02#\a\x00\x00\x01\x00\x00\x00\x00\x00A\x00\x00\x00\x00\x00\x00\x00\x11\x00\x02\x00\x01\x00\x00\x00\v\x00\x06\x00\x01\x00\x00\x00GLSL.std.450\x00\x00\x00\x00\x0e\x00\x03\x00\x00\x00\x00\x00\x01\x00\x00\x00\x0f\x00\a\x00\x04\x00\x00\x00\x02\x00\x00\x00main\x00\x00\x00\x00\x03\x00\x00\x00\x04\x00\x00\x00\x10\x00\x03\x00\x02\x00\x00\x00\a\x00\x00\x00\x03\x00\x03\x00\x02\x00\x00\x00\xc2\x01\x00\x00\x05\x00\x04\x00\x02\x00\x00\x00main\x00\x00\x00\x00\x05\x00\x05\x00\x03\x00\x00\x00outColor\x00\x00\x00\x00\x05\x00\x03\x00\x04\x00\x00\x00uv\x00\x00\x05\x00\x04\x00\x05\x00\x00\x00source\x00\x00\x05\x00\x05\x00\x06\x00\x00\x00effectInput\x00\x05\x00\x05\x00\a\x00\x00\x00Constants\x00\x00\x00\x06\x00\x06\x00\a\x00\x00\x00\x00\x00\x00\x00resolution\x00\x00\x06\x00\x05\x00\a\x00\x00\x00\x01\x00\x00\x00time\x00\x00\x00\x00\x06\x00\x05\x00\a\x00\x00\x00\x02\x00\x00\x00pass\x00\x00\x00\x00\x06\x00\x05\x00\a\x00\x00\x00\x03\x00\x00\x00params\x00\x00\x05\x00\x05\x00\b\x00\x00\x00constants\x00\x00\x00G\x00\x04\x00\x03\x00\x00\x00\x1e\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\x04\x00\x00\x00\x1e\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\x05\x00\x00\x00\"\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\x05\x00\x00\x00!\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\x06\x00\x00\x00\"\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\x06\x00\x00\x00!\x00\x00\x00\x01\x00\x00\x00G\x00\x04\x00\t\x00\x00\x00\x06\x00\x00\x00\x10\x00\x00\x00H\x00\x05\x00\a\x00\x00\x00\x00\x00\x00\x00#\x00\x00\x00\x00\x00\x00\x00H\x00\x05\x00\a\x00\x00\x00\x01\x00\x00\x00#\x00\x00\x00\b\x00\x00\x00H\x00\x05\x00\a\x00\x00\x00\x02\x00\x00\x00#\x00\x00\x00\f\x00\x00\x00H\x00\x05\x00\a\x00\x00\x00\x03\x00\x00\x00#\x00\x00\x00\x10\x00\x00\x00G\x00\x03\x00\a\x00\x00\x00\x02\x00\x00\x00\x13\x00\x02\x00\n\x00\x00\x00!\x00\x03\x00\v\x00\x00\x00\n\x00\x00\x00\x14\x00\x02\x00\f\x00\x00\x00\x16\x00\x03\x00\r\x00\x00\x00 \x00\x00\x00\x15\x00\x04\x00\x0e\x00\x00\x00 \x00\x00\x00\x01\x00\x00\x00\x15\x00\x04\x00\x0f\x00\x00\x00 \x00\x00\x00\x00\x00\x00\x00\x17\x00\x04\x00\x10\x00\x00\x00\r\x00\x00\x00\x02\x00\x00\x00\x17\x00\x04\x00\x11\x00\x00\x00\r\x00\x00\x00\x03\x00\x00\x00\x17\x00\x04\x00\x12\x00\x00\x00\r\x00\x00\x00\x04\x00\x00\x00+\x00\x04\x00\x0f\x00\x00\x00\x13\x00\x00\x00\a\x00\x00\x00\x1c\x00\x04\x00\t\x00\x00\x00\x12\x00\x00\x00\x13\x00\x00\x00\x1e\x00\x06\x00\a\x00\x00\x00\x10\x00\x00\x00\r\x00\x00\x00\r\x00\x00\x00\t\x00\x00\x00 \x00\x04\x00\x14\x00\x00\x00\t\x00\x00\x00\a\x00\x00\x00;\x00\x04\x00\x14\x00\x00\x00\b\x00\x00\x00\t\x00\x00\x00 \x00\x04\x00\x15\x00\x00\x00\t\x00\x00\x00\x10\x00\x00\x00 \x00\x04\x00\x16\x00\x00\x00\t\x00\x00\x00\r\x00\x00\x00 \x00\x04\x00\x17\x00\x00\x00\t\x00\x00\x00\x12\x00\x00\x00 \x00\x04\x00\x18\x00\x00\x00\x03\x00\x00\x00\x12\x00\x00\x00;\x00\x04\x00\x18\x00\x00\x00\x03\x00\x00\x00\x03\x00\x00\x00 \x00\x04\x00\x19\x00\x00\x00\x01\x00\x00\x00\x10\x00\x00\x00;\x00\x04\x00\x19\x00\x00\x00\x04\x00\x00\x00\x01\x00\x00\x00\x19\x00\t\x00\x1a\x00\x00\x00\r\x00\x00\x00\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x01\x00\x00\x00\x00\x00\x00\x00\x1b\x00\x03\x00\x1b\x00\x00\x00\x1a\x00\x00\x00 \x00\x04\x00\x1c\x00\x00\x00\x00\x00\x00\x00\x1b\x00\x00\x00;\x00\x04\x00\x1c\x00\x00\x00\x05\x00\x00\x00\x00\x00\x00\x00;\x00\x04\x00\x1c\x00\x00\x00\x06\x00\x00\x00\x00\x00\x00\x00+\x00\x04\x00\x0e\x00\x00\x00\x1d\x00\x00\x00\x00\x00\x00\x00+\x00\x04\x00\x0e\x00\x00\x00\x1e\x00\x00\x00\x01\x00\x00\x00+\x00\x04\x00\x0e\x00\x00\x00\x1f\x00\x00\x00\x02\x00\x00\x00+\x00\x04\x00\x0e\x00\x00\x00 \x00\x00\x00\x03\x00\x00\x00+\x00\x04\x00\r\x00\x00\x00!\x00\x00\x00\x00\x00\x00\x00+\x00\x04\x00\r\x00\x00\x00\"\x00\x00\x00\x00\x00\x80>+\x00\x04\x00\r\x00\x00\x00#\x00\x00\x00\x00\x00\x00?+\x00\x04\x00\r\x00\x00\x00$\x00\x00\x00\x00\x00\x80?+\x00\x04\x00\r\x00\x00\x00%\x00\x00\x00\x00\x00\x00@,\x00\x05\x00\x10\x00\x00\x00&\x00\x00\x00#\x00\x00\x00#\x00\x00\x00,\x00\x05\x00\x10\x00\x00\x00'\x00\x00\x00$\x00\x00\x00$\x00\x00\x00+\x00\x04\x00\r\x00\x00\x00(\x00\x00\x00гY>+\x00\x04\x00\r\x00\x00\x00)\x00\x00\x00Y\x177?+\x00\x04\x00\r\x00\x00\x00*\x00\x00\x00\x98ݓ=,\x00\x06\x00\x11\x00\x00\x00+\x00\x00\x00(\x00\x00\x00)\x00\x00\x00*\x00\x00\x006\x00\x05\x00\n\x00\x00\x00\x02\x00\x00\x00\x00\x00\x00\x00\v\x00\x00\x00\xf8\x00\x02\x00,\x00\x00\x00A\x00\x05\x00\x15\x00\x00\x00-\x00\x00\x00\b\x00\x00\x00\x1d\x00\x00\x00=\x00\x04\x00\x10\x00\x00\x00.\x00\x00\x00-\x00\x00\x00A\x00\x05\x00\x16\x00\x00\x00/\x00\x00\x00\b\x00\x00\x00\x1e\x00\x00\x00=\x00\x04\x00\r\x00\x00\x000\x00\x00\x00/\x00\x00\x00A\x00\x05\x00\x16\x00\x00\x001\x00\x00\x00\b\x00\x00\x00\x1f\x00\x00\x00=\x00\x04\x00\r\x00\x00\x002\x00\x00\x001\x00\x00\x00A\x00\x06\x00\x17\x00\x00\x003\x00\x00\x00\b\x00\x00\x00 \x00\x00\x00\x1d\x00\x00\x00=\x00\x04\x00\x12\x00\x00\x004\x00\x00\x003\x00\x00\x00=\x00\x04\x00\x10\x00\x00\x005\x00\x00\x00\x04\x00\x00\x00=\x00\x04\x00\x1b\x00\x00\x006\x00\x00\x00\x05\x00\x00\x00=\x00\x04\x00\x1b\x00\x00\x007\x00\x00\x00\x06\x00\x00\x00W\x00\x05\x00\x12\x00\x00\x008\x00\x00\x006\x00\x00\x005\x00\x00\x00W\x00\x05\x00\x12\x00\x00\x009\x00\x00\x007\x00\x00\x005\x00\x00\x00O\x00\b\x00\x11\x00\x00\x00:\x00\x00\x008\x00\x00\x008\x00\x00\x00\x00\x00\x00\x00\x01\x00\x00\x00\x02\x00\x00\x00O\x00\b\x00\x11\x00\x00\x00;\x00\x00\x009\x00\x00\x009\x00\x00\x00\x00\x00\x00\x00\x01\x00\x00\x00\x02\x00\x00\x00Q\x00\x05\x00\r\x00\x00\x00<\x00\x00\x004\x00\x00\x00\x02\x00\x00\x00\x8e\x00\x05\x00\x11\x00\x00\x00=\x00\x00\x00:\x00\x00\x00<\x00\x00\x00\x81\x00\x05\x00\x11\x00\x00\x00>\x00\x00\x00;\x00\x00\x00=\x00\x00\x00Q\x00\x05\x00\r\x00\x00\x00?\x00\x00\x009\x00\x00\x00\x03\x00\x00\x00P\x00\x05\x00\x12\x00\x00\x00@\x00\x00\x00>\x00\x00\x00?\x00\x00\x00>\x00\x03\x00\x03\x00\x00\x00@\x00\x00\x00\xfd\x00\x01\x008\x00\x01\x00")
+
+func bloomSpvBytes() ([]byte, error) {
+	return _bloomSpv, nil
+}
+
+func bloomSpv() (*asset, error) {
+	bytes, err := bloomSpvBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "bloom.spv", size: 1648, mode: os.FileMode(420), modTime: time.Unix(1792327966, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _blurSpv = []byte("\x03\x02#\a\x00\x00\x01\x00\x00\x00\x00\x00k\x00\x00\x00\x00\x00\x00\x00\x11\x00\x02\x00\x01\x00\x00\x00\v\x00\x06\x00\x01\x00\x00\x00GLSL.std.450\x00\x00\x00\x00\x0e\x00\x03\x00\x00\x00\x00\x00\x01\x00\x00\x00\x0f\x00\a\x00\x04\x00\x00\x00\x02\x00\x00\x00main\x00\x00\x00\x00\x03\x00\x00\x00\x04\x00\x00\x00\x10\x00\x03\x00\x02\x00\x00\x00\a\x00\x00\x00\x03\x00\x03\x00\x02\x00\x00\x00\xc2\x01\x00\x00\x05\x00\x04\x00\x02\x00\x00\x00main\x00\x00\x00\x00\x05\x00\x05\x00\x03\x00\x00\x00outColor\x00\x00\x00\x00\x05\x00\x03\x00\x04\x00\x00\x00uv\x00\x00\x05\x00\x04\x00\x05\x00\x00\x00source\x00\x00\x05\x00\x05\x00\x06\x00\x00\x00Constants\x00\x00\x00\x06\x00\x06\x00\x06\x00\x00\x00\x00\x00\x00\x00resolution\x00\x00\x06\x00\x05\x00\x06\x00\x00\x00\x01\x00\x00\x00time\x00\x00\x00\x00\x06\x00\x05\x00\x06\x00\x00\x00\x02\x00\x00\x00pass\x00\x00\x00\x00\x06\x00\x05\x00\x06\x00\x00\x00\x03\x00\x00\x00params\x00\x00\x05\x00\x05\x00\a\x00\x00\x00constants\x00\x00\x00G\x00\x04\x00\x03\x00\x00\x00\x1e\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\x04\x00\x00\x00\x1e\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\x05\x00\x00\x00\"\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\x05\x00\x00\x00!\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\b\x00\x00\x00\x06\x00\x00\x00\x10\x00\x00\x00H\x00\x05\x00\x06\x00\x00\x00\x00\x00\x00\x00#\x00\x00\x00\x00\x00\x00\x00H\x00\x05\x00\x06\x00\x00\x00\x01\x00\x00\x00#\x00\x00\x00\b\x00\x00\x00H\x00\x05\x00\x06\x00\x00\x00\x02\x00\x00\x00#\x00\x00\x00\f\x00\x00\x00H\x00\x05\x00\x06\x00\x00\x00\x03\x00\x00\x00#\x00\x00\x00\x10\x00\x00\x00G\x00\x03\x00\x06\x00\x00\x00\x02\x00\x00\x00\x13\x00\x02\x00\t\x00\x00\x00!\x00\x03\x00\n\x00\x00\x00\t\x00\x00\x00\x14\x00\x02\x00\v\x00\x00\x00\x16\x00\x03\x00\f\x00\x00\x00 \x00\x00\x00\x15\x00\x04\x00\r\x00\x00\x00 \x00\x00\x00\x01\x00\x00\x00\x15\x00\x04\x00\x0e\x00\x00\x00 \x00\x00\x00\x00\x00\x00\x00\x17\x00\x04\x00\x0f\x00\x00\x00\f\x00\x00\x00\x02\x00\x00\x00\x17\x00\x04\x00\x10\x00\x00\x00\f\x00\x00\x00\x03\x00\x00\x00\x17\x00\x04\x00\x11\x00\x00\x00\f\x00\x00\x00\x04\x00\x00\x00+\x00\x04\x00\x0e\x00\x00\x00\x12\x00\x00\x00\a\x00\x00\x00\x1c\x00\x04\x00\b\x00\x00\x00\x11\x00\x00\x00\x12\x00\x00\x00\x1e\x00\x06\x00\x06\x00\x00\x00\x0f\x00\x00\x00\f\x00\x00\x00\f\x00\x00\x00\b\x00\x00\x00 \x00\x04\x00\x13\x00\x00\x00\t\x00\x00\x00\x06\x00\x00\x00;\x00\x04\x00\x13\x00\x00\x00\a\x00\x00\x00\t\x00\x00\x00 \x00\x04\x00\x14\x00\x00\x00\t\x00\x00\x00\x0f\x00\x00\x00 \x00\x04\x00\x15\x00\x00\x00\t\x00\x00\x00\f\x00\x00\x00 \x00\x04\x00\x16\x00\x00\x00\t\x00\x00\x00\x11\x00\x00\x00 \x00\x04\x00\x17\x00\x00\x00\x03\x00\x00\x00\x11\x00\x00\x00;\x00\x04\x00\x17\x00\x00\x00\x03\x00\x00\x00\x03\x00\x00\x00 \x00\x04\x00\x18\x00\x00\x00\x01\x00\x00\x00\x0f\x00\x00\x00;\x00\x04\x00\x18\x00\x00\x00\x04\x00\x00\x00\x01\x00\x00\x00\x19\x00\t\x00\x19\x00\x00\x00\f\x00\x00\x00\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x01\x00\x00\x00\x00\x00\x00\x00\x1b\x00\x03\x00\x1a\x00\x00\x00\x19\x00\x00\x00 \x00\x04\x00\x1b\x00\x00\x00\x00\x00\x00\x00\x1a\x00\x00\x00;\x00\x04\x00\x1b\x00\x00\x00\x05\x00\x00\x00\x00\x00\x00\x00+\x00\x04\x00\r\x00\x00\x00\x1c\x00\x00\x00\x00\x00\x00\x00+\x00\x04\x00\r\x00\x00\x00\x1d\x00\x00\x00\x01\x00\x00\x00+\x00\x04\x00\r\x00\x00\x00\x1e\x00\x00\x00\x02\x00\x00\x00+\x00\x04\x00\r\x00\x00\x00\x1f\x00\x00\x00\x03\x00\x00\x00+\x00\x04\x00\f\x00\x00\x00 \x00\x00\x00\x00\x00\x00\x00+\x00\x04\x00\f\x00\x00\x00!\x00\x00\x00\x00\x00\x80>+\x00\x04\x00\f\x00\x00\x00\"\x00\x00\x00\x00\x00\x00?+\x00\x04\x00\f\x00\x00\x00#\x00\x00\x00\x00\x00\x80?+\x00\x04\x00\f\x00\x00\x00$\x00\x00\x00\x00\x00\x00@,\x00\x05\x00\x0f\x00\x00\x00%\x00\x00\x00\"\x00\x00\x00\"\x00\x00\x00,\x00\x05\x00\x0f\x00\x00\x00&\x00\x00\x00#\x00\x00\x00#\x00\x00\x00+\x00\x04\x00\f\x00\x00\x00'\x00\x00\x00гY>+\x00\x04\x00\f\x00\x00\x00(\x00\x00\x00Y\x177?+\x00\x04\x00\f\x00\x00\x00)\x00\x00\x00\x98ݓ=,\x00\x06\x00\x10\x00\x00\x00*\x00\x00\x00'\x00\x00\x00(\x00\x00\x00)\x00\x00\x00+\x00\x04\x00\f\x00\x00\x00+\x00\x00\x00\xc4yh>+\x00\x04\x00\f\x00\x00\x00,\x00\x00\x00\xcfCG>+\x00\x04\x00\f\x00\x00\x00-\x00\x00\x00\xbf\x14\xf9=+\x00\x04\x00\f\x00\x00\x00.\x00\x00\x00\xbag]=+\x00\x04\x00\f\x00\x00\x00/\x00\x00\x00kׄ<+\x00\x04\x00\f\x00\x00\x000\x00\x00\x00\x00\x00@@+\x00\x04\x00\f\x00\x00\x001\x00\x00\x00\x00\x00\x80@6\x00\x05\x00\t\x00\x00\x00\x02\x00\x00\x00\x00\x00\x00\x00\n\x00\x00\x00\xf8\x00\x02\x002\x00\x00\x00A\x00\x05\x00\x14\x00\x00\x003\x00\x00\x00\a\x00\x00\x00\x1c\x00\x00\x00=\x00\x04\x00\x0f\x00\x00\x004\x00\x00\x003\x00\x00\x00A\x00\x05\x00\x15\x00\x00\x005\x00\x00\x00\a\x00\x00\x00\x1d\x00\x00\x00=\x00\x04\x00\f\x00\x00\x006\x00\x00\x005\x00\x00\x00A\x00\x05\x00\x15\x00\x00\x007\x00\x00\x00\a\x00\x00\x00\x1e\x00\x00\x00=\x00\x04\x00\f\x00\x00\x008\x00\x00\x007\x00\x00\x00A\x00\x06\x00\x16\x00\x00\x009\x00\x00\x00\a\x00\x00\x00\x1f\x00\x00\x00\x1c\x00\x00\x00=\x00\x04\x00\x11\x00\x00\x00:\x00\x00\x009\x00\x00\x00=\x00\x04\x00\x0f\x00\x00\x00;\x00\x00\x00\x04\x00\x00\x00=\x00\x04\x00\x1a\x00\x00\x00<\x00\x00\x00\x05\x00\x00\x00\x88\x00\x05\x00\x0f\x00\x00\x00=\x00\x00\x00&\x00\x00\x004\x00\x00\x00\x8d\x00\x05\x00\f\x00\x00\x00>\x00\x00\x008\x00\x00\x00$\x00\x00\x00\f\x00\a\x00\f\x00\x00\x00?\x00\x00\x00\x01\x00\x00\x000\x00\x00\x00\"\x00\x00\x00>\x00\x00\x00\x83\x00\x05\x00\f\x00\x00\x00@\x00\x00\x00#\x00\x00\x00?\x00\x00\x00Q\x00\x05\x00\f\x00\x00\x00A\x00\x00\x00=\x00\x00\x00\x00\x00\x00\x00Q\x00\x05\x00\f\x00\x00\x00B\x00\x00\x00=\x00\x00\x00\x01\x00\x00\x00\x85\x00\x05\x00\f\x00\x00\x00C\x00\x00\x00A\x00\x00\x00@\x00\x00\x00\x85\x00\x05\x00\f\x00\x00\x00D\x00\x00\x00B\x00\x00\x00?\x00\x00\x00P\x00\x05\x00\x0f\x00\x00\x00E\x00\x00\x00C\x00\x00\x00D\x00\x00\x00Q\x00\x05\x00\f\x00\x00\x00F\x00\x00\x00:\x00\x00\x00\x00\x00\x00\x00\x85\x00\x05\x00\f\x00\x00\x00G\x00\x00\x00F\x00\x00\x00!\x00\x00\x00\x8e\x00\x05\x00\x0f\x00\x00\x00H\x00\x00\x00E\x00\x00\x00G\x00\x00\x00W\x00\x05\x00\x11\x00\x00\x00I\x00\x00\x00<\x00\x00\x00;\x00\x00\x00\x8e\x00\x05\x00\x11\x00\x00\x00J\x00\x00\x00I\x00\x00\x00+\x00\x00\x00\x8e\x00\x05\x00\x0f\x00\x00\x00K\x00\x00\x00H\x00\x00\x00#\x00\x00\x00\x81\x00\x05\x00\x0f\x00\x00\x00L\x00\x00\x00;\x00\x00\x00K\x00\x00\x00\x83\x00\x05\x00\x0f\x00\x00\x00M\x00\x00\x00;\x00\x00\x00K\x00\x00\x00W\x00\x05\x00\x11\x00\x00\x00N\x00\x00\x00<\x00\x00\x00L\x00\x00\x00W\x00\x05\x00\x11\x00\x00\x00O\x00\x00\x00<\x00\x00\x00M\x00\x00\x00\x81\x00\x05\x00\x11\x00\x00\x00P\x00\x00\x00N\x00\x00\x00O\x00\x00\x00\x8e\x00\x05\x00\x11\x00\x00\x00Q\x00\x00\x00P\x00\x00\x00,\x00\x00\x00\x81\x00\x05\x00\x11\x00\x00\x00R\x00\x00\x00J\x00\x00\x00Q\x00\x00\x00\x8e\x00\x05\x00\x0f\x00\x00\x00S\x00\x00\x00H\x00\x00\x00$\x00\x00\x00\x81\x00\x05\x00\x0f\x00\x00\x00T\x00\x00\x00;\x00\x00\x00S\x00\x00\x00\x83\x00\x05\x00\x0f\x00\x00\x00U\x00\x00\x00;\x00\x00\x00S\x00\x00\x00W\x00\x05\x00\x11\x00\x00\x00V\x00\x00\x00<\x00\x00\x00T\x00\x00\x00W\x00\x05\x00\x11\x00\x00\x00W\x00\x00\x00<\x00\x00\x00U\x00\x00\x00\x81\x00\x05\x00\x11\x00\x00\x00X\x00\x00\x00V\x00\x00\x00W\x00\x00\x00\x8e\x00\x05\x00\x11\x00\x00\x00Y\x00\x00\x00X\x00\x00\x00-\x00\x00\x00\x81\x00\x05\x00\x11\x00\x00\x00Z\x00\x00\x00R\x00\x00\x00Y\x00\x00\x00\x8e\x00\x05\x00\x0f\x00\x00\x00[\x00\x00\x00H\x00\x00\x000\x00\x00\x00\x81\x00\x05\x00\x0f\x00\x00\x00\\\x00\x00\x00;\x00\x00\x00[\x00\x00\x00\x83\x00\x05\x00\x0f\x00\x00\x00]\x00\x00\x00;\x00\x00\x00[\x00\x00\x00W\x00\x05\x00\x11\x00\x00\x00^\x00\x00\x00<\x00\x00\x00\\\x00\x00\x00W\x00\x05\x00\x11\x00\x00\x00_\x00\x00\x00<\x00\x00\x00]\x00\x00\x00\x81\x00\x05\x00\x11\x00\x00\x00`\x00\x00\x00^\x00\x00\x00_\x00\x00\x00\x8e\x00\x05\x00\x11\x00\x00\x00a\x00\x00\x00`\x00\x00\x00.\x00\x00\x00\x81\x00\x05\x00\x11\x00\x00\x00b\x00\x00\x00Z\x00\x00\x00a\x00\x00\x00\x8e\x00\x05\x00\x0f\x00\x00\x00c\x00\x00\x00H\x00\x00\x001\x00\x00\x00\x81\x00\x05\x00\x0f\x00\x00\x00d\x00\x00\x00;\x00\x00\x00c\x00\x00\x00\x83\x00\x05\x00\x0f\x00\x00\x00e\x00\x00\x00;\x00\x00\x00c\x00\x00\x00W\x00\x05\x00\x11\x00\x00\x00f\x00\x00\x00<\x00\x00\x00d\x00\x00\x00W\x00\x05\x00\x11\x00\x00\x00g\x00\x00\x00<\x00\x00\x00e\x00\x00\x00\x81\x00\x05\x00\x11\x00\x00\x00h\x00\x00\x00f\x00\x00\x00g\x00\x00\x00\x8e\x00\x05\x00\x11\x00\x00\x00i\x00\x00\x00h\x00\x00\x00/\x00\x00\x00\x81\x00\x05\x00\x11\x00\x00\x00j\x00\x00\x00b\x00\x00\x00i\x00\x00\x00>\x00\x03\x00\x03\x00\x00\x00j\x00\x00\x00\xfd\x00\x01\x008\x00\x01\x00")
+
+func blurSpvBytes() ([]byte, error) {
+	return _blurSpv, nil
+}
+
+func blurSpv() (*asset, error) {
+	bytes, err := blurSpvBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "blur.spv", size: 2400, mode: os.FileMode(420), modTime: time.Unix(1792327966, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _chromaticSpv = []byte("\x03\x02#\a\x00\x00\x01\x00\x00\x00\x00\x00E\x00\x00\x00\x00\x00\x00\x00\x11\x00\x02\x00\x01\x00\x00\x00\v\x00\x06\x00\x01\x00\x00\x00GLSL.std.450\x00\x00\x00\x00\x0e\x00\x03\x00\x00\x00\x00\x00\x01\x00\x00\x00\x0f\x00\a\x00\x04\x00\x00\x00\x02\x00\x00\x00main\x00\x00\x00\x00\x03\x00\x00\x00\x04\x00\x00\x00\x10\x00\x03\x00\x02\x00\x00\x00\a\x00\x00\x00\x03\x00\x03\x00\x02\x00\x00\x00\xc2\x01\x00\x00\x05\x00\x04\x00\x02\x00\x00\x00main\x00\x00\x00\x00\x05\x00\x05\x00\x03\x00\x00\x00outColor\x00\x00\x00\x00\x05\x00\x03\x00\x04\x00\x00\x00uv\x00\x00\x05\x00\x04\x00\x05\x00\x00\x00source\x00\x00\x05\x00\x05\x00\x06\x00\x00\x00Constants\x00\x00\x00\x06\x00\x06\x00\x06\x00\x00\x00\x00\x00\x00\x00resolution\x00\x00\x06\x00\x05\x00\x06\x00\x00\x00\x01\x00\x00\x00time\x00\x00\x00\x00\x06\x00\x05\x00\x06\x00\x00\x00\x02\x00\x00\x00pass\x00\x00\x00\x00\x06\x00\x05\x00\x06\x00\x00\x00\x03\x00\x00\x00params\x00\x00\x05\x00\x05\x00\a\x00\x00\x00constants\x00\x00\x00G\x00\x04\x00\x03\x00\x00\x00\x1e\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\x04\x00\x00\x00\x1e\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\x05\x00\x00\x00\"\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\x05\x00\x00\x00!\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\b\x00\x00\x00\x06\x00\x00\x00\x10\x00\x00\x00H\x00\x05\x00\x06\x00\x00\x00\x00\x00\x00\x00#\x00\x00\x00\x00\x00\x00\x00H\x00\x05\x00\x06\x00\x00\x00\x01\x00\x00\x00#\x00\x00\x00\b\x00\x00\x00H\x00\x05\x00\x06\x00\x00\x00\x02\x00\x00\x00#\x00\x00\x00\f\x00\x00\x00H\x00\x05\x00\x06\x00\x00\x00\x03\x00\x00\x00#\x00\x00\x00\x10\x00\x00\x00G\x00\x03\x00\x06\x00\x00\x00\x02\x00\x00\x00\x13\x00\x02\x00\t\x00\x00\x00!\x00\x03\x00\n\x00\x00\x00\t\x00\x00\x00\x14\x00\x02\x00\v\x00\x00\x00\x16\x00\x03\x00\f\x00\x00\x00 \x00\x00\x00\x15\x00\x04\x00\r\x00\x00\x00 \x00\x00\x00\x01\x00\x00\x00\x15\x00\x04\x00\x0e\x00\x00\x00 \x00\x00\x00\x00\x00\x00\x00\x17\x00\x04\x00\x0f\x00\x00\x00\f\x00\x00\x00\x02\x00\x00\x00\x17\x00\x04\x00\x10\x00\x00\x00\f\x00\x00\x00\x03\x00\x00\x00\x17\x00\x04\x00\x11\x00\x00\x00\f\x00\x00\x00\x04\x00\x00\x00+\x00\x04\x00\x0e\x00\x00\x00\x12\x00\x00\x00\a\x00\x00\x00\x1c\x00\x04\x00\b\x00\x00\x00\x11\x00\x00\x00\x12\x00\x00\x00\x1e\x00\x06\x00\x06\x00\x00\x00\x0f\x00\x00\x00\f\x00\x00\x00\f\x00\x00\x00\b\x00\x00\x00 \x00\x04\x00\x13\x00\x00\x00\t\x00\x00\x00\x06\x00\x00\x00;\x00\x04\x00\x13\x00\x00\x00\a\x00\x00\x00\t\x00\x00\x00 \x00\x04\x00\x14\x00\x00\x00\t\x00\x00\x00\x0f\x00\x00\x00 \x00\x04\x00\x15\x00\x00\x00\t\x00\x00\x00\f\x00\x00\x00 \x00\x04\x00\x16\x00\x00\x00\t\x00\x00\x00\x11\x00\x00\x00 \x00\x04\x00\x17\x00\x00\x00\x03\x00\x00\x00\x11\x00\x00\x00;\x00\x04\x00\x17\x00\x00\x00\x03\x00\x00\x00\x03\x00\x00\x00 \x00\x04\x00\x18\x00\x00\x00\x01\x00\x00\x00\x0f\x00\x00\x00;\x00\x04\x00\x18\x00\x00\x00\x04\x00\x00\x00\x01\x00\x00\x00\x19\x00\t\x00\x19\x00\x00\x00\f\x00\x00\x00\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x01\x00\x00\x00\x00\x00\x00\x00\x1b\x00\x03\x00\x1a\x00\x00\x00\x19\x00\x00\x00 \x00\x04\x00\x1b\x00\x00\x00\x00\x00\x00\x00\x1a\x00\x00\x00;\x00\x04\x00\x1b\x00\x00\x00\x05\x00\x00\x00\x00\x00\x00\x00+\x00\x04\x00\r\x00\x00\x00\x1c\x00\x00\x00\x00\x00\x00\x00+\x00\x04\x00\r\x00\x00\x00\x1d\x00\x00\x00\x01\x00\x00\x00+\x00\x04\x00\r\x00\x00\x00\x1e\x00\x00\x00\x02\x00\x00\x00+\x00\x04\x00\r\x00\x00\x00\x1f\x00\x00\x00\x03\x00\x00\x00+\x00\x04\x00\f\x00\x00\x00 \x00\x00\x00\x00\x00\x00\x00+\x00\x04\x00\f\x00\x00\x00!\x00\x00\x00\x00\x00\x80>+\x00\x04\x00\f\x00\x00\x00\"\x00\x00\x00\x00\x00\x00?+\x00\x04\x00\f\x00\x00\x00#\x00\x00\x00\x00\x00\x80?+\x00\x04\x00\f\x00\x00\x00$\x00\x00\x00\x00\x00\x00@,\x00\x05\x00\x0f\x00\x00\x00%\x00\x00\x00\"\x00\x00\x00\"\x00\x00\x00,\x00\x05\x00\x0f\x00\x00\x00&\x00\x00\x00#\x00\x00\x00#\x00\x00\x00+\x00\x04\x00\f\x00\x00\x00'\x00\x00\x00гY>+\x00\x04\x00\f\x00\x00\x00(\x00\x00\x00Y\x177?+\x00\x04\x00\f\x00\x00\x00)\x00\x00\x00\x98ݓ=,\x00\x06\x00\x10\x00\x00\x00*\x00\x00\x00'\x00\x00\x00(\x00\x00\x00)\x00\x00\x006\x00\x05\x00\t\x00\x00\x00\x02\x00\x00\x00\x00\x00\x00\x00\n\x00\x00\x00\xf8\x00\x02\x00+\x00\x00\x00A\x00\x05\x00\x14\x00\x00\x00,\x00\x00\x00\a\x00\x00\x00\x1c\x00\x00\x00=\x00\x04\x00\x0f\x00\x00\x00-\x00\x00\x00,\x00\x00\x00A\x00\x05\x00\x15\x00\x00\x00.\x00\x00\x00\a\x00\x00\x00\x1d\x00\x00\x00=\x00\x04\x00\f\x00\x00\x00/\x00\x00\x00.\x00\x00\x00A\x00\x05\x00\x15\x00\x00\x000\x00\x00\x00\a\x00\x00\x00\x1e\x00\x00\x00=\x00\x04\x00\f\x00\x00\x001\x00\x00\x000\x00\x00\x00A\x00\x06\x00\x16\x00\x00\x002\x00\x00\x00\a\x00\x00\x00\x1f\x00\x00\x00\x1c\x00\x00\x00=\x00\x04\x00\x11\x00\x00\x003\x00\x00\x002\x00\x00\x00=\x00\x04\x00\x0f\x00\x00\x004\x00\x00\x00\x04\x00\x00\x00=\x00\x04\x00\x1a\x00\x00\x005\x00\x00\x00\x05\x00\x00\x00\x83\x00\x05\x00\x0f\x00\x00\x006\x00\x00\x004\x00\x00\x00%\x00\x00\x00Q\x00\x05\x00\f\x00\x00\x007\x00\x00\x003\x00\x00\x00\x00\x00\x00\x00\x8e\x00\x05\x00\x0f\x00\x00\x008\x00\x00\x006\x00\x00\x007\x00\x00\x00\x8e\x00\x05\x00\x0f\x00\x00\x009\x00\x00\x008\x00\x00\x00$\x00\x00\x00\x88\x00\x05\x00\x0f\x00\x00\x00:\x00\x00\x009\x00\x00\x00-\x00\x00\x00\x81\x00\x05\x00\x0f\x00\x00\x00;\x00\x00\x004\x00\x00\x00:\x00\x00\x00\x83\x00\x05\x00\x0f\x00\x00\x00<\x00\x00\x004\x00\x00\x00:\x00\x00\x00W\x00\x05\x00\x11\x00\x00\x00=\x00\x00\x005\x00\x00\x00;\x00\x00\x00W\x00\x05\x00\x11\x00\x00\x00>\x00\x00\x005\x00\x00\x004\x00\x00\x00W\x00\x05\x00\x11\x00\x00\x00?\x00\x00\x005\x00\x00\x00<\x00\x00\x00Q\x00\x05\x00\f\x00\x00\x00@\x00\x00\x00=\x00\x00\x00\x00\x00\x00\x00Q\x00\x05\x00\f\x00\x00\x00A\x00\x00\x00>\x00\x00\x00\x01\x00\x00\x00Q\x00\x05\x00\f\x00\x00\x00B\x00\x00\x00?\x00\x00\x00\x02\x00\x00\x00Q\x00\x05\x00\f\x00\x00\x00C\x00\x00\x00>\x00\x00\x00\x03\x00\x00\x00P\x00\a\x00\x11\x00\x00\x00D\x00\x00\x00@\x00\x00\x00A\x00\x00\x00B\x00\x00\x00C\x00\x00\x00>\x00\x03\x00\x03\x00\x00\x00D\x00\x00\x00\xfd\x00\x01\x008\x00\x01\x00")
+
+func chromaticSpvBytes() ([]byte, error) {
+	return _chromaticSpv, nil
+}
+
+func chromaticSpv() (*asset, error) {
+	bytes, err := chromaticSpvBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "chromatic.spv", size: 1668, mode: os.FileMode(420), modTime: time.Unix(1792327966, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _crtSpv = []byte("\x03\x02#\a\x00\x00\x01\x00\x00\x00\x00\x00W\x00\x00\x00\x00\x00\x00\x00\x11\x00\x02\x00\x01\x00\x00\x00\v\x00\x06\x00\x01\x00\x00\x00GLSL.std.450\x00\x00\x00\x00\x0e\x00\x03\x00\x00\x00\x00\x00\x01\x00\x00\x00\x0f\x00\a\x00\x04\x00\x00\x00\x02\x00\x00\x00main\x00\x00\x00\x00\x03\x00\x00\x00\x04\x00\x00\x00\x10\x00\x03\x00\x02\x00\x00\x00\a\x00\x00\x00\x03\x00\x03\x00\x02\x00\x00\x00\xc2\x01\x00\x00\x05\x00\x04\x00\x02\x00\x00\x00main\x00\x00\x00\x00\x05\x00\x05\x00\x03\x00\x00\x00outColor\x00\x00\x00\x00\x05\x00\x03\x00\x04\x00\x00\x00uv\x00\x00\x05\x00\x04\x00\x05\x00\x00\x00source\x00\x00\x05\x00\x05\x00\x06\x00\x00\x00Constants\x00\x00\x00\x06\x00\x06\x00\x06\x00\x00\x00\x00\x00\x00\x00resolution\x00\x00\x06\x00\x05\x00\x06\x00\x00\x00\x01\x00\x00\x00time\x00\x00\x00\x00\x06\x00\x05\x00\x06\x00\x00\x00\x02\x00\x00\x00pass\x00\x00\x00\x00\x06\x00\x05\x00\x06\x00\x00\x00\x03\x00\x00\x00params\x00\x00\x05\x00\x05\x00\a\x00\x00\x00constants\x00\x00\x00G\x00\x04\x00\x03\x00\x00\x00\x1e\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\x04\x00\x00\x00\x1e\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\x05\x00\x00\x00\"\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\x05\x00\x00\x00!\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\b\x00\x00\x00\x06\x00\x00\x00\x10\x00\x00\x00H\x00\x05\x00\x06\x00\x00\x00\x00\x00\x00\x00#\x00\x00\x00\x00\x00\x00\x00H\x00\x05\x00\x06\x00\x00\x00\x01\x00\x00\x00#\x00\x00\x00\b\x00\x00\x00H\x00\x05\x00\x06\x00\x00\x00\x02\x00\x00\x00#\x00\x00\x00\f\x00\x00\x00H\x00\x05\x00\x06\x00\x00\x00\x03\x00\x00\x00#\x00\x00\x00\x10\x00\x00\x00G\x00\x03\x00\x06\x00\x00\x00\x02\x00\x00\x00\x13\x00\x02\x00\t\x00\x00\x00!\x00\x03\x00\n\x00\x00\x00\t\x00\x00\x00\x14\x00\x02\x00\v\x00\x00\x00\x16\x00\x03\x00\f\x00\x00\x00 \x00\x00\x00\x15\x00\x04\x00\r\x00\x00\x00 \x00\x00\x00\x01\x00\x00\x00\x15\x00\x04\x00\x0e\x00\x00\x00 \x00\x00\x00\x00\x00\x00\x00\x17\x00\x04\x00\x0f\x00\x00\x00\f\x00\x00\x00\x02\x00\x00\x00\x17\x00\x04\x00\x10\x00\x00\x00\f\x00\x00\x00\x03\x00\x00\x00\x17\x00\x04\x00\x11\x00\x00\x00\f\x00\x00\x00\x04\x00\x00\x00+\x00\x04\x00\x0e\x00\x00\x00\x12\x00\x00\x00\a\x00\x00\x00\x1c\x00\x04\x00\b\x00\x00\x00\x11\x00\x00\x00\x12\x00\x00\x00\x1e\x00\x06\x00\x06\x00\x00\x00\x0f\x00\x00\x00\f\x00\x00\x00\f\x00\x00\x00\b\x00\x00\x00 \x00\x04\x00\x13\x00\x00\x00\t\x00\x00\x00\x06\x00\x00\x00;\x00\x04\x00\x13\x00\x00\x00\a\x00\x00\x00\t\x00\x00\x00 \x00\x04\x00\x14\x00\x00\x00\t\x00\x00\x00\x0f\x00\x00\x00 \x00\x04\x00\x15\x00\x00\x00\t\x00\x00\x00\f\x00\x00\x00 \x00\x04\x00\x16\x00\x00\x00\t\x00\x00\x00\x11\x00\x00\x00 \x00\x04\x00\x17\x00\x00\x00\x03\x00\x00\x00\x11\x00\x00\x00;\x00\x04\x00\x17\x00\x00\x00\x03\x00\x00\x00\x03\x00\x00\x00 \x00\x04\x00\x18\x00\x00\x00\x01\x00\x00\x00\x0f\x00\x00\x00;\x00\x04\x00\x18\x00\x00\x00\x04\x00\x00\x00\x01\x00\x00\x00\x19\x00\t\x00\x19\x00\x00\x00\f\x00\x00\x00\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x01\x00\x00\x00\x00\x00\x00\x00\x1b\x00\x03\x00\x1a\x00\x00\x00\x19\x00\x00\x00 \x00\x04\x00\x1b\x00\x00\x00\x00\x00\x00\x00\x1a\x00\x00\x00;\x00\x04\x00\x1b\x00\x00\x00\x05\x00\x00\x00\x00\x00\x00\x00+\x00\x04\x00\r\x00\x00\x00\x1c\x00\x00\x00\x00\x00\x00\x00+\x00\x04\x00\r\x00\x00\x00\x1d\x00\x00\x00\x01\x00\x00\x00+\x00\x04\x00\r\x00\x00\x00\x1e\x00\x00\x00\x02\x00\x00\x00+\x00\x04\x00\r\x00\x00\x00\x1f\x00\x00\x00\x03\x00\x00\x00+\x00\x04\x00\f\x00\x00\x00 \x00\x00\x00\x00\x00\x00\x00+\x00\x04\x00\f\x00\x00\x00!\x00\x00\x00\x00\x00\x80>+\x00\x04\x00\f\x00\x00\x00\"\x00\x00\x00\x00\x00\x00?+\x00\x04\x00\f\x00\x00\x00#\x00\x00\x00\x00\x00\x80?+\x00\x04\x00\f\x00\x00\x00$\x00\x00\x00\x00\x00\x00@,\x00\x05\x00\x0f\x00\x00\x00%\x00\x00\x00\"\x00\x00\x00\"\x00\x00\x00,\x00\x05\x00\x0f\x00\x00\x00&\x00\x00\x00#\x00\x00\x00#\x00\x00\x00+\x00\x04\x00\f\x00\x00\x00'\x00\x00\x00гY>+\x00\x04\x00\f\x00\x00\x00(\x00\x00\x00Y\x177?+\x00\x04\x00\f\x00\x00\x00)\x00\x00\x00\x98ݓ=,\x00\x06\x00\x10\x00\x00\x00*\x00\x00\x00'\x00\x00\x00(\x00\x00\x00)\x00\x00\x00+\x00\x04\x00\f\x00\x00\x00+\x00\x00\x00\xdb\x0fI@6\x00\x05\x00\t\x00\x00\x00\x02\x00\x00\x00\x00\x00\x00\x00\n\x00\x00\x00\xf8\x00\x02\x00,\x00\x00\x00A\x00\x05\x00\x14\x00\x00\x00-\x00\x00\x00\a\x00\x00\x00\x1c\x00\x00\x00=\x00\x04\x00\x0f\x00\x00\x00.\x00\x00\x00-\x00\x00\x00A\x00\x05\x00\x15\x00\x00\x00/\x00\x00\x00\a\x00\x00\x00\x1d\x00\x00\x00=\x00\x04\x00\f\x00\x00\x000\x00\x00\x00/\x00\x00\x00A\x00\x05\x00\x15\x00\x00\x001\x00\x00\x00\a\x00\x00\x00\x1e\x00\x00\x00=\x00\x04\x00\f\x00\x00\x002\x00\x00\x001\x00\x00\x00A\x00\x06\x00\x16\x00\x00\x003\x00\x00\x00\a\x00\x00\x00\x1f\x00\x00\x00\x1c\x00\x00\x00=\x00\x04\x00\x11\x00\x00\x004\x00\x00\x003\x00\x00\x00=\x00\x04\x00\x0f\x00\x00\x005\x00\x00\x00\x04\x00\x00\x00=\x00\x04\x00\x1a\x00\x00\x006\x00\x00\x00\x05\x00\x00\x00\x8e\x00\x05\x00\x0f\x00\x00\x007\x00\x00\x005\x00\x00\x00$\x00\x00\x00\x83\x00\x05\x00\x0f\x00\x00\x008\x00\x00\x007\x00\x00\x00&\x00\x00\x00\x94\x00\x05\x00\f\x00\x00\x009\x00\x00\x008\x00\x00\x008\x00\x00\x00Q\x00\x05\x00\f\x00\x00\x00:\x00\x00\x004\x00\x00\x00\x00\x00\x00\x00\x85\x00\x05\x00\f\x00\x00\x00;\x00\x00\x00:\x00\x00\x009\x00\x00\x00\x81\x00\x05\x00\f\x00\x00\x00<\x00\x00\x00#\x00\x00\x00;\x00\x00\x00\x8e\x00\x05\x00\x0f\x00\x00\x00=\x00\x00\x008\x00\x00\x00<\x00\x00\x00\x8e\x00\x05\x00\x0f\x00\x00\x00>\x00\x00\x00=\x00\x00\x00\"\x00\x00\x00\x81\x00\x05\x00\x0f\x00\x00\x00?\x00\x00\x00>\x00\x00\x00%\x00\x00\x00W\x00\x05\x00\x11\x00\x00\x00@\x00\x00\x006\x00\x00\x00?\x00\x00\x00Q\x00\x05\x00\f\x00\x00\x00A\x00\x00\x00?\x00\x00\x00\x01\x00\x00\x00Q\x00\x05\x00\f\x00\x00\x00B\x00\x00\x00.\x00\x00\x00\x01\x00\x00\x00\x85\x00\x05\x00\f\x00\x00\x00C\x00\x00\x00A\x00\x00\x00B\x00\x00\x00\x85\x00\x05\x00\f\x00\x00\x00D\x00\x00\x00C\x00\x00\x00+\x00\x00\x00\f\x00\x06\x00\f\x00\x00\x00E\x00\x00\x00\x01\x00\x00\x00\r\x00\x00\x00D\x00\x00\x00\x85\x00\x05\x00\f\x00\x00\x00F\x00\x00\x00E\x00\x00\x00\"\x00\x00\x00\x81\x00\x05\x00\f\x00\x00\x00G\x00\x00\x00F\x00\x00\x00\"\x00\x00\x00Q\x00\x05\x00\f\x00\x00\x00H\x00\x00\x004\x00\x00\x00\x01\x00\x00\x00\x85\x00\x05\x00\f\x00\x00\x00I\x00\x00\x00H\x00\x00\x00G\x00\x00\x00\x83\x00\x05\x00\f\x00\x00\x00J\x00\x00\x00#\x00\x00\x00I\x00\x00\x00Q\x00\x05\x00\f\x00\x00\x00K\x00\x00\x00?\x00\x00\x00\x00\x00\x00\x00\f\x00\a\x00\f\x00\x00\x00L\x00\x00\x00\x01\x00\x00\x000\x00\x00\x00 \x00\x00\x00K\x00\x00\x00\f\x00\a\x00\f\x00\x00\x00M\x00\x00\x00\x01\x00\x00\x000\x00\x00\x00K\x00\x00\x00#\x00\x00\x00\f\x00\a\x00\f\x00\x00\x00N\x00\x00\x00\x01\x00\x00\x000\x00\x00\x00 \x00\x00\x00A\x00\x00\x00\f\x00\a\x00\f\x00\x00\x00O\x00\x00\x00\x01\x00\x00\x000\x00\x00\x00A\x00\x00\x00#\x00\x00\x00\x85\x00\x05\x00\f\x00\x00\x00P\x00\x00\x00L\x00\x00\x00M\x00\x00\x00\x85\x00\x05\x00\f\x00\x00\x00Q\x00\x00\x00N\x00\x00\x00O\x00\x00\x00\x85\x00\x05\x00\f\x00\x00\x00R\x00\x00\x00P\x00\x00\x00Q\x00\x00\x00\x85\x00\x05\x00\f\x00\x00\x00S\x00\x00\x00J\x00\x00\x00R\x00\x00\x00O\x00\b\x00\x10\x00\x00\x00T\x00\x00\x00@\x00\x00\x00@\x00\x00\x00\x00\x00\x00\x00\x01\x00\x00\x00\x02\x00\x00\x00\x8e\x00\x05\x00\x10\x00\x00\x00U\x00\x00\x00T\x00\x00\x00S\x00\x00\x00P\x00\x05\x00\x11\x00\x00\x00V\x00\x00\x00U\x00\x00\x00#\x00\x00\x00>\x00\x03\x00\x03\x00\x00\x00V\x00\x00\x00\xfd\x00\x01\x008\x00\x01\x00")
+
+func crtSpvBytes() ([]byte, error) {
+	return _crtSpv, nil
+}
+
+func crtSpv() (*asset, error) {
+	bytes, err := crtSpvBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "crt.spv", size: 2064, mode: os.FileMode(420), modTime: time.Unix(1792327966, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _fragSpv = []byte("\x03\x02#\a\x00\x00\x01\x00\x00\x00\x00\x00\x1b\x00\x00\x00\x00\x00\x00\x00\x11\x00\x02\x00\x01\x00\x00\x00\v\x00\x06\x00\x01\x00\x00\x00GLSL.std.450\x00\x00\x00\x00\x0e\x00\x03\x00\x00\x00\x00\x00\x01\x00\x00\x00\x0f\x00\b\x00\x04\x00\x00\x00\x02\x00\x00\x00main\x00\x00\x00\x00\x03\x00\x00\x00\x04\x00\x00\x00\x05\x00\x00\x00\x10\x00\x03\x00\x02\x00\x00\x00\a\x00\x00\x00\x03\x00\x03\x00\x02\x00\x00\x00\xc2\x01\x00\x00\x05\x00\x04\x00\x02\x00\x00\x00main\x00\x00\x00\x00\x05\x00\x05\x00\x03\x00\x00\x00outColor\x00\x00\x00\x00\x05\x00\x05\x00\x06\x00\x00\x00texSampler\x00\x00\x05\x00\x06\x00\x04\x00\x00\x00fragTexCoord\x00\x00\x00\x00\x05\x00\x05\x00\x05\x00\x00\x00fragColor\x00\x00\x00G\x00\x04\x00\x03\x00\x00\x00\x1e\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\x06\x00\x00\x00\"\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\x06\x00\x00\x00!\x00\x00\x00\x01\x00\x00\x00G\x00\x04\x00\x04\x00\x00\x00\x1e\x00\x00\x00\x01\x00\x00\x00G\x00\x04\x00\x05\x00\x00\x00\x1e\x00\x00\x00\x00\x00\x00\x00\x13\x00\x02\x00\a\x00\x00\x00!\x00\x03\x00\b\x00\x00\x00\a\x00\x00\x00\x16\x00\x03\x00\t\x00\x00\x00 \x00\x00\x00\x17\x00\x04\x00\n\x00\x00\x00\t\x00\x00\x00\x04\x00\x00\x00 \x00\x04\x00\v\x00\x00\x00\x03\x00\x00\x00\n\x00\x00\x00;\x00\x04\x00\v\x00\x00\x00\x03\x00\x00\x00\x03\x00\x00\x00\x19\x00\t\x00\f\x00\x00\x00\t\x00\x00\x00\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x01\x00\x00\x00\x00\x00\x00\x00\x1b\x00\x03\x00\r\x00\x00\x00\f\x00\x00\x00 \x00\x04\x00\x0e\x00\x00\x00\x00\x00\x00\x00\r\x00\x00\x00;\x00\x04\x00\x0e\x00\x00\x00\x06\x00\x00\x00\x00\x00\x00\x00\x17\x00\x04\x00\x0f\x00\x00\x00\t\x00\x00\x00\x02\x00\x00\x00 \x00\x04\x00\x10\x00\x00\x00\x01\x00\x00\x00\x0f\x00\x00\x00;\x00\x04\x00\x10\x00\x00\x00\x04\x00\x00\x00\x01\x00\x00\x00\x17\x00\x04\x00\x11\x00\x00\x00\t\x00\x00\x00\x03\x00\x00\x00 \x00\x04\x00\x12\x00\x00\x00\x01\x00\x00\x00\n\x00\x00\x00;\x00\x04\x00\x12\x00\x00\x00\x05\x00\x00\x00\x01\x00\x00\x00+\x00\x04\x00\t\x00\x00\x00\x13\x00\x00\x00\x00\x00\x80?6\x00\x05\x00\a\x00\x00\x00\x02\x00\x00\x00\x00\x00\x00\x00\b\x00\x00\x00\xf8\x00\x02\x00\x14\x00\x00\x00=\x00\x04\x00\r\x00\x00\x00\x15\x00\x00\x00\x06\x00\x00\x00=\x00\x04\x00\x0f\x00\x00\x00\x16\x00\x00\x00\x04\x00\x00\x00W\x00\x05\x00\n\x00\x00\x00\x17\x00\x00\x00\x15\x00\x00\x00\x16\x00\x00\x00=\x00\x04\x00\n\x00\x00\x00\x18\x00\x00\x00\x05\x00\x00\x00\x85\x00\x05\x00\n\x00\x00\x00\x1a\x00\x00\x00\x17\x00\x00\x00\x18\x00\x00\x00>\x00\x03\x00\x03\x00\x00\x00\x1a\x00\x00\x00\xfd\x00\x01\x008\x00\x01\x00")
 
 func fragSpvBytes() ([]byte, error) {
 	return _fragSpv, nil
@@ -56,12 +152,97 @@ func fragSpv() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "frag.spv", size: 692, mode: os.FileMode(420), modTime: time.Unix(1585938058, 0)}
+	info := bindataFileInfo{name: "frag.spv", size: 708, mode: os.FileMode(420), modTime: time.Unix(1792329521, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _vertSpv = []byte("\x03\x02#\a\x00\x00\x01\x00\a\x00\b\x005\x00\x00\x00\x00\x00\x00\x00\x11\x00\x02\x00\x01\x00\x00\x00\v\x00\x06\x00\x01\x00\x00\x00GLSL.std.450\x00\x00\x00\x00\x0e\x00\x03\x00\x00\x00\x00\x00\x01\x00\x00\x00\x0f\x00\v\x00\x00\x00\x00\x00\x04\x00\x00\x00main\x00\x00\x00\x00\r\x00\x00\x00!\x00\x00\x00-\x00\x00\x00/\x00\x00\x002\x00\x00\x003\x00\x00\x00\x03\x00\x03\x00\x02\x00\x00\x00\xc2\x01\x00\x00\x04\x00\t\x00GL_ARB_separate_shader_objects\x00\x00\x05\x00\x04\x00\x04\x00\x00\x00main\x00\x00\x00\x00\x05\x00\x06\x00\v\x00\x00\x00gl_PerVertex\x00\x00\x00\x00\x06\x00\x06\x00\v\x00\x00\x00\x00\x00\x00\x00gl_Position\x00\x06\x00\a\x00\v\x00\x00\x00\x01\x00\x00\x00gl_PointSize\x00\x00\x00\x00\x06\x00\a\x00\v\x00\x00\x00\x02\x00\x00\x00gl_ClipDistance\x00\x06\x00\a\x00\v\x00\x00\x00\x03\x00\x00\x00gl_CullDistance\x00\x05\x00\x03\x00\r\x00\x00\x00\x00\x00\x00\x00\x05\x00\a\x00\x11\x00\x00\x00UniformBufferObject\x00\x06\x00\x05\x00\x11\x00\x00\x00\x00\x00\x00\x00model\x00\x00\x00\x06\x00\x05\x00\x11\x00\x00\x00\x01\x00\x00\x00view\x00\x00\x00\x00\x06\x00\x05\x00\x11\x00\x00\x00\x02\x00\x00\x00proj\x00\x00\x00\x00\x05\x00\x03\x00\x13\x00\x00\x00ubo\x00\x05\x00\x05\x00!\x00\x00\x00inPosition\x00\x00\x05\x00\x05\x00-\x00\x00\x00fragColor\x00\x00\x00\x05\x00\x04\x00/\x00\x00\x00inColor\x00\x05\x00\x06\x002\x00\x00\x00fragTexCoord\x00\x00\x00\x00\x05\x00\x05\x003\x00\x00\x00inTexCoord\x00\x00H\x00\x05\x00\v\x00\x00\x00\x00\x00\x00\x00\v\x00\x00\x00\x00\x00\x00\x00H\x00\x05\x00\v\x00\x00\x00\x01\x00\x00\x00\v\x00\x00\x00\x01\x00\x00\x00H\x00\x05\x00\v\x00\x00\x00\x02\x00\x00\x00\v\x00\x00\x00\x03\x00\x00\x00H\x00\x05\x00\v\x00\x00\x00\x03\x00\x00\x00\v\x00\x00\x00\x04\x00\x00\x00G\x00\x03\x00\v\x00\x00\x00\x02\x00\x00\x00H\x00\x04\x00\x11\x00\x00\x00\x00\x00\x00\x00\x05\x00\x00\x00H\x00\x05\x00\x11\x00\x00\x00\x00\x00\x00\x00#\x00\x00\x00\x00\x00\x00\x00H\x00\x05\x00\x11\x00\x00\x00\x00\x00\x00\x00\a\x00\x00\x00\x10\x00\x00\x00H\x00\x04\x00\x11\x00\x00\x00\x01\x00\x00\x00\x05\x00\x00\x00H\x00\x05\x00\x11\x00\x00\x00\x01\x00\x00\x00#\x00\x00\x00@\x00\x00\x00H\x00\x05\x00\x11\x00\x00\x00\x01\x00\x00\x00\a\x00\x00\x00\x10\x00\x00\x00H\x00\x04\x00\x11\x00\x00\x00\x02\x00\x00\x00\x05\x00\x00\x00H\x00\x05\x00\x11\x00\x00\x00\x02\x00\x00\x00#\x00\x00\x00\x80\x00\x00\x00H\x00\x05\x00\x11\x00\x00\x00\x02\x00\x00\x00\a\x00\x00\x00\x10\x00\x00\x00G\x00\x03\x00\x11\x00\x00\x00\x02\x00\x00\x00G\x00\x04\x00\x13\x00\x00\x00\"\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\x13\x00\x00\x00!\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00!\x00\x00\x00\x1e\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00-\x00\x00\x00\x1e\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00/\x00\x00\x00\x1e\x00\x00\x00\x01\x00\x00\x00G\x00\x04\x002\x00\x00\x00\x1e\x00\x00\x00\x01\x00\x00\x00G\x00\x04\x003\x00\x00\x00\x1e\x00\x00\x00\x02\x00\x00\x00\x13\x00\x02\x00\x02\x00\x00\x00!\x00\x03\x00\x03\x00\x00\x00\x02\x00\x00\x00\x16\x00\x03\x00\x06\x00\x00\x00 \x00\x00\x00\x17\x00\x04\x00\a\x00\x00\x00\x06\x00\x00\x00\x04\x00\x00\x00\x15\x00\x04\x00\b\x00\x00\x00 \x00\x00\x00\x00\x00\x00\x00+\x00\x04\x00\b\x00\x00\x00\t\x00\x00\x00\x01\x00\x00\x00\x1c\x00\x04\x00\n\x00\x00\x00\x06\x00\x00\x00\t\x00\x00\x00\x1e\x00\x06\x00\v\x00\x00\x00\a\x00\x00\x00\x06\x00\x00\x00\n\x00\x00\x00\n\x00\x00\x00 \x00\x04\x00\f\x00\x00\x00\x03\x00\x00\x00\v\x00\x00\x00;\x00\x04\x00\f\x00\x00\x00\r\x00\x00\x00\x03\x00\x00\x00\x15\x00\x04\x00\x0e\x00\x00\x00 \x00\x00\x00\x01\x00\x00\x00+\x00\x04\x00\x0e\x00\x00\x00\x0f\x00\x00\x00\x00\x00\x00\x00\x18\x00\x04\x00\x10\x00\x00\x00\a\x00\x00\x00\x04\x00\x00\x00\x1e\x00\x05\x00\x11\x00\x00\x00\x10\x00\x00\x00\x10\x00\x00\x00\x10\x00\x00\x00 \x00\x04\x00\x12\x00\x00\x00\x02\x00\x00\x00\x11\x00\x00\x00;\x00\x04\x00\x12\x00\x00\x00\x13\x00\x00\x00\x02\x00\x00\x00+\x00\x04\x00\x0e\x00\x00\x00\x14\x00\x00\x00\x02\x00\x00\x00 \x00\x04\x00\x15\x00\x00\x00\x02\x00\x00\x00\x10\x00\x00\x00+\x00\x04\x00\x0e\x00\x00\x00\x18\x00\x00\x00\x01\x00\x00\x00\x17\x00\x04\x00\x1f\x00\x00\x00\x06\x00\x00\x00\x02\x00\x00\x00 \x00\x04\x00 \x00\x00\x00\x01\x00\x00\x00\x1f\x00\x00\x00;\x00\x04\x00 \x00\x00\x00!\x00\x00\x00\x01\x00\x00\x00+\x00\x04\x00\x06\x00\x00\x00#\x00\x00\x00\x00\x00\x00\x00+\x00\x04\x00\x06\x00\x00\x00$\x00\x00\x00\x00\x00\x80? \x00\x04\x00)\x00\x00\x00\x03\x00\x00\x00\a\x00\x00\x00\x17\x00\x04\x00+\x00\x00\x00\x06\x00\x00\x00\x03\x00\x00\x00 \x00\x04\x00,\x00\x00\x00\x03\x00\x00\x00+\x00\x00\x00;\x00\x04\x00,\x00\x00\x00-\x00\x00\x00\x03\x00\x00\x00 \x00\x04\x00.\x00\x00\x00\x01\x00\x00\x00+\x00\x00\x00;\x00\x04\x00.\x00\x00\x00/\x00\x00\x00\x01\x00\x00\x00 \x00\x04\x001\x00\x00\x00\x03\x00\x00\x00\x1f\x00\x00\x00;\x00\x04\x001\x00\x00\x002\x00\x00\x00\x03\x00\x00\x00;\x00\x04\x00 \x00\x00\x003\x00\x00\x00\x01\x00\x00\x006\x00\x05\x00\x02\x00\x00\x00\x04\x00\x00\x00\x00\x00\x00\x00\x03\x00\x00\x00\xf8\x00\x02\x00\x05\x00\x00\x00A\x00\x05\x00\x15\x00\x00\x00\x16\x00\x00\x00\x13\x00\x00\x00\x14\x00\x00\x00=\x00\x04\x00\x10\x00\x00\x00\x17\x00\x00\x00\x16\x00\x00\x00A\x00\x05\x00\x15\x00\x00\x00\x19\x00\x00\x00\x13\x00\x00\x00\x18\x00\x00\x00=\x00\x04\x00\x10\x00\x00\x00\x1a\x00\x00\x00\x19\x00\x00\x00\x92\x00\x05\x00\x10\x00\x00\x00\x1b\x00\x00\x00\x17\x00\x00\x00\x1a\x00\x00\x00A\x00\x05\x00\x15\x00\x00\x00\x1c\x00\x00\x00\x13\x00\x00\x00\x0f\x00\x00\x00=\x00\x04\x00\x10\x00\x00\x00\x1d\x00\x00\x00\x1c\x00\x00\x00\x92\x00\x05\x00\x10\x00\x00\x00\x1e\x00\x00\x00\x1b\x00\x00\x00\x1d\x00\x00\x00=\x00\x04\x00\x1f\x00\x00\x00\"\x00\x00\x00!\x00\x00\x00Q\x00\x05\x00\x06\x00\x00\x00%\x00\x00\x00\"\x00\x00\x00\x00\x00\x00\x00Q\x00\x05\x00\x06\x00\x00\x00&\x00\x00\x00\"\x00\x00\x00\x01\x00\x00\x00P\x00\a\x00\a\x00\x00\x00'\x00\x00\x00%\x00\x00\x00&\x00\x00\x00#\x00\x00\x00$\x00\x00\x00\x91\x00\x05\x00\a\x00\x00\x00(\x00\x00\x00\x1e\x00\x00\x00'\x00\x00\x00A\x00\x05\x00)\x00\x00\x00*\x00\x00\x00\r\x00\x00\x00\x0f\x00\x00\x00>\x00\x03\x00*\x00\x00\x00(\x00\x00\x00=\x00\x04\x00+\x00\x00\x000\x00\x00\x00/\x00\x00\x00>\x00\x03\x00-\x00\x00\x000\x00\x00\x00=\x00\x04\x00\x1f\x00\x00\x004\x00\x00\x003\x00\x00\x00>\x00\x03\x002\x00\x00\x004\x00\x00\x00\xfd\x00\x01\x008\x00\x01\x00")
+var _fullscreenSpv = []byte("\x03\x02#\a\x00\x00\x01\x00\x00\x00\x00\x00!\x00\x00\x00\x00\x00\x00\x00\x11\x00\x02\x00\x01\x00\x00\x00\x0e\x00\x03\x00\x00\x00\x00\x00\x01\x00\x00\x00\x0f\x00\b\x00\x00\x00\x00\x00\x01\x00\x00\x00main\x00\x00\x00\x00\x02\x00\x00\x00\x03\x00\x00\x00\x04\x00\x00\x00\x03\x00\x03\x00\x02\x00\x00\x00\xc2\x01\x00\x00\x05\x00\x04\x00\x01\x00\x00\x00main\x00\x00\x00\x00\x05\x00\x06\x00\x02\x00\x00\x00gl_VertexIndex\x00\x00\x05\x00\x03\x00\x03\x00\x00\x00uv\x00\x00\x05\x00\x05\x00\x04\x00\x00\x00gl_Position\x00G\x00\x04\x00\x02\x00\x00\x00\v\x00\x00\x00*\x00\x00\x00G\x00\x04\x00\x03\x00\x00\x00\x1e\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\x04\x00\x00\x00\v\x00\x00\x00\x00\x00\x00\x00\x13\x00\x02\x00\x05\x00\x00\x00!\x00\x03\x00\x06\x00\x00\x00\x05\x00\x00\x00\x15\x00\x04\x00\a\x00\x00\x00 \x00\x00\x00\x01\x00\x00\x00\x16\x00\x03\x00\b\x00\x00\x00 \x00\x00\x00\x17\x00\x04\x00\t\x00\x00\x00\b\x00\x00\x00\x02\x00\x00\x00\x17\x00\x04\x00\n\x00\x00\x00\b\x00\x00\x00\x04\x00\x00\x00 \x00\x04\x00\v\x00\x00\x00\x01\x00\x00\x00\a\x00\x00\x00;\x00\x04\x00\v\x00\x00\x00\x02\x00\x00\x00\x01\x00\x00\x00 \x00\x04\x00\f\x00\x00\x00\x03\x00\x00\x00\t\x00\x00\x00;\x00\x04\x00\f\x00\x00\x00\x03\x00\x00\x00\x03\x00\x00\x00 \x00\x04\x00\r\x00\x00\x00\x03\x00\x00\x00\n\x00\x00\x00;\x00\x04\x00\r\x00\x00\x00\x04\x00\x00\x00\x03\x00\x00\x00+\x00\x04\x00\a\x00\x00\x00\x0e\x00\x00\x00\x01\x00\x00\x00+\x00\x04\x00\a\x00\x00\x00\x0f\x00\x00\x00\x02\x00\x00\x00+\x00\x04\x00\b\x00\x00\x00\x10\x00\x00\x00\x00\x00\x00\x00+\x00\x04\x00\b\x00\x00\x00\x11\x00\x00\x00\x00\x00\x80?+\x00\x04\x00\b\x00\x00\x00\x12\x00\x00\x00\x00\x00\x00@,\x00\x05\x00\t\x00\x00\x00\x13\x00\x00\x00\x11\x00\x00\x00\x11\x00\x00\x006\x00\x05\x00\x05\x00\x00\x00\x01\x00\x00\x00\x00\x00\x00\x00\x06\x00\x00\x00\xf8\x00\x02\x00\x14\x00\x00\x00=\x00\x04\x00\a\x00\x00\x00\x15\x00\x00\x00\x02\x00\x00\x00\xc4\x00\x05\x00\a\x00\x00\x00\x16\x00\x00\x00\x15\x00\x00\x00\x0e\x00\x00\x00\xc7\x00\x05\x00\a\x00\x00\x00\x17\x00\x00\x00\x16\x00\x00\x00\x0f\x00\x00\x00\xc7\x00\x05\x00\a\x00\x00\x00\x18\x00\x00\x00\x15\x00\x00\x00\x0f\x00\x00\x00o\x00\x04\x00\b\x00\x00\x00\x19\x00\x00\x00\x17\x00\x00\x00o\x00\x04\x00\b\x00\x00\x00\x1a\x00\x00\x00\x18\x00\x00\x00P\x00\x05\x00\t\x00\x00\x00\x1b\x00\x00\x00\x19\x00\x00\x00\x1a\x00\x00\x00>\x00\x03\x00\x03\x00\x00\x00\x1b\x00\x00\x00\x8e\x00\x05\x00\t\x00\x00\x00\x1c\x00\x00\x00\x1b\x00\x00\x00\x12\x00\x00\x00\x83\x00\x05\x00\t\x00\x00\x00\x1d\x00\x00\x00\x1c\x00\x00\x00\x13\x00\x00\x00Q\x00\x05\x00\b\x00\x00\x00\x1e\x00\x00\x00\x1d\x00\x00\x00\x00\x00\x00\x00Q\x00\x05\x00\b\x00\x00\x00\x1f\x00\x00\x00\x1d\x00\x00\x00\x01\x00\x00\x00P\x00\a\x00\n\x00\x00\x00 \x00\x00\x00\x1e\x00\x00\x00\x1f\x00\x00\x00\x10\x00\x00\x00\x11\x00\x00\x00>\x00\x03\x00\x04\x00\x00\x00 \x00\x00\x00\xfd\x00\x01\x008\x00\x01\x00")
+
+func fullscreenSpvBytes() ([]byte, error) {
+	return _fullscreenSpv, nil
+}
+
+func fullscreenSpv() (*asset, error) {
+	bytes, err := fullscreenSpvBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "fullscreen.spv", size: 776, mode: os.FileMode(420), modTime: time.Unix(1792327749, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _gradeSpv = []byte("\x03\x02#\a\x00\x00\x01\x00\x00\x00\x00\x00f\x00\x00\x00\x00\x00\x00\x00\x11\x00\x02\x00\x01\x00\x00\x00\x11\x00\x02\x002\x00\x00\x00\v\x00\x06\x00\x01\x00\x00\x00GLSL.std.450\x00\x00\x00\x00\x0e\x00\x03\x00\x00\x00\x00\x00\x01\x00\x00\x00\x0f\x00\a\x00\x04\x00\x00\x00\x02\x00\x00\x00main\x00\x00\x00\x00\x03\x00\x00\x00\x04\x00\x00\x00\x10\x00\x03\x00\x02\x00\x00\x00\a\x00\x00\x00\x03\x00\x03\x00\x02\x00\x00\x00\xc2\x01\x00\x00\x05\x00\x04\x00\x02\x00\x00\x00main\x00\x00\x00\x00\x05\x00\x05\x00\x03\x00\x00\x00outColor\x00\x00\x00\x00\x05\x00\x03\x00\x04\x00\x00\x00uv\x00\x00\x05\x00\x04\x00\x05\x00\x00\x00source\x00\x00\x05\x00\x04\x00\x06\x00\x00\x00lutA\x00\x00\x00\x00\x05\x00\x04\x00\a\x00\x00\x00lutB\x00\x00\x00\x00\x05\x00\x05\x00\b\x00\x00\x00Constants\x00\x00\x00\x06\x00\x06\x00\b\x00\x00\x00\x00\x00\x00\x00resolution\x00\x00\x06\x00\x05\x00\b\x00\x00\x00\x01\x00\x00\x00time\x00\x00\x00\x00\x06\x00\x05\x00\b\x00\x00\x00\x02\x00\x00\x00pass\x00\x00\x00\x00\x06\x00\x05\x00\b\x00\x00\x00\x03\x00\x00\x00params\x00\x00\x05\x00\x05\x00\t\x00\x00\x00constants\x00\x00\x00G\x00\x04\x00\x03\x00\x00\x00\x1e\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\x04\x00\x00\x00\x1e\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\x05\x00\x00\x00\"\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\x05\x00\x00\x00!\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\x06\x00\x00\x00\"\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\x06\x00\x00\x00!\x00\x00\x00\x02\x00\x00\x00G\x00\x04\x00\a\x00\x00\x00\"\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\a\x00\x00\x00!\x00\x00\x00\x03\x00\x00\x00G\x00\x04\x00\n\x00\x00\x00\x06\x00\x00\x00\x10\x00\x00\x00H\x00\x05\x00\b\x00\x00\x00\x00\x00\x00\x00#\x00\x00\x00\x00\x00\x00\x00H\x00\x05\x00\b\x00\x00\x00\x01\x00\x00\x00#\x00\x00\x00\b\x00\x00\x00H\x00\x05\x00\b\x00\x00\x00\x02\x00\x00\x00#\x00\x00\x00\f\x00\x00\x00H\x00\x05\x00\b\x00\x00\x00\x03\x00\x00\x00#\x00\x00\x00\x10\x00\x00\x00G\x00\x03\x00\b\x00\x00\x00\x02\x00\x00\x00\x13\x00\x02\x00\v\x00\x00\x00!\x00\x03\x00\f\x00\x00\x00\v\x00\x00\x00\x14\x00\x02\x00\r\x00\x00\x00\x16\x00\x03\x00\x0e\x00\x00\x00 \x00\x00\x00\x15\x00\x04\x00\x0f\x00\x00\x00 \x00\x00\x00\x01\x00\x00\x00\x15\x00\x04\x00\x10\x00\x00\x00 \x00\x00\x00\x00\x00\x00\x00\x17\x00\x04\x00\x11\x00\x00\x00\x0e\x00\x00\x00\x02\x00\x00\x00\x17\x00\x04\x00\x12\x00\x00\x00\x0e\x00\x00\x00\x03\x00\x00\x00\x17\x00\x04\x00\x13\x00\x00\x00\x0e\x00\x00\x00\x04\x00\x00\x00+\x00\x04\x00\x10\x00\x00\x00\x14\x00\x00\x00\a\x00\x00\x00\x1c\x00\x04\x00\n\x00\x00\x00\x13\x00\x00\x00\x14\x00\x00\x00\x1e\x00\x06\x00\b\x00\x00\x00\x11\x00\x00\x00\x0e\x00\x00\x00\x0e\x00\x00\x00\n\x00\x00\x00 \x00\x04\x00\x15\x00\x00\x00\t\x00\x00\x00\b\x00\x00\x00;\x00\x04\x00\x15\x00\x00\x00\t\x00\x00\x00\t\x00\x00\x00 \x00\x04\x00\x16\x00\x00\x00\t\x00\x00\x00\x11\x00\x00\x00 \x00\x04\x00\x17\x00\x00\x00\t\x00\x00\x00\x0e\x00\x00\x00 \x00\x04\x00\x18\x00\x00\x00\t\x00\x00\x00\x13\x00\x00\x00 \x00\x04\x00\x19\x00\x00\x00\x03\x00\x00\x00\x13\x00\x00\x00;\x00\x04\x00\x19\x00\x00\x00\x03\x00\x00\x00\x03\x00\x00\x00 \x00\x04\x00\x1a\x00\x00\x00\x01\x00\x00\x00\x11\x00\x00\x00;\x00\x04\x00\x1a\x00\x00\x00\x04\x00\x00\x00\x01\x00\x00\x00\x19\x00\t\x00\x1b\x00\x00\x00\x0e\x00\x00\x00\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x01\x00\x00\x00\x00\x00\x00\x00\x1b\x00\x03\x00\x1c\x00\x00\x00\x1b\x00\x00\x00 \x00\x04\x00\x1d\x00\x00\x00\x00\x00\x00\x00\x1c\x00\x00\x00;\x00\x04\x00\x1d\x00\x00\x00\x05\x00\x00\x00\x00\x00\x00\x00\x19\x00\t\x00\x1e\x00\x00\x00\x0e\x00\x00\x00\x02\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x01\x00\x00\x00\x00\x00\x00\x00\x1b\x00\x03\x00\x1f\x00\x00\x00\x1e\x00\x00\x00 \x00\x04\x00 \x00\x00\x00\x00\x00\x00\x00\x1f\x00\x00\x00;\x00\x04\x00 \x00\x00\x00\x06\x00\x00\x00\x00\x00\x00\x00;\x00\x04\x00 \x00\x00\x00\a\x00\x00\x00\x00\x00\x00\x00\x17\x00\x04\x00!\x00\x00\x00\x0f\x00\x00\x00\x03\x00\x00\x00+\x00\x04\x00\x0f\x00\x00\x00\"\x00\x00\x00\x00\x00\x00\x00+\x00\x04\x00\x0f\x00\x00\x00#\x00\x00\x00\x01\x00\x00\x00+\x00\x04\x00\x0f\x00\x00\x00$\x00\x00\x00\x02\x00\x00\x00+\x00\x04\x00\x0f\x00\x00\x00%\x00\x00\x00\x03\x00\x00\x00+\x00\x04\x00\x0e\x00\x00\x00&\x00\x00\x00\x00\x00\x00\x00+\x00\x04\x00\x0e\x00\x00\x00'\x00\x00\x00\x00\x00\x80>+\x00\x04\x00\x0e\x00\x00\x00(\x00\x00\x00\x00\x00\x00?+\x00\x04\x00\x0e\x00\x00\x00)\x00\x00\x00\x00\x00\x80?+\x00\x04\x00\x0e\x00\x00\x00*\x00\x00\x00\x00\x00\x00@,\x00\x05\x00\x11\x00\x00\x00+\x00\x00\x00(\x00\x00\x00(\x00\x00\x00,\x00\x05\x00\x11\x00\x00\x00,\x00\x00\x00)\x00\x00\x00)\x00\x00\x00+\x00\x04\x00\x0e\x00\x00\x00-\x00\x00\x00гY>+\x00\x04\x00\x0e\x00\x00\x00.\x00\x00\x00Y\x177?+\x00\x04\x00\x0e\x00\x00\x00/\x00\x00\x00\x98ݓ=,\x00\x06\x00\x12\x00\x00\x000\x00\x00\x00-\x00\x00\x00.\x00\x00\x00/\x00\x00\x00+\x00\x04\x00\x0e\x00\x00\x001\x00\x00\x00\x17\xb7\xd18+\x00\x04\x00\x0e\x00\x00\x002\x00\x00\x00\xdb\x0f\xc9@,\x00\x06\x00\x12\x00\x00\x003\x00\x00\x00)\x00\x00\x00)\x00\x00\x00)\x00\x00\x00,\x00\x06\x00\x12\x00\x00\x004\x00\x00\x00(\x00\x00\x00(\x00\x00\x00(\x00\x00\x006\x00\x05\x00\v\x00\x00\x00\x02\x00\x00\x00\x00\x00\x00\x00\f\x00\x00\x00\xf8\x00\x02\x005\x00\x00\x00A\x00\x05\x00\x16\x00\x00\x006\x00\x00\x00\t\x00\x00\x00\"\x00\x00\x00=\x00\x04\x00\x11\x00\x00\x007\x00\x00\x006\x00\x00\x00A\x00\x05\x00\x17\x00\x00\x008\x00\x00\x00\t\x00\x00\x00#\x00\x00\x00=\x00\x04\x00\x0e\x00\x00\x009\x00\x00\x008\x00\x00\x00A\x00\x05\x00\x17\x00\x00\x00:\x00\x00\x00\t\x00\x00\x00$\x00\x00\x00=\x00\x04\x00\x0e\x00\x00\x00;\x00\x00\x00:\x00\x00\x00A\x00\x06\x00\x18\x00\x00\x00<\x00\x00\x00\t\x00\x00\x00%\x00\x00\x00\"\x00\x00\x00=\x00\x04\x00\x13\x00\x00\x00=\x00\x00\x00<\x00\x00\x00=\x00\x04\x00\x11\x00\x00\x00>\x00\x00\x00\x04\x00\x00\x00=\x00\x04\x00\x1c\x00\x00\x00?\x00\x00\x00\x05\x00\x00\x00W\x00\x05\x00\x13\x00\x00\x00@\x00\x00\x00?\x00\x00\x00>\x00\x00\x00O\x00\b\x00\x12\x00\x00\x00A\x00\x00\x00@\x00\x00\x00@\x00\x00\x00\x00\x00\x00\x00\x01\x00\x00\x00\x02\x00\x00\x00Q\x00\x05\x00\x0e\x00\x00\x00B\x00\x00\x00=\x00\x00\x00\x00\x00\x00\x00Q\x00\x05\x00\x0e\x00\x00\x00C\x00\x00\x00=\x00\x00\x00\x01\x00\x00\x00\f\x00\a\x00\x0e\x00\x00\x00D\x00\x00\x00\x01\x00\x00\x00(\x00\x00\x00C\x00\x00\x001\x00\x00\x00\x88\x00\x05\x00\x0e\x00\x00\x00E\x00\x00\x009\x00\x00\x00D\x00\x00\x00\x85\x00\x05\x00\x0e\x00\x00\x00F\x00\x00\x00E\x00\x00\x002\x00\x00\x00\f\x00\x06\x00\x0e\x00\x00\x00G\x00\x00\x00\x01\x00\x00\x00\x0e\x00\x00\x00F\x00\x00\x00\x85\x00\x05\x00\x0e\x00\x00\x00H\x00\x00\x00G\x00\x00\x00(\x00\x00\x00\x83\x00\x05\x00\x0e\x00\x00\x00I\x00\x00\x00(\x00\x00\x00H\x00\x00\x00\f\x00\a\x00\x0e\x00\x00\x00J\x00\x00\x00\x01\x00\x00\x000\x00\x00\x001\x00\x00\x00C\x00\x00\x00\f\x00\b\x00\x0e\x00\x00\x00K\x00\x00\x00\x01\x00\x00\x00.\x00\x00\x00B\x00\x00\x00I\x00\x00\x00J\x00\x00\x00P\x00\x06\x00\x12\x00\x00\x00L\x00\x00\x00K\x00\x00\x00K\x00\x00\x00K\x00\x00\x00=\x00\x04\x00\x1f\x00\x00\x00M\x00\x00\x00\x06\x00\x00\x00d\x00\x04\x00\x1e\x00\x00\x00N\x00\x00\x00M\x00\x00\x00g\x00\x05\x00!\x00\x00\x00O\x00\x00\x00N\x00\x00\x00\"\x00\x00\x00o\x00\x04\x00\x12\x00\x00\x00P\x00\x00\x00O\x00\x00\x00\x83\x00\x05\x00\x12\x00\x00\x00Q\x00\x00\x00P\x00\x00\x003\x00\x00\x00\x88\x00\x05\x00\x12\x00\x00\x00R\x00\x00\x00Q\x00\x00\x00P\x00\x00\x00\x88\x00\x05\x00\x12\x00\x00\x00S\x00\x00\x004\x00\x00\x00P\x00\x00\x00\x85\x00\x05\x00\x12\x00\x00\x00T\x00\x00\x00A\x00\x00\x00R\x00\x00\x00\x81\x00\x05\x00\x12\x00\x00\x00U\x00\x00\x00T\x00\x00\x00S\x00\x00\x00W\x00\x05\x00\x13\x00\x00\x00V\x00\x00\x00M\x00\x00\x00U\x00\x00\x00O\x00\b\x00\x12\x00\x00\x00W\x00\x00\x00V\x00\x00\x00V\x00\x00\x00\x00\x00\x00\x00\x01\x00\x00\x00\x02\x00\x00\x00=\x00\x04\x00\x1f\x00\x00\x00X\x00\x00\x00\a\x00\x00\x00d\x00\x04\x00\x1e\x00\x00\x00Y\x00\x00\x00X\x00\x00\x00g\x00\x05\x00!\x00\x00\x00Z\x00\x00\x00Y\x00\x00\x00\"\x00\x00\x00o\x00\x04\x00\x12\x00\x00\x00[\x00\x00\x00Z\x00\x00\x00\x83\x00\x05\x00\x12\x00\x00\x00\\\x00\x00\x00[\x00\x00\x003\x00\x00\x00\x88\x00\x05\x00\x12\x00\x00\x00]\x00\x00\x00\\\x00\x00\x00[\x00\x00\x00\x88\x00\x05\x00\x12\x00\x00\x00^\x00\x00\x004\x00\x00\x00[\x00\x00\x00\x85\x00\x05\x00\x12\x00\x00\x00_\x00\x00\x00A\x00\x00\x00]\x00\x00\x00\x81\x00\x05\x00\x12\x00\x00\x00`\x00\x00\x00_\x00\x00\x00^\x00\x00\x00W\x00\x05\x00\x13\x00\x00\x00a\x00\x00\x00X\x00\x00\x00`\x00\x00\x00O\x00\b\x00\x12\x00\x00\x00b\x00\x00\x00a\x00\x00\x00a\x00\x00\x00\x00\x00\x00\x00\x01\x00\x00\x00\x02\x00\x00\x00\f\x00\b\x00\x12\x00\x00\x00c\x00\x00\x00\x01\x00\x00\x00.\x00\x00\x00W\x00\x00\x00b\x00\x00\x00L\x00\x00\x00Q\x00\x05\x00\x0e\x00\x00\x00d\x00\x00\x00@\x00\x00\x00\x03\x00\x00\x00P\x00\x05\x00\x13\x00\x00\x00e\x00\x00\x00c\x00\x00\x00d\x00\x00\x00>\x00\x03\x00\x03\x00\x00\x00e\x00\x00\x00\xfd\x00\x01\x008\x00\x01\x00")
+
+func gradeSpvBytes() ([]byte, error) {
+	return _gradeSpv, nil
+}
+
+func gradeSpv() (*asset, error) {
+	bytes, err := gradeSpvBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "grade.spv", size: 2476, mode: os.FileMode(420), modTime: time.Unix(1792328222, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _grayscaleSpv = []byte("\x03\x02#\a\x00\x00\x01\x00\x00\x00\x00\x00?\x00\x00\x00\x00\x00\x00\x00\x11\x00\x02\x00\x01\x00\x00\x00\v\x00\x06\x00\x01\x00\x00\x00GLSL.std.450\x00\x00\x00\x00\x0e\x00\x03\x00\x00\x00\x00\x00\x01\x00\x00\x00\x0f\x00\a\x00\x04\x00\x00\x00\x02\x00\x00\x00main\x00\x00\x00\x00\x03\x00\x00\x00\x04\x00\x00\x00\x10\x00\x03\x00\x02\x00\x00\x00\a\x00\x00\x00\x03\x00\x03\x00\x02\x00\x00\x00\xc2\x01\x00\x00\x05\x00\x04\x00\x02\x00\x00\x00main\x00\x00\x00\x00\x05\x00\x05\x00\x03\x00\x00\x00outColor\x00\x00\x00\x00\x05\x00\x03\x00\x04\x00\x00\x00uv\x00\x00\x05\x00\x04\x00\x05\x00\x00\x00source\x00\x00\x05\x00\x05\x00\x06\x00\x00\x00Constants\x00\x00\x00\x06\x00\x06\x00\x06\x00\x00\x00\x00\x00\x00\x00resolution\x00\x00\x06\x00\x05\x00\x06\x00\x00\x00\x01\x00\x00\x00time\x00\x00\x00\x00\x06\x00\x05\x00\x06\x00\x00\x00\x02\x00\x00\x00pass\x00\x00\x00\x00\x06\x00\x05\x00\x06\x00\x00\x00\x03\x00\x00\x00params\x00\x00\x05\x00\x05\x00\a\x00\x00\x00constants\x00\x00\x00G\x00\x04\x00\x03\x00\x00\x00\x1e\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\x04\x00\x00\x00\x1e\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\x05\x00\x00\x00\"\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\x05\x00\x00\x00!\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\b\x00\x00\x00\x06\x00\x00\x00\x10\x00\x00\x00H\x00\x05\x00\x06\x00\x00\x00\x00\x00\x00\x00#\x00\x00\x00\x00\x00\x00\x00H\x00\x05\x00\x06\x00\x00\x00\x01\x00\x00\x00#\x00\x00\x00\b\x00\x00\x00H\x00\x05\x00\x06\x00\x00\x00\x02\x00\x00\x00#\x00\x00\x00\f\x00\x00\x00H\x00\x05\x00\x06\x00\x00\x00\x03\x00\x00\x00#\x00\x00\x00\x10\x00\x00\x00G\x00\x03\x00\x06\x00\x00\x00\x02\x00\x00\x00\x13\x00\x02\x00\t\x00\x00\x00!\x00\x03\x00\n\x00\x00\x00\t\x00\x00\x00\x14\x00\x02\x00\v\x00\x00\x00\x16\x00\x03\x00\f\x00\x00\x00 \x00\x00\x00\x15\x00\x04\x00\r\x00\x00\x00 \x00\x00\x00\x01\x00\x00\x00\x15\x00\x04\x00\x0e\x00\x00\x00 \x00\x00\x00\x00\x00\x00\x00\x17\x00\x04\x00\x0f\x00\x00\x00\f\x00\x00\x00\x02\x00\x00\x00\x17\x00\x04\x00\x10\x00\x00\x00\f\x00\x00\x00\x03\x00\x00\x00\x17\x00\x04\x00\x11\x00\x00\x00\f\x00\x00\x00\x04\x00\x00\x00+\x00\x04\x00\x0e\x00\x00\x00\x12\x00\x00\x00\a\x00\x00\x00\x1c\x00\x04\x00\b\x00\x00\x00\x11\x00\x00\x00\x12\x00\x00\x00\x1e\x00\x06\x00\x06\x00\x00\x00\x0f\x00\x00\x00\f\x00\x00\x00\f\x00\x00\x00\b\x00\x00\x00 \x00\x04\x00\x13\x00\x00\x00\t\x00\x00\x00\x06\x00\x00\x00;\x00\x04\x00\x13\x00\x00\x00\a\x00\x00\x00\t\x00\x00\x00 \x00\x04\x00\x14\x00\x00\x00\t\x00\x00\x00\x0f\x00\x00\x00 \x00\x04\x00\x15\x00\x00\x00\t\x00\x00\x00\f\x00\x00\x00 \x00\x04\x00\x16\x00\x00\x00\t\x00\x00\x00\x11\x00\x00\x00 \x00\x04\x00\x17\x00\x00\x00\x03\x00\x00\x00\x11\x00\x00\x00;\x00\x04\x00\x17\x00\x00\x00\x03\x00\x00\x00\x03\x00\x00\x00 \x00\x04\x00\x18\x00\x00\x00\x01\x00\x00\x00\x0f\x00\x00\x00;\x00\x04\x00\x18\x00\x00\x00\x04\x00\x00\x00\x01\x00\x00\x00\x19\x00\t\x00\x19\x00\x00\x00\f\x00\x00\x00\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x01\x00\x00\x00\x00\x00\x00\x00\x1b\x00\x03\x00\x1a\x00\x00\x00\x19\x00\x00\x00 \x00\x04\x00\x1b\x00\x00\x00\x00\x00\x00\x00\x1a\x00\x00\x00;\x00\x04\x00\x1b\x00\x00\x00\x05\x00\x00\x00\x00\x00\x00\x00+\x00\x04\x00\r\x00\x00\x00\x1c\x00\x00\x00\x00\x00\x00\x00+\x00\x04\x00\r\x00\x00\x00\x1d\x00\x00\x00\x01\x00\x00\x00+\x00\x04\x00\r\x00\x00\x00\x1e\x00\x00\x00\x02\x00\x00\x00+\x00\x04\x00\r\x00\x00\x00\x1f\x00\x00\x00\x03\x00\x00\x00+\x00\x04\x00\f\x00\x00\x00 \x00\x00\x00\x00\x00\x00\x00+\x00\x04\x00\f\x00\x00\x00!\x00\x00\x00\x00\x00\x80>+\x00\x04\x00\f\x00\x00\x00\"\x00\x00\x00\x00\x00\x00?+\x00\x04\x00\f\x00\x00\x00#\x00\x00\x00\x00\x00\x80?+\x00\x04\x00\f\x00\x00\x00$\x00\x00\x00\x00\x00\x00@,\x00\x05\x00\x0f\x00\x00\x00%\x00\x00\x00\"\x00\x00\x00\"\x00\x00\x00,\x00\x05\x00\x0f\x00\x00\x00&\x00\x00\x00#\x00\x00\x00#\x00\x00\x00+\x00\x04\x00\f\x00\x00\x00'\x00\x00\x00гY>+\x00\x04\x00\f\x00\x00\x00(\x00\x00\x00Y\x177?+\x00\x04\x00\f\x00\x00\x00)\x00\x00\x00\x98ݓ=,\x00\x06\x00\x10\x00\x00\x00*\x00\x00\x00'\x00\x00\x00(\x00\x00\x00)\x00\x00\x006\x00\x05\x00\t\x00\x00\x00\x02\x00\x00\x00\x00\x00\x00\x00\n\x00\x00\x00\xf8\x00\x02\x00+\x00\x00\x00A\x00\x05\x00\x14\x00\x00\x00,\x00\x00\x00\a\x00\x00\x00\x1c\x00\x00\x00=\x00\x04\x00\x0f\x00\x00\x00-\x00\x00\x00,\x00\x00\x00A\x00\x05\x00\x15\x00\x00\x00.\x00\x00\x00\a\x00\x00\x00\x1d\x00\x00\x00=\x00\x04\x00\f\x00\x00\x00/\x00\x00\x00.\x00\x00\x00A\x00\x05\x00\x15\x00\x00\x000\x00\x00\x00\a\x00\x00\x00\x1e\x00\x00\x00=\x00\x04\x00\f\x00\x00\x001\x00\x00\x000\x00\x00\x00A\x00\x06\x00\x16\x00\x00\x002\x00\x00\x00\a\x00\x00\x00\x1f\x00\x00\x00\x1c\x00\x00\x00=\x00\x04\x00\x11\x00\x00\x003\x00\x00\x002\x00\x00\x00=\x00\x04\x00\x0f\x00\x00\x004\x00\x00\x00\x04\x00\x00\x00=\x00\x04\x00\x1a\x00\x00\x005\x00\x00\x00\x05\x00\x00\x00W\x00\x05\x00\x11\x00\x00\x006\x00\x00\x005\x00\x00\x004\x00\x00\x00O\x00\b\x00\x10\x00\x00\x007\x00\x00\x006\x00\x00\x006\x00\x00\x00\x00\x00\x00\x00\x01\x00\x00\x00\x02\x00\x00\x00\x94\x00\x05\x00\f\x00\x00\x008\x00\x00\x007\x00\x00\x00*\x00\x00\x00P\x00\x06\x00\x10\x00\x00\x009\x00\x00\x008\x00\x00\x008\x00\x00\x008\x00\x00\x00Q\x00\x05\x00\f\x00\x00\x00:\x00\x00\x003\x00\x00\x00\x00\x00\x00\x00P\x00\x06\x00\x10\x00\x00\x00;\x00\x00\x00:\x00\x00\x00:\x00\x00\x00:\x00\x00\x00\f\x00\b\x00\x10\x00\x00\x00<\x00\x00\x00\x01\x00\x00\x00.\x00\x00\x007\x00\x00\x009\x00\x00\x00;\x00\x00\x00Q\x00\x05\x00\f\x00\x00\x00=\x00\x00\x006\x00\x00\x00\x03\x00\x00\x00P\x00\x05\x00\x11\x00\x00\x00>\x00\x00\x00<\x00\x00\x00=\x00\x00\x00>\x00\x03\x00\x03\x00\x00\x00>\x00\x00\x00\xfd\x00\x01\x008\x00\x01\x00")
+
+func grayscaleSpvBytes() ([]byte, error) {
+	return _grayscaleSpv, nil
+}
+
+func grayscaleSpv() (*asset, error) {
+	bytes, err := grayscaleSpvBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "grayscale.spv", size: 1572, mode: os.FileMode(420), modTime: time.Unix(1792327966, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _pixelateSpv = []byte("\x03\x02#\a\x00\x00\x01\x00\x00\x00\x00\x00?\x00\x00\x00\x00\x00\x00\x00\x11\x00\x02\x00\x01\x00\x00\x00\v\x00\x06\x00\x01\x00\x00\x00GLSL.std.450\x00\x00\x00\x00\x0e\x00\x03\x00\x00\x00\x00\x00\x01\x00\x00\x00\x0f\x00\a\x00\x04\x00\x00\x00\x02\x00\x00\x00main\x00\x00\x00\x00\x03\x00\x00\x00\x04\x00\x00\x00\x10\x00\x03\x00\x02\x00\x00\x00\a\x00\x00\x00\x03\x00\x03\x00\x02\x00\x00\x00\xc2\x01\x00\x00\x05\x00\x04\x00\x02\x00\x00\x00main\x00\x00\x00\x00\x05\x00\x05\x00\x03\x00\x00\x00outColor\x00\x00\x00\x00\x05\x00\x03\x00\x04\x00\x00\x00uv\x00\x00\x05\x00\x04\x00\x05\x00\x00\x00source\x00\x00\x05\x00\x05\x00\x06\x00\x00\x00Constants\x00\x00\x00\x06\x00\x06\x00\x06\x00\x00\x00\x00\x00\x00\x00resolution\x00\x00\x06\x00\x05\x00\x06\x00\x00\x00\x01\x00\x00\x00time\x00\x00\x00\x00\x06\x00\x05\x00\x06\x00\x00\x00\x02\x00\x00\x00pass\x00\x00\x00\x00\x06\x00\x05\x00\x06\x00\x00\x00\x03\x00\x00\x00params\x00\x00\x05\x00\x05\x00\a\x00\x00\x00constants\x00\x00\x00G\x00\x04\x00\x03\x00\x00\x00\x1e\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\x04\x00\x00\x00\x1e\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\x05\x00\x00\x00\"\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\x05\x00\x00\x00!\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\b\x00\x00\x00\x06\x00\x00\x00\x10\x00\x00\x00H\x00\x05\x00\x06\x00\x00\x00\x00\x00\x00\x00#\x00\x00\x00\x00\x00\x00\x00H\x00\x05\x00\x06\x00\x00\x00\x01\x00\x00\x00#\x00\x00\x00\b\x00\x00\x00H\x00\x05\x00\x06\x00\x00\x00\x02\x00\x00\x00#\x00\x00\x00\f\x00\x00\x00H\x00\x05\x00\x06\x00\x00\x00\x03\x00\x00\x00#\x00\x00\x00\x10\x00\x00\x00G\x00\x03\x00\x06\x00\x00\x00\x02\x00\x00\x00\x13\x00\x02\x00\t\x00\x00\x00!\x00\x03\x00\n\x00\x00\x00\t\x00\x00\x00\x14\x00\x02\x00\v\x00\x00\x00\x16\x00\x03\x00\f\x00\x00\x00 \x00\x00\x00\x15\x00\x04\x00\r\x00\x00\x00 \x00\x00\x00\x01\x00\x00\x00\x15\x00\x04\x00\x0e\x00\x00\x00 \x00\x00\x00\x00\x00\x00\x00\x17\x00\x04\x00\x0f\x00\x00\x00\f\x00\x00\x00\x02\x00\x00\x00\x17\x00\x04\x00\x10\x00\x00\x00\f\x00\x00\x00\x03\x00\x00\x00\x17\x00\x04\x00\x11\x00\x00\x00\f\x00\x00\x00\x04\x00\x00\x00+\x00\x04\x00\x0e\x00\x00\x00\x12\x00\x00\x00\a\x00\x00\x00\x1c\x00\x04\x00\b\x00\x00\x00\x11\x00\x00\x00\x12\x00\x00\x00\x1e\x00\x06\x00\x06\x00\x00\x00\x0f\x00\x00\x00\f\x00\x00\x00\f\x00\x00\x00\b\x00\x00\x00 \x00\x04\x00\x13\x00\x00\x00\t\x00\x00\x00\x06\x00\x00\x00;\x00\x04\x00\x13\x00\x00\x00\a\x00\x00\x00\t\x00\x00\x00 \x00\x04\x00\x14\x00\x00\x00\t\x00\x00\x00\x0f\x00\x00\x00 \x00\x04\x00\x15\x00\x00\x00\t\x00\x00\x00\f\x00\x00\x00 \x00\x04\x00\x16\x00\x00\x00\t\x00\x00\x00\x11\x00\x00\x00 \x00\x04\x00\x17\x00\x00\x00\x03\x00\x00\x00\x11\x00\x00\x00;\x00\x04\x00\x17\x00\x00\x00\x03\x00\x00\x00\x03\x00\x00\x00 \x00\x04\x00\x18\x00\x00\x00\x01\x00\x00\x00\x0f\x00\x00\x00;\x00\x04\x00\x18\x00\x00\x00\x04\x00\x00\x00\x01\x00\x00\x00\x19\x00\t\x00\x19\x00\x00\x00\f\x00\x00\x00\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x01\x00\x00\x00\x00\x00\x00\x00\x1b\x00\x03\x00\x1a\x00\x00\x00\x19\x00\x00\x00 \x00\x04\x00\x1b\x00\x00\x00\x00\x00\x00\x00\x1a\x00\x00\x00;\x00\x04\x00\x1b\x00\x00\x00\x05\x00\x00\x00\x00\x00\x00\x00+\x00\x04\x00\r\x00\x00\x00\x1c\x00\x00\x00\x00\x00\x00\x00+\x00\x04\x00\r\x00\x00\x00\x1d\x00\x00\x00\x01\x00\x00\x00+\x00\x04\x00\r\x00\x00\x00\x1e\x00\x00\x00\x02\x00\x00\x00+\x00\x04\x00\r\x00\x00\x00\x1f\x00\x00\x00\x03\x00\x00\x00+\x00\x04\x00\f\x00\x00\x00 \x00\x00\x00\x00\x00\x00\x00+\x00\x04\x00\f\x00\x00\x00!\x00\x00\x00\x00\x00\x80>+\x00\x04\x00\f\x00\x00\x00\"\x00\x00\x00\x00\x00\x00?+\x00\x04\x00\f\x00\x00\x00#\x00\x00\x00\x00\x00\x80?+\x00\x04\x00\f\x00\x00\x00$\x00\x00\x00\x00\x00\x00@,\x00\x05\x00\x0f\x00\x00\x00%\x00\x00\x00\"\x00\x00\x00\"\x00\x00\x00,\x00\x05\x00\x0f\x00\x00\x00&\x00\x00\x00#\x00\x00\x00#\x00\x00\x00+\x00\x04\x00\f\x00\x00\x00'\x00\x00\x00гY>+\x00\x04\x00\f\x00\x00\x00(\x00\x00\x00Y\x177?+\x00\x04\x00\f\x00\x00\x00)\x00\x00\x00\x98ݓ=,\x00\x06\x00\x10\x00\x00\x00*\x00\x00\x00'\x00\x00\x00(\x00\x00\x00)\x00\x00\x006\x00\x05\x00\t\x00\x00\x00\x02\x00\x00\x00\x00\x00\x00\x00\n\x00\x00\x00\xf8\x00\x02\x00+\x00\x00\x00A\x00\x05\x00\x14\x00\x00\x00,\x00\x00\x00\a\x00\x00\x00\x1c\x00\x00\x00=\x00\x04\x00\x0f\x00\x00\x00-\x00\x00\x00,\x00\x00\x00A\x00\x05\x00\x15\x00\x00\x00.\x00\x00\x00\a\x00\x00\x00\x1d\x00\x00\x00=\x00\x04\x00\f\x00\x00\x00/\x00\x00\x00.\x00\x00\x00A\x00\x05\x00\x15\x00\x00\x000\x00\x00\x00\a\x00\x00\x00\x1e\x00\x00\x00=\x00\x04\x00\f\x00\x00\x001\x00\x00\x000\x00\x00\x00A\x00\x06\x00\x16\x00\x00\x002\x00\x00\x00\a\x00\x00\x00\x1f\x00\x00\x00\x1c\x00\x00\x00=\x00\x04\x00\x11\x00\x00\x003\x00\x00\x002\x00\x00\x00=\x00\x04\x00\x0f\x00\x00\x004\x00\x00\x00\x04\x00\x00\x00=\x00\x04\x00\x1a\x00\x00\x005\x00\x00\x00\x05\x00\x00\x00Q\x00\x05\x00\f\x00\x00\x006\x00\x00\x003\x00\x00\x00\x00\x00\x00\x00\f\x00\a\x00\f\x00\x00\x007\x00\x00\x00\x01\x00\x00\x00(\x00\x00\x006\x00\x00\x00#\x00\x00\x00P\x00\x05\x00\x0f\x00\x00\x008\x00\x00\x007\x00\x00\x007\x00\x00\x00\x88\x00\x05\x00\x0f\x00\x00\x009\x00\x00\x008\x00\x00\x00-\x00\x00\x00\x88\x00\x05\x00\x0f\x00\x00\x00:\x00\x00\x004\x00\x00\x009\x00\x00\x00\f\x00\x06\x00\x0f\x00\x00\x00;\x00\x00\x00\x01\x00\x00\x00\b\x00\x00\x00:\x00\x00\x00\x81\x00\x05\x00\x0f\x00\x00\x00<\x00\x00\x00;\x00\x00\x00%\x00\x00\x00\x85\x00\x05\x00\x0f\x00\x00\x00=\x00\x00\x00<\x00\x00\x009\x00\x00\x00W\x00\x05\x00\x11\x00\x00\x00>\x00\x00\x005\x00\x00\x00=\x00\x00\x00>\x00\x03\x00\x03\x00\x00\x00>\x00\x00\x00\xfd\x00\x01\x008\x00\x01\x00")
+
+func pixelateSpvBytes() ([]byte, error) {
+	return _pixelateSpv, nil
+}
+
+func pixelateSpv() (*asset, error) {
+	bytes, err := pixelateSpvBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "pixelate.spv", size: 1552, mode: os.FileMode(420), modTime: time.Unix(1792327966, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _thresholdSpv = []byte("\x03\x02#\a\x00\x00\x01\x00\x00\x00\x00\x00A\x00\x00\x00\x00\x00\x00\x00\x11\x00\x02\x00\x01\x00\x00\x00\v\x00\x06\x00\x01\x00\x00\x00GLSL.std.450\x00\x00\x00\x00\x0e\x00\x03\x00\x00\x00\x00\x00\x01\x00\x00\x00\x0f\x00\a\x00\x04\x00\x00\x00\x02\x00\x00\x00main\x00\x00\x00\x00\x03\x00\x00\x00\x04\x00\x00\x00\x10\x00\x03\x00\x02\x00\x00\x00\a\x00\x00\x00\x03\x00\x03\x00\x02\x00\x00\x00\xc2\x01\x00\x00\x05\x00\x04\x00\x02\x00\x00\x00main\x00\x00\x00\x00\x05\x00\x05\x00\x03\x00\x00\x00outColor\x00\x00\x00\x00\x05\x00\x03\x00\x04\x00\x00\x00uv\x00\x00\x05\x00\x04\x00\x05\x00\x00\x00source\x00\x00\x05\x00\x05\x00\x06\x00\x00\x00Constants\x00\x00\x00\x06\x00\x06\x00\x06\x00\x00\x00\x00\x00\x00\x00resolution\x00\x00\x06\x00\x05\x00\x06\x00\x00\x00\x01\x00\x00\x00time\x00\x00\x00\x00\x06\x00\x05\x00\x06\x00\x00\x00\x02\x00\x00\x00pass\x00\x00\x00\x00\x06\x00\x05\x00\x06\x00\x00\x00\x03\x00\x00\x00params\x00\x00\x05\x00\x05\x00\a\x00\x00\x00constants\x00\x00\x00G\x00\x04\x00\x03\x00\x00\x00\x1e\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\x04\x00\x00\x00\x1e\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\x05\x00\x00\x00\"\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\x05\x00\x00\x00!\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\b\x00\x00\x00\x06\x00\x00\x00\x10\x00\x00\x00H\x00\x05\x00\x06\x00\x00\x00\x00\x00\x00\x00#\x00\x00\x00\x00\x00\x00\x00H\x00\x05\x00\x06\x00\x00\x00\x01\x00\x00\x00#\x00\x00\x00\b\x00\x00\x00H\x00\x05\x00\x06\x00\x00\x00\x02\x00\x00\x00#\x00\x00\x00\f\x00\x00\x00H\x00\x05\x00\x06\x00\x00\x00\x03\x00\x00\x00#\x00\x00\x00\x10\x00\x00\x00G\x00\x03\x00\x06\x00\x00\x00\x02\x00\x00\x00\x13\x00\x02\x00\t\x00\x00\x00!\x00\x03\x00\n\x00\x00\x00\t\x00\x00\x00\x14\x00\x02\x00\v\x00\x00\x00\x16\x00\x03\x00\f\x00\x00\x00 \x00\x00\x00\x15\x00\x04\x00\r\x00\x00\x00 \x00\x00\x00\x01\x00\x00\x00\x15\x00\x04\x00\x0e\x00\x00\x00 \x00\x00\x00\x00\x00\x00\x00\x17\x00\x04\x00\x0f\x00\x00\x00\f\x00\x00\x00\x02\x00\x00\x00\x17\x00\x04\x00\x10\x00\x00\x00\f\x00\x00\x00\x03\x00\x00\x00\x17\x00\x04\x00\x11\x00\x00\x00\f\x00\x00\x00\x04\x00\x00\x00+\x00\x04\x00\x0e\x00\x00\x00\x12\x00\x00\x00\a\x00\x00\x00\x1c\x00\x04\x00\b\x00\x00\x00\x11\x00\x00\x00\x12\x00\x00\x00\x1e\x00\x06\x00\x06\x00\x00\x00\x0f\x00\x00\x00\f\x00\x00\x00\f\x00\x00\x00\b\x00\x00\x00 \x00\x04\x00\x13\x00\x00\x00\t\x00\x00\x00\x06\x00\x00\x00;\x00\x04\x00\x13\x00\x00\x00\a\x00\x00\x00\t\x00\x00\x00 \x00\x04\x00\x14\x00\x00\x00\t\x00\x00\x00\x0f\x00\x00\x00 \x00\x04\x00\x15\x00\x00\x00\t\x00\x00\x00\f\x00\x00\x00 \x00\x04\x00\x16\x00\x00\x00\t\x00\x00\x00\x11\x00\x00\x00 \x00\x04\x00\x17\x00\x00\x00\x03\x00\x00\x00\x11\x00\x00\x00;\x00\x04\x00\x17\x00\x00\x00\x03\x00\x00\x00\x03\x00\x00\x00 \x00\x04\x00\x18\x00\x00\x00\x01\x00\x00\x00\x0f\x00\x00\x00;\x00\x04\x00\x18\x00\x00\x00\x04\x00\x00\x00\x01\x00\x00\x00\x19\x00\t\x00\x19\x00\x00\x00\f\x00\x00\x00\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x01\x00\x00\x00\x00\x00\x00\x00\x1b\x00\x03\x00\x1a\x00\x00\x00\x19\x00\x00\x00 \x00\x04\x00\x1b\x00\x00\x00\x00\x00\x00\x00\x1a\x00\x00\x00;\x00\x04\x00\x1b\x00\x00\x00\x05\x00\x00\x00\x00\x00\x00\x00+\x00\x04\x00\r\x00\x00\x00\x1c\x00\x00\x00\x00\x00\x00\x00+\x00\x04\x00\r\x00\x00\x00\x1d\x00\x00\x00\x01\x00\x00\x00+\x00\x04\x00\r\x00\x00\x00\x1e\x00\x00\x00\x02\x00\x00\x00+\x00\x04\x00\r\x00\x00\x00\x1f\x00\x00\x00\x03\x00\x00\x00+\x00\x04\x00\f\x00\x00\x00 \x00\x00\x00\x00\x00\x00\x00+\x00\x04\x00\f\x00\x00\x00!\x00\x00\x00\x00\x00\x80>+\x00\x04\x00\f\x00\x00\x00\"\x00\x00\x00\x00\x00\x00?+\x00\x04\x00\f\x00\x00\x00#\x00\x00\x00\x00\x00\x80?+\x00\x04\x00\f\x00\x00\x00$\x00\x00\x00\x00\x00\x00@,\x00\x05\x00\x0f\x00\x00\x00%\x00\x00\x00\"\x00\x00\x00\"\x00\x00\x00,\x00\x05\x00\x0f\x00\x00\x00&\x00\x00\x00#\x00\x00\x00#\x00\x00\x00+\x00\x04\x00\f\x00\x00\x00'\x00\x00\x00гY>+\x00\x04\x00\f\x00\x00\x00(\x00\x00\x00Y\x177?+\x00\x04\x00\f\x00\x00\x00)\x00\x00\x00\x98ݓ=,\x00\x06\x00\x10\x00\x00\x00*\x00\x00\x00'\x00\x00\x00(\x00\x00\x00)\x00\x00\x00+\x00\x04\x00\f\x00\x00\x00+\x00\x00\x00\x17\xb7\xd186\x00\x05\x00\t\x00\x00\x00\x02\x00\x00\x00\x00\x00\x00\x00\n\x00\x00\x00\xf8\x00\x02\x00,\x00\x00\x00A\x00\x05\x00\x14\x00\x00\x00-\x00\x00\x00\a\x00\x00\x00\x1c\x00\x00\x00=\x00\x04\x00\x0f\x00\x00\x00.\x00\x00\x00-\x00\x00\x00A\x00\x05\x00\x15\x00\x00\x00/\x00\x00\x00\a\x00\x00\x00\x1d\x00\x00\x00=\x00\x04\x00\f\x00\x00\x000\x00\x00\x00/\x00\x00\x00A\x00\x05\x00\x15\x00\x00\x001\x00\x00\x00\a\x00\x00\x00\x1e\x00\x00\x00=\x00\x04\x00\f\x00\x00\x002\x00\x00\x001\x00\x00\x00A\x00\x06\x00\x16\x00\x00\x003\x00\x00\x00\a\x00\x00\x00\x1f\x00\x00\x00\x1c\x00\x00\x00=\x00\x04\x00\x11\x00\x00\x004\x00\x00\x003\x00\x00\x00=\x00\x04\x00\x0f\x00\x00\x005\x00\x00\x00\x04\x00\x00\x00=\x00\x04\x00\x1a\x00\x00\x006\x00\x00\x00\x05\x00\x00\x00W\x00\x05\x00\x11\x00\x00\x007\x00\x00\x006\x00\x00\x005\x00\x00\x00O\x00\b\x00\x10\x00\x00\x008\x00\x00\x007\x00\x00\x007\x00\x00\x00\x00\x00\x00\x00\x01\x00\x00\x00\x02\x00\x00\x00\x94\x00\x05\x00\f\x00\x00\x009\x00\x00\x008\x00\x00\x00*\x00\x00\x00Q\x00\x05\x00\f\x00\x00\x00:\x00\x00\x004\x00\x00\x00\x01\x00\x00\x00\x83\x00\x05\x00\f\x00\x00\x00;\x00\x00\x009\x00\x00\x00:\x00\x00\x00\f\x00\a\x00\f\x00\x00\x00<\x00\x00\x00\x01\x00\x00\x00(\x00\x00\x00;\x00\x00\x00 \x00\x00\x00\f\x00\a\x00\f\x00\x00\x00=\x00\x00\x00\x01\x00\x00\x00(\x00\x00\x009\x00\x00\x00+\x00\x00\x00\x88\x00\x05\x00\f\x00\x00\x00>\x00\x00\x00<\x00\x00\x00=\x00\x00\x00\x8e\x00\x05\x00\x10\x00\x00\x00?\x00\x00\x008\x00\x00\x00>\x00\x00\x00P\x00\x05\x00\x11\x00\x00\x00@\x00\x00\x00?\x00\x00\x00#\x00\x00\x00>\x00\x03\x00\x03\x00\x00\x00@\x00\x00\x00\xfd\x00\x01\x008\x00\x01\x00")
+
+func thresholdSpvBytes() ([]byte, error) {
+	return _thresholdSpv, nil
+}
+
+func thresholdSpv() (*asset, error) {
+	bytes, err := thresholdSpvBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "threshold.spv", size: 1604, mode: os.FileMode(420), modTime: time.Unix(1792327966, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _vertSpv = []byte("\x03\x02#\a\x00\x00\x01\x00\a\x00\b\x005\x00\x00\x00\x00\x00\x00\x00\x11\x00\x02\x00\x01\x00\x00\x00\v\x00\x06\x00\x01\x00\x00\x00GLSL.std.450\x00\x00\x00\x00\x0e\x00\x03\x00\x00\x00\x00\x00\x01\x00\x00\x00\x0f\x00\v\x00\x00\x00\x00\x00\x04\x00\x00\x00main\x00\x00\x00\x00\r\x00\x00\x00!\x00\x00\x00-\x00\x00\x00/\x00\x00\x002\x00\x00\x003\x00\x00\x00\x03\x00\x03\x00\x02\x00\x00\x00\xc2\x01\x00\x00\x04\x00\t\x00GL_ARB_separate_shader_objects\x00\x00\x05\x00\x04\x00\x04\x00\x00\x00main\x00\x00\x00\x00\x05\x00\x06\x00\v\x00\x00\x00gl_PerVertex\x00\x00\x00\x00\x06\x00\x06\x00\v\x00\x00\x00\x00\x00\x00\x00gl_Position\x00\x06\x00\a\x00\v\x00\x00\x00\x01\x00\x00\x00gl_PointSize\x00\x00\x00\x00\x06\x00\a\x00\v\x00\x00\x00\x02\x00\x00\x00gl_ClipDistance\x00\x06\x00\a\x00\v\x00\x00\x00\x03\x00\x00\x00gl_CullDistance\x00\x05\x00\x03\x00\r\x00\x00\x00\x00\x00\x00\x00\x05\x00\a\x00\x11\x00\x00\x00UniformBufferObject\x00\x06\x00\x05\x00\x11\x00\x00\x00\x00\x00\x00\x00model\x00\x00\x00\x06\x00\x05\x00\x11\x00\x00\x00\x01\x00\x00\x00view\x00\x00\x00\x00\x06\x00\x05\x00\x11\x00\x00\x00\x02\x00\x00\x00proj\x00\x00\x00\x00\x05\x00\x03\x00\x13\x00\x00\x00ubo\x00\x05\x00\x05\x00!\x00\x00\x00inPosition\x00\x00\x05\x00\x05\x00-\x00\x00\x00fragColor\x00\x00\x00\x05\x00\x04\x00/\x00\x00\x00inColor\x00\x05\x00\x06\x002\x00\x00\x00fragTexCoord\x00\x00\x00\x00\x05\x00\x05\x003\x00\x00\x00inTexCoord\x00\x00H\x00\x05\x00\v\x00\x00\x00\x00\x00\x00\x00\v\x00\x00\x00\x00\x00\x00\x00H\x00\x05\x00\v\x00\x00\x00\x01\x00\x00\x00\v\x00\x00\x00\x01\x00\x00\x00H\x00\x05\x00\v\x00\x00\x00\x02\x00\x00\x00\v\x00\x00\x00\x03\x00\x00\x00H\x00\x05\x00\v\x00\x00\x00\x03\x00\x00\x00\v\x00\x00\x00\x04\x00\x00\x00G\x00\x03\x00\v\x00\x00\x00\x02\x00\x00\x00H\x00\x04\x00\x11\x00\x00\x00\x00\x00\x00\x00\x05\x00\x00\x00H\x00\x05\x00\x11\x00\x00\x00\x00\x00\x00\x00#\x00\x00\x00\x00\x00\x00\x00H\x00\x05\x00\x11\x00\x00\x00\x00\x00\x00\x00\a\x00\x00\x00\x10\x00\x00\x00H\x00\x04\x00\x11\x00\x00\x00\x01\x00\x00\x00\x05\x00\x00\x00H\x00\x05\x00\x11\x00\x00\x00\x01\x00\x00\x00#\x00\x00\x00@\x00\x00\x00H\x00\x05\x00\x11\x00\x00\x00\x01\x00\x00\x00\a\x00\x00\x00\x10\x00\x00\x00H\x00\x04\x00\x11\x00\x00\x00\x02\x00\x00\x00\x05\x00\x00\x00H\x00\x05\x00\x11\x00\x00\x00\x02\x00\x00\x00#\x00\x00\x00\x80\x00\x00\x00H\x00\x05\x00\x11\x00\x00\x00\x02\x00\x00\x00\a\x00\x00\x00\x10\x00\x00\x00G\x00\x03\x00\x11\x00\x00\x00\x02\x00\x00\x00G\x00\x04\x00\x13\x00\x00\x00\"\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\x13\x00\x00\x00!\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00!\x00\x00\x00\x1e\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00-\x00\x00\x00\x1e\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00/\x00\x00\x00\x1e\x00\x00\x00\x01\x00\x00\x00G\x00\x04\x002\x00\x00\x00\x1e\x00\x00\x00\x01\x00\x00\x00G\x00\x04\x003\x00\x00\x00\x1e\x00\x00\x00\x02\x00\x00\x00\x13\x00\x02\x00\x02\x00\x00\x00!\x00\x03\x00\x03\x00\x00\x00\x02\x00\x00\x00\x16\x00\x03\x00\x06\x00\x00\x00 \x00\x00\x00\x17\x00\x04\x00\a\x00\x00\x00\x06\x00\x00\x00\x04\x00\x00\x00\x15\x00\x04\x00\b\x00\x00\x00 \x00\x00\x00\x00\x00\x00\x00+\x00\x04\x00\b\x00\x00\x00\t\x00\x00\x00\x01\x00\x00\x00\x1c\x00\x04\x00\n\x00\x00\x00\x06\x00\x00\x00\t\x00\x00\x00\x1e\x00\x06\x00\v\x00\x00\x00\a\x00\x00\x00\x06\x00\x00\x00\n\x00\x00\x00\n\x00\x00\x00 \x00\x04\x00\f\x00\x00\x00\x03\x00\x00\x00\v\x00\x00\x00;\x00\x04\x00\f\x00\x00\x00\r\x00\x00\x00\x03\x00\x00\x00\x15\x00\x04\x00\x0e\x00\x00\x00 \x00\x00\x00\x01\x00\x00\x00+\x00\x04\x00\x0e\x00\x00\x00\x0f\x00\x00\x00\x00\x00\x00\x00\x18\x00\x04\x00\x10\x00\x00\x00\a\x00\x00\x00\x04\x00\x00\x00\x1e\x00\x05\x00\x11\x00\x00\x00\x10\x00\x00\x00\x10\x00\x00\x00\x10\x00\x00\x00 \x00\x04\x00\x12\x00\x00\x00\x02\x00\x00\x00\x11\x00\x00\x00;\x00\x04\x00\x12\x00\x00\x00\x13\x00\x00\x00\x02\x00\x00\x00+\x00\x04\x00\x0e\x00\x00\x00\x14\x00\x00\x00\x02\x00\x00\x00 \x00\x04\x00\x15\x00\x00\x00\x02\x00\x00\x00\x10\x00\x00\x00+\x00\x04\x00\x0e\x00\x00\x00\x18\x00\x00\x00\x01\x00\x00\x00\x17\x00\x04\x00\x1f\x00\x00\x00\x06\x00\x00\x00\x02\x00\x00\x00 \x00\x04\x00 \x00\x00\x00\x01\x00\x00\x00\x1f\x00\x00\x00;\x00\x04\x00 \x00\x00\x00!\x00\x00\x00\x01\x00\x00\x00+\x00\x04\x00\x06\x00\x00\x00#\x00\x00\x00\x00\x00\x00\x00+\x00\x04\x00\x06\x00\x00\x00$\x00\x00\x00\x00\x00\x80? \x00\x04\x00)\x00\x00\x00\x03\x00\x00\x00\a\x00\x00\x00 \x00\x04\x00,\x00\x00\x00\x03\x00\x00\x00\a\x00\x00\x00;\x00\x04\x00,\x00\x00\x00-\x00\x00\x00\x03\x00\x00\x00 \x00\x04\x00.\x00\x00\x00\x01\x00\x00\x00\a\x00\x00\x00;\x00\x04\x00.\x00\x00\x00/\x00\x00\x00\x01\x00\x00\x00 \x00\x04\x001\x00\x00\x00\x03\x00\x00\x00\x1f\x00\x00\x00;\x00\x04\x001\x00\x00\x002\x00\x00\x00\x03\x00\x00\x00;\x00\x04\x00 \x00\x00\x003\x00\x00\x00\x01\x00\x00\x006\x00\x05\x00\x02\x00\x00\x00\x04\x00\x00\x00\x00\x00\x00\x00\x03\x00\x00\x00\xf8\x00\x02\x00\x05\x00\x00\x00A\x00\x05\x00\x15\x00\x00\x00\x16\x00\x00\x00\x13\x00\x00\x00\x14\x00\x00\x00=\x00\x04\x00\x10\x00\x00\x00\x17\x00\x00\x00\x16\x00\x00\x00A\x00\x05\x00\x15\x00\x00\x00\x19\x00\x00\x00\x13\x00\x00\x00\x18\x00\x00\x00=\x00\x04\x00\x10\x00\x00\x00\x1a\x00\x00\x00\x19\x00\x00\x00\x92\x00\x05\x00\x10\x00\x00\x00\x1b\x00\x00\x00\x17\x00\x00\x00\x1a\x00\x00\x00A\x00\x05\x00\x15\x00\x00\x00\x1c\x00\x00\x00\x13\x00\x00\x00\x0f\x00\x00\x00=\x00\x04\x00\x10\x00\x00\x00\x1d\x00\x00\x00\x1c\x00\x00\x00\x92\x00\x05\x00\x10\x00\x00\x00\x1e\x00\x00\x00\x1b\x00\x00\x00\x1d\x00\x00\x00=\x00\x04\x00\x1f\x00\x00\x00\"\x00\x00\x00!\x00\x00\x00Q\x00\x05\x00\x06\x00\x00\x00%\x00\x00\x00\"\x00\x00\x00\x00\x00\x00\x00Q\x00\x05\x00\x06\x00\x00\x00&\x00\x00\x00\"\x00\x00\x00\x01\x00\x00\x00P\x00\a\x00\a\x00\x00\x00'\x00\x00\x00%\x00\x00\x00&\x00\x00\x00#\x00\x00\x00$\x00\x00\x00\x91\x00\x05\x00\a\x00\x00\x00(\x00\x00\x00\x1e\x00\x00\x00'\x00\x00\x00A\x00\x05\x00)\x00\x00\x00*\x00\x00\x00\r\x00\x00\x00\x0f\x00\x00\x00>\x00\x03\x00*\x00\x00\x00(\x00\x00\x00=\x00\x04\x00\a\x00\x00\x000\x00\x00\x00/\x00\x00\x00>\x00\x03\x00-\x00\x00\x000\x00\x00\x00=\x00\x04\x00\x1f\x00\x00\x004\x00\x00\x003\x00\x00\x00>\x00\x03\x002\x00\x00\x004\x00\x00\x00\xfd\x00\x01\x008\x00\x01\x00")
 
 func vertSpvBytes() ([]byte, error) {
 	return _vertSpv, nil
@@ -73,7 +254,24 @@ func vertSpv() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "vert.spv", size: 1800, mode: os.FileMode(420), modTime: time.Unix(1585938058, 0)}
+	info := bindataFileInfo{name: "vert.spv", size: 1784, mode: os.FileMode(420), modTime: time.Unix(1792329532, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _vignetteSpv = []byte("\x03\x02#\a\x00\x00\x01\x00\x00\x00\x00\x00D\x00\x00\x00\x00\x00\x00\x00\x11\x00\x02\x00\x01\x00\x00\x00\v\x00\x06\x00\x01\x00\x00\x00GLSL.std.450\x00\x00\x00\x00\x0e\x00\x03\x00\x00\x00\x00\x00\x01\x00\x00\x00\x0f\x00\a\x00\x04\x00\x00\x00\x02\x00\x00\x00main\x00\x00\x00\x00\x03\x00\x00\x00\x04\x00\x00\x00\x10\x00\x03\x00\x02\x00\x00\x00\a\x00\x00\x00\x03\x00\x03\x00\x02\x00\x00\x00\xc2\x01\x00\x00\x05\x00\x04\x00\x02\x00\x00\x00main\x00\x00\x00\x00\x05\x00\x05\x00\x03\x00\x00\x00outColor\x00\x00\x00\x00\x05\x00\x03\x00\x04\x00\x00\x00uv\x00\x00\x05\x00\x04\x00\x05\x00\x00\x00source\x00\x00\x05\x00\x05\x00\x06\x00\x00\x00Constants\x00\x00\x00\x06\x00\x06\x00\x06\x00\x00\x00\x00\x00\x00\x00resolution\x00\x00\x06\x00\x05\x00\x06\x00\x00\x00\x01\x00\x00\x00time\x00\x00\x00\x00\x06\x00\x05\x00\x06\x00\x00\x00\x02\x00\x00\x00pass\x00\x00\x00\x00\x06\x00\x05\x00\x06\x00\x00\x00\x03\x00\x00\x00params\x00\x00\x05\x00\x05\x00\a\x00\x00\x00constants\x00\x00\x00G\x00\x04\x00\x03\x00\x00\x00\x1e\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\x04\x00\x00\x00\x1e\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\x05\x00\x00\x00\"\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\x05\x00\x00\x00!\x00\x00\x00\x00\x00\x00\x00G\x00\x04\x00\b\x00\x00\x00\x06\x00\x00\x00\x10\x00\x00\x00H\x00\x05\x00\x06\x00\x00\x00\x00\x00\x00\x00#\x00\x00\x00\x00\x00\x00\x00H\x00\x05\x00\x06\x00\x00\x00\x01\x00\x00\x00#\x00\x00\x00\b\x00\x00\x00H\x00\x05\x00\x06\x00\x00\x00\x02\x00\x00\x00#\x00\x00\x00\f\x00\x00\x00H\x00\x05\x00\x06\x00\x00\x00\x03\x00\x00\x00#\x00\x00\x00\x10\x00\x00\x00G\x00\x03\x00\x06\x00\x00\x00\x02\x00\x00\x00\x13\x00\x02\x00\t\x00\x00\x00!\x00\x03\x00\n\x00\x00\x00\t\x00\x00\x00\x14\x00\x02\x00\v\x00\x00\x00\x16\x00\x03\x00\f\x00\x00\x00 \x00\x00\x00\x15\x00\x04\x00\r\x00\x00\x00 \x00\x00\x00\x01\x00\x00\x00\x15\x00\x04\x00\x0e\x00\x00\x00 \x00\x00\x00\x00\x00\x00\x00\x17\x00\x04\x00\x0f\x00\x00\x00\f\x00\x00\x00\x02\x00\x00\x00\x17\x00\x04\x00\x10\x00\x00\x00\f\x00\x00\x00\x03\x00\x00\x00\x17\x00\x04\x00\x11\x00\x00\x00\f\x00\x00\x00\x04\x00\x00\x00+\x00\x04\x00\x0e\x00\x00\x00\x12\x00\x00\x00\a\x00\x00\x00\x1c\x00\x04\x00\b\x00\x00\x00\x11\x00\x00\x00\x12\x00\x00\x00\x1e\x00\x06\x00\x06\x00\x00\x00\x0f\x00\x00\x00\f\x00\x00\x00\f\x00\x00\x00\b\x00\x00\x00 \x00\x04\x00\x13\x00\x00\x00\t\x00\x00\x00\x06\x00\x00\x00;\x00\x04\x00\x13\x00\x00\x00\a\x00\x00\x00\t\x00\x00\x00 \x00\x04\x00\x14\x00\x00\x00\t\x00\x00\x00\x0f\x00\x00\x00 \x00\x04\x00\x15\x00\x00\x00\t\x00\x00\x00\f\x00\x00\x00 \x00\x04\x00\x16\x00\x00\x00\t\x00\x00\x00\x11\x00\x00\x00 \x00\x04\x00\x17\x00\x00\x00\x03\x00\x00\x00\x11\x00\x00\x00;\x00\x04\x00\x17\x00\x00\x00\x03\x00\x00\x00\x03\x00\x00\x00 \x00\x04\x00\x18\x00\x00\x00\x01\x00\x00\x00\x0f\x00\x00\x00;\x00\x04\x00\x18\x00\x00\x00\x04\x00\x00\x00\x01\x00\x00\x00\x19\x00\t\x00\x19\x00\x00\x00\f\x00\x00\x00\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x01\x00\x00\x00\x00\x00\x00\x00\x1b\x00\x03\x00\x1a\x00\x00\x00\x19\x00\x00\x00 \x00\x04\x00\x1b\x00\x00\x00\x00\x00\x00\x00\x1a\x00\x00\x00;\x00\x04\x00\x1b\x00\x00\x00\x05\x00\x00\x00\x00\x00\x00\x00+\x00\x04\x00\r\x00\x00\x00\x1c\x00\x00\x00\x00\x00\x00\x00+\x00\x04\x00\r\x00\x00\x00\x1d\x00\x00\x00\x01\x00\x00\x00+\x00\x04\x00\r\x00\x00\x00\x1e\x00\x00\x00\x02\x00\x00\x00+\x00\x04\x00\r\x00\x00\x00\x1f\x00\x00\x00\x03\x00\x00\x00+\x00\x04\x00\f\x00\x00\x00 \x00\x00\x00\x00\x00\x00\x00+\x00\x04\x00\f\x00\x00\x00!\x00\x00\x00\x00\x00\x80>+\x00\x04\x00\f\x00\x00\x00\"\x00\x00\x00\x00\x00\x00?+\x00\x04\x00\f\x00\x00\x00#\x00\x00\x00\x00\x00\x80?+\x00\x04\x00\f\x00\x00\x00$\x00\x00\x00\x00\x00\x00@,\x00\x05\x00\x0f\x00\x00\x00%\x00\x00\x00\"\x00\x00\x00\"\x00\x00\x00,\x00\x05\x00\x0f\x00\x00\x00&\x00\x00\x00#\x00\x00\x00#\x00\x00\x00+\x00\x04\x00\f\x00\x00\x00'\x00\x00\x00гY>+\x00\x04\x00\f\x00\x00\x00(\x00\x00\x00Y\x177?+\x00\x04\x00\f\x00\x00\x00)\x00\x00\x00\x98ݓ=,\x00\x06\x00\x10\x00\x00\x00*\x00\x00\x00'\x00\x00\x00(\x00\x00\x00)\x00\x00\x006\x00\x05\x00\t\x00\x00\x00\x02\x00\x00\x00\x00\x00\x00\x00\n\x00\x00\x00\xf8\x00\x02\x00+\x00\x00\x00A\x00\x05\x00\x14\x00\x00\x00,\x00\x00\x00\a\x00\x00\x00\x1c\x00\x00\x00=\x00\x04\x00\x0f\x00\x00\x00-\x00\x00\x00,\x00\x00\x00A\x00\x05\x00\x15\x00\x00\x00.\x00\x00\x00\a\x00\x00\x00\x1d\x00\x00\x00=\x00\x04\x00\f\x00\x00\x00/\x00\x00\x00.\x00\x00\x00A\x00\x05\x00\x15\x00\x00\x000\x00\x00\x00\a\x00\x00\x00\x1e\x00\x00\x00=\x00\x04\x00\f\x00\x00\x001\x00\x00\x000\x00\x00\x00A\x00\x06\x00\x16\x00\x00\x002\x00\x00\x00\a\x00\x00\x00\x1f\x00\x00\x00\x1c\x00\x00\x00=\x00\x04\x00\x11\x00\x00\x003\x00\x00\x002\x00\x00\x00=\x00\x04\x00\x0f\x00\x00\x004\x00\x00\x00\x04\x00\x00\x00=\x00\x04\x00\x1a\x00\x00\x005\x00\x00\x00\x05\x00\x00\x00W\x00\x05\x00\x11\x00\x00\x006\x00\x00\x005\x00\x00\x004\x00\x00\x00\x83\x00\x05\x00\x0f\x00\x00\x007\x00\x00\x004\x00\x00\x00%\x00\x00\x00\f\x00\x06\x00\f\x00\x00\x008\x00\x00\x00\x01\x00\x00\x00B\x00\x00\x007\x00\x00\x00Q\x00\x05\x00\f\x00\x00\x009\x00\x00\x003\x00\x00\x00\x00\x00\x00\x00Q\x00\x05\x00\f\x00\x00\x00:\x00\x00\x003\x00\x00\x00\x01\x00\x00\x00Q\x00\x05\x00\f\x00\x00\x00;\x00\x00\x003\x00\x00\x00\x02\x00\x00\x00\x83\x00\x05\x00\f\x00\x00\x00<\x00\x00\x00:\x00\x00\x00;\x00\x00\x00\f\x00\b\x00\f\x00\x00\x00=\x00\x00\x00\x01\x00\x00\x001\x00\x00\x00<\x00\x00\x00:\x00\x00\x008\x00\x00\x00\x83\x00\x05\x00\f\x00\x00\x00>\x00\x00\x00#\x00\x00\x00=\x00\x00\x00\f\x00\b\x00\f\x00\x00\x00?\x00\x00\x00\x01\x00\x00\x00.\x00\x00\x00#\x00\x00\x00>\x00\x00\x009\x00\x00\x00O\x00\b\x00\x10\x00\x00\x00@\x00\x00\x006\x00\x00\x006\x00\x00\x00\x00\x00\x00\x00\x01\x00\x00\x00\x02\x00\x00\x00\x8e\x00\x05\x00\x10\x00\x00\x00A\x00\x00\x00@\x00\x00\x00?\x00\x00\x00Q\x00\x05\x00\f\x00\x00\x00B\x00\x00\x006\x00\x00\x00\x03\x00\x00\x00P\x00\x05\x00\x11\x00\x00\x00C\x00\x00\x00A\x00\x00\x00B\x00\x00\x00>\x00\x03\x00\x03\x00\x00\x00C\x00\x00\x00\xfd\x00\x01\x008\x00\x01\x00")
+
+func vignetteSpvBytes() ([]byte, error) {
+	return _vignetteSpv, nil
+}
+
+func vignetteSpv() (*asset, error) {
+	bytes, err := vignetteSpvBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "vignette.spv", size: 1680, mode: os.FileMode(420), modTime: time.Unix(1792327966, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -130,8 +328,19 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
+	"blit.spv": blitSpv,
+	"bloom.spv": bloomSpv,
+	"blur.spv": blurSpv,
+	"chromatic.spv": chromaticSpv,
+	"crt.spv": crtSpv,
 	"frag.spv": fragSpv,
+	"fullscreen.spv": fullscreenSpv,
+	"grade.spv": gradeSpv,
+	"grayscale.spv": grayscaleSpv,
+	"pixelate.spv": pixelateSpv,
+	"threshold.spv": thresholdSpv,
 	"vert.spv": vertSpv,
+	"vignette.spv": vignetteSpv,
 }
 
 // AssetDir returns the file names below a certain
@@ -175,8 +384,19 @@ type bintree struct {
 }
 
 var _bintree = &bintree{nil, map[string]*bintree{
+	"blit.spv": {blitSpv, map[string]*bintree{}},
+	"bloom.spv": {bloomSpv, map[string]*bintree{}},
+	"blur.spv": {blurSpv, map[string]*bintree{}},
+	"chromatic.spv": {chromaticSpv, map[string]*bintree{}},
+	"crt.spv": {crtSpv, map[string]*bintree{}},
 	"frag.spv": {fragSpv, map[string]*bintree{}},
+	"fullscreen.spv": {fullscreenSpv, map[string]*bintree{}},
+	"grade.spv": {gradeSpv, map[string]*bintree{}},
+	"grayscale.spv": {grayscaleSpv, map[string]*bintree{}},
+	"pixelate.spv": {pixelateSpv, map[string]*bintree{}},
+	"threshold.spv": {thresholdSpv, map[string]*bintree{}},
 	"vert.spv": {vertSpv, map[string]*bintree{}},
+	"vignette.spv": {vignetteSpv, map[string]*bintree{}},
 }}
 
 // RestoreAsset restores an asset under the given directory
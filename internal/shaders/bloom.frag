@@ -0,0 +1,22 @@
+#version 450
+#extension GL_ARB_separate_shader_objects : enable
+
+// adds the blurred bright parts of the image, times params[0].z, to what the
+// bloom started from
+layout(location = 0) in vec2 uv;
+
+layout(location = 0) out vec4 outColor;
+layout(binding = 0) uniform sampler2D source;
+layout(binding = 1) uniform sampler2D effectInput;
+layout(push_constant) uniform Constants {
+    vec2 resolution;
+    float time;
+    float pass;
+    vec4 params[7];
+} constants;
+
+void main() {
+    vec3 glow = texture(source, uv).rgb;
+    vec4 base = texture(effectInput, uv);
+    outColor = vec4(base.rgb + glow * constants.params[0].z, base.a);
+}
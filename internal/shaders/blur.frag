@@ -0,0 +1,29 @@
+#version 450
+#extension GL_ARB_separate_shader_objects : enable
+
+// separable gaussian blur. Even passes blur horizontally and odd passes
+// vertically, over params[0].x pixels either side.
+layout(location = 0) in vec2 uv;
+
+layout(location = 0) out vec4 outColor;
+layout(binding = 0) uniform sampler2D source;
+layout(push_constant) uniform Constants {
+    vec2 resolution;
+    float time;
+    float pass;
+    vec4 params[7];
+} constants;
+
+const float weights[5] = float[](0.227027, 0.1945946, 0.1216216, 0.054054, 0.016216);
+
+void main() {
+    vec2 texel = 1.0 / constants.resolution;
+    float vertical = step(0.5, mod(constants.pass, 2.0));
+    vec2 dir = vec2(texel.x * (1.0 - vertical), texel.y * vertical);
+    vec2 offset = dir * constants.params[0].x * 0.25;
+    vec4 color = texture(source, uv) * weights[0];
+    for (int i = 1; i < 5; i++) {
+        color += (texture(source, uv + offset * float(i)) + texture(source, uv - offset * float(i))) * weights[i];
+    }
+    outColor = color;
+}
@@ -0,0 +1,23 @@
+#version 450
+#extension GL_ARB_separate_shader_objects : enable
+
+// chromatic aberration. Red and blue are split apart towards the edges, by
+// params[0].x pixels at the middle of each edge.
+layout(location = 0) in vec2 uv;
+
+layout(location = 0) out vec4 outColor;
+layout(binding = 0) uniform sampler2D source;
+layout(push_constant) uniform Constants {
+    vec2 resolution;
+    float time;
+    float pass;
+    vec4 params[7];
+} constants;
+
+void main() {
+    vec2 shift = (uv - 0.5) * constants.params[0].x * 2.0 / constants.resolution;
+    vec4 center = texture(source, uv);
+    float red = texture(source, uv + shift).r;
+    float blue = texture(source, uv - shift).b;
+    outColor = vec4(red, center.g, blue, center.a);
+}
@@ -0,0 +1,26 @@
+#version 450
+#extension GL_ARB_separate_shader_objects : enable
+
+// an old monitor. The screen is bent by params[0].x, and every other line is
+// darkened by params[0].y.
+layout(location = 0) in vec2 uv;
+
+layout(location = 0) out vec4 outColor;
+layout(binding = 0) uniform sampler2D source;
+layout(push_constant) uniform Constants {
+    vec2 resolution;
+    float time;
+    float pass;
+    vec4 params[7];
+} constants;
+
+const float pi = 3.14159265;
+
+void main() {
+    vec2 c = uv * 2.0 - 1.0;
+    vec2 curved = c * (1.0 + constants.params[0].x * dot(c, c)) * 0.5 + 0.5;
+    vec4 color = texture(source, curved);
+    float scan = 1.0 - constants.params[0].y * (sin(curved.y * constants.resolution.y * pi) * 0.5 + 0.5);
+    float inside = step(0.0, curved.x) * step(curved.x, 1.0) * step(0.0, curved.y) * step(curved.y, 1.0);
+    outColor = vec4(color.rgb * scan * inside, 1.0);
+}
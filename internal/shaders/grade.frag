@@ -0,0 +1,31 @@
+#version 450
+#extension GL_ARB_separate_shader_objects : enable
+
+// looks the color up in two LUTs and mixes them by params[0].x, or by a cycle
+// of params[0].y seconds when that's more than 0
+layout(location = 0) in vec2 uv;
+
+layout(location = 0) out vec4 outColor;
+layout(binding = 0) uniform sampler2D source;
+layout(binding = 2) uniform sampler3D lutA;
+layout(binding = 3) uniform sampler3D lutB;
+layout(push_constant) uniform Constants {
+    vec2 resolution;
+    float time;
+    float pass;
+    vec4 params[7];
+} constants;
+
+// grade samples the LUT at the color, through the middle of its edge texels
+vec3 grade(sampler3D lut, vec3 color) {
+    vec3 size = vec3(textureSize(lut, 0));
+    return texture(lut, color * (size - 1.0) / size + 0.5 / size).rgb;
+}
+
+void main() {
+    vec4 color = texture(source, uv);
+    float period = constants.params[0].y;
+    float cycle = 0.5 - 0.5 * cos(constants.time / max(period, 0.0001) * 6.28318531);
+    float weight = mix(constants.params[0].x, cycle, step(0.0001, period));
+    outColor = vec4(mix(grade(lutA, color.rgb), grade(lutB, color.rgb), weight), color.a);
+}
@@ -0,0 +1,20 @@
+#version 450
+#extension GL_ARB_separate_shader_objects : enable
+
+// mixes params[0].x of the way from the color to its luminance
+layout(location = 0) in vec2 uv;
+
+layout(location = 0) out vec4 outColor;
+layout(binding = 0) uniform sampler2D source;
+layout(push_constant) uniform Constants {
+    vec2 resolution;
+    float time;
+    float pass;
+    vec4 params[7];
+} constants;
+
+void main() {
+    vec4 color = texture(source, uv);
+    float luma = dot(color.rgb, vec3(0.2126, 0.7152, 0.0722));
+    outColor = vec4(mix(color.rgb, vec3(luma), constants.params[0].x), color.a);
+}
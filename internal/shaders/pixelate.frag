@@ -0,0 +1,19 @@
+#version 450
+#extension GL_ARB_separate_shader_objects : enable
+
+// draws the image in blocks of params[0].x pixels
+layout(location = 0) in vec2 uv;
+
+layout(location = 0) out vec4 outColor;
+layout(binding = 0) uniform sampler2D source;
+layout(push_constant) uniform Constants {
+    vec2 resolution;
+    float time;
+    float pass;
+    vec4 params[7];
+} constants;
+
+void main() {
+    vec2 cell = vec2(max(constants.params[0].x, 1.0)) / constants.resolution;
+    outColor = texture(source, (floor(uv / cell) + 0.5) * cell);
+}
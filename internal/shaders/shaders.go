@@ -1,5 +1,16 @@
 package shaders
 
 //go:generate glslangvalidator -V shader.frag shader.vert
-//go:generate go-bindata -nocompress -pkg=shaders frag.spv vert.spv
+//go:generate glslangvalidator -V fullscreen.vert -o fullscreen.spv
+//go:generate glslangvalidator -V blit.frag -o blit.spv
+//go:generate glslangvalidator -V blur.frag -o blur.spv
+//go:generate glslangvalidator -V threshold.frag -o threshold.spv
+//go:generate glslangvalidator -V bloom.frag -o bloom.spv
+//go:generate glslangvalidator -V vignette.frag -o vignette.spv
+//go:generate glslangvalidator -V crt.frag -o crt.spv
+//go:generate glslangvalidator -V chromatic.frag -o chromatic.spv
+//go:generate glslangvalidator -V pixelate.frag -o pixelate.spv
+//go:generate glslangvalidator -V grayscale.frag -o grayscale.spv
+//go:generate glslangvalidator -V grade.frag -o grade.spv
+//go:generate go-bindata -nocompress -pkg=shaders frag.spv vert.spv fullscreen.spv blit.spv blur.spv threshold.spv bloom.spv vignette.spv crt.spv chromatic.spv pixelate.spv grayscale.spv grade.spv
 //go:generate gofmt -s -w .
@@ -0,0 +1,22 @@
+#version 450
+#extension GL_ARB_separate_shader_objects : enable
+
+// keeps the part of the color that's brighter than params[0].y. It's drawn
+// into a smaller image, so the linear filtering downsamples it too.
+layout(location = 0) in vec2 uv;
+
+layout(location = 0) out vec4 outColor;
+layout(binding = 0) uniform sampler2D source;
+layout(push_constant) uniform Constants {
+    vec2 resolution;
+    float time;
+    float pass;
+    vec4 params[7];
+} constants;
+
+void main() {
+    vec3 color = texture(source, uv).rgb;
+    float luma = dot(color, vec3(0.2126, 0.7152, 0.0722));
+    float factor = max(luma - constants.params[0].y, 0.0) / max(luma, 0.0001);
+    outColor = vec4(color * factor, 1.0);
+}
@@ -0,0 +1,22 @@
+#version 450
+#extension GL_ARB_separate_shader_objects : enable
+
+// darkens the image by params[0].x outside of params[0].y from the center,
+// fading in over params[0].z
+layout(location = 0) in vec2 uv;
+
+layout(location = 0) out vec4 outColor;
+layout(binding = 0) uniform sampler2D source;
+layout(push_constant) uniform Constants {
+    vec2 resolution;
+    float time;
+    float pass;
+    vec4 params[7];
+} constants;
+
+void main() {
+    vec4 color = texture(source, uv);
+    float radius = constants.params[0].y;
+    float lit = 1.0 - smoothstep(radius - constants.params[0].z, radius, length(uv - 0.5));
+    outColor = vec4(color.rgb * mix(1.0, lit, constants.params[0].x), color.a);
+}
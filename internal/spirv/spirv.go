@@ -0,0 +1,842 @@
+// Package spirv reads the interface of SPIR-V shader modules: their entry
+// points with their inputs and outputs, descriptor bindings, push constants and
+// specialization constants. It doesn't validate the rest of the module.
+package spirv
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Magic is the first word of every SPIR-V module
+const Magic = 0x07230203
+
+// Stage is the execution model of an entry point
+type Stage uint32
+
+// The stages have the values of the SPIR-V execution models
+const (
+	// StageVertex runs once per vertex
+	StageVertex Stage = iota
+	// StageTessellationControl picks how much patches are tessellated
+	StageTessellationControl
+	// StageTessellationEvaluation places the vertices made by tessellation
+	StageTessellationEvaluation
+	// StageGeometry runs once per primitive
+	StageGeometry
+	// StageFragment runs once per fragment
+	StageFragment
+	// StageCompute runs outside the graphics pipeline
+	StageCompute
+)
+
+// String returns the name of the stage, like vertex or fragment
+func (s Stage) String() string {
+	switch s {
+	case StageVertex:
+		return "vertex"
+	case StageTessellationControl:
+		return "tessellation control"
+	case StageTessellationEvaluation:
+		return "tessellation evaluation"
+	case StageGeometry:
+		return "geometry"
+	case StageFragment:
+		return "fragment"
+	case StageCompute:
+		return "compute"
+	}
+	return fmt.Sprintf("stage %d", uint32(s))
+}
+
+// ScalarKind is the kind of the components of a Format
+type ScalarKind uint8
+
+const (
+	// Float is a floating point number, a float or a double
+	Float ScalarKind = iota
+	// Int is a signed integer
+	Int
+	// Uint is an unsigned integer
+	Uint
+	// Bool is a boolean, which has no size in memory
+	Bool
+)
+
+// Format is the type of a scalar, vector or matrix
+type Format struct {
+	Kind ScalarKind
+	// Width is the size of a component in bits
+	Width uint32
+	// Components is the number of components of a vector, or of a column of a
+	// matrix. Scalars have one.
+	Components uint32
+	// Columns is the number of columns of a matrix. Scalars and vectors have
+	// one.
+	Columns uint32
+}
+
+// String returns the GLSL name of the format, like vec3 or mat4
+func (f Format) String() string {
+	prefix, scalar := "", "float"
+	switch f.Kind {
+	case Int:
+		prefix, scalar = "i", "int"
+	case Uint:
+		prefix, scalar = "u", "uint"
+	case Bool:
+		prefix, scalar = "b", "bool"
+	}
+	if f.Width == 64 {
+		prefix, scalar = "d"+prefix, "double"
+	}
+	switch {
+	case f.Columns > 1:
+		return fmt.Sprintf("%smat%dx%d", prefix, f.Columns, f.Components)
+	case f.Components > 1:
+		return fmt.Sprintf("%svec%d", prefix, f.Components)
+	}
+	return scalar
+}
+
+// Variable is an input or output of an entry point
+type Variable struct {
+	Name     string
+	Location uint32
+	Format   Format
+	// Locations is the number of locations the variable takes up, for
+	// matrices and arrays
+	Locations uint32
+}
+
+// EntryPoint is a function of the module a pipeline stage can run
+type EntryPoint struct {
+	Name    string
+	Stage   Stage
+	Inputs  []Variable
+	Outputs []Variable
+}
+
+// DescriptorType is the kind of resource a descriptor binding holds
+type DescriptorType uint8
+
+const (
+	// UniformBuffer is a read only block, a uniform in GLSL
+	UniformBuffer DescriptorType = iota
+	// StorageBuffer is a block that can be written, a buffer in GLSL
+	StorageBuffer
+	// CombinedImageSampler is an image with its sampler, like a sampler2D
+	CombinedImageSampler
+	// SampledImage is an image without a sampler, like a texture2D
+	SampledImage
+	// StorageImage is an image that's read and written without a sampler,
+	// like an image2D
+	StorageImage
+	// Sampler is a sampler without an image
+	Sampler
+	// UniformTexelBuffer is a buffer read as texels, a samplerBuffer
+	UniformTexelBuffer
+	// StorageTexelBuffer is a buffer read and written as texels, an
+	// imageBuffer
+	StorageTexelBuffer
+	// InputAttachment is an attachment of the render pass read by a fragment
+	// shader, a subpassInput
+	InputAttachment
+)
+
+// String returns the name of the descriptor type, like uniform buffer
+func (t DescriptorType) String() string {
+	switch t {
+	case UniformBuffer:
+		return "uniform buffer"
+	case StorageBuffer:
+		return "storage buffer"
+	case CombinedImageSampler:
+		return "combined image sampler"
+	case SampledImage:
+		return "sampled image"
+	case StorageImage:
+		return "storage image"
+	case Sampler:
+		return "sampler"
+	case UniformTexelBuffer:
+		return "uniform texel buffer"
+	case StorageTexelBuffer:
+		return "storage texel buffer"
+	case InputAttachment:
+		return "input attachment"
+	}
+	return fmt.Sprintf("descriptor type %d", uint8(t))
+}
+
+// Binding is a descriptor binding
+type Binding struct {
+	Name    string
+	Set     uint32
+	Binding uint32
+	Type    DescriptorType
+	// Count is the length of an array of descriptors. It's one for single
+	// descriptors and zero for runtime sized arrays.
+	Count uint32
+	// Size is the size in bytes of a buffer's block, without its runtime
+	// sized array
+	Size uint32
+	// Dim is the dimensionality of an image: 1, 2 or 3, or 0 for cubes and
+	// everything else
+	Dim uint32
+}
+
+// PushConstants is the push constant block of a module
+type PushConstants struct {
+	Name string
+	// Offset is where the first member of the block starts
+	Offset uint32
+	// Size is the size of the block from Offset
+	Size uint32
+}
+
+// SpecConstant is a specialization constant
+type SpecConstant struct {
+	Name   string
+	ID     uint32
+	Format Format
+	// Default is the bits of the default value. Bools are 0 or 1.
+	Default uint64
+}
+
+// Module is the interface of a SPIR-V module
+type Module struct {
+	EntryPoints   []EntryPoint
+	Bindings      []Binding
+	PushConstants *PushConstants
+	SpecConstants []SpecConstant
+}
+
+// EntryPoint returns the entry point with the given name
+func (m *Module) EntryPoint(name string) (EntryPoint, bool) {
+	for _, e := range m.EntryPoints {
+		if e.Name == name {
+			return e, true
+		}
+	}
+	return EntryPoint{}, false
+}
+
+const (
+	opName                 = 5
+	opEntryPoint           = 15
+	opTypeBool             = 20
+	opTypeInt              = 21
+	opTypeFloat            = 22
+	opTypeVector           = 23
+	opTypeMatrix           = 24
+	opTypeImage            = 25
+	opTypeSampler          = 26
+	opTypeSampledImage     = 27
+	opTypeArray            = 28
+	opTypeRuntimeArray     = 29
+	opTypeStruct           = 30
+	opTypePointer          = 32
+	opConstant             = 43
+	opSpecConstantTrue     = 48
+	opSpecConstantFalse    = 49
+	opSpecConstant         = 50
+	opVariable             = 59
+	opDecorate             = 71
+	opMemberDecorate       = 72
+	decorationSpecID       = 1
+	decorationBlock        = 2
+	decorationBufferBlock  = 3
+	decorationArrayStride  = 6
+	decorationMatrixStride = 7
+	decorationBuiltIn      = 11
+	decorationLocation     = 30
+	decorationBinding      = 33
+	decorationSet          = 34
+	decorationOffset       = 35
+	storageUniformConst    = 0
+	storageInput           = 1
+	storageUniform         = 2
+	storageOutput          = 3
+	storagePushConstant    = 9
+	storageStorageBuffer   = 12
+	dimBuffer              = 5
+	dimSubpassData         = 6
+)
+
+// decorations are the decorations of an id or a struct member that matter for
+// reflection
+type decorations struct {
+	specID, arrayStride, matrixStride, location, binding, set, offset uint32
+	hasSpecID, hasLocation, hasOffset                                 bool
+	block, bufferBlock, builtIn                                       bool
+}
+
+type variable struct {
+	id, typeID, storage uint32
+}
+
+type parser struct {
+	names      map[uint32]string
+	decorated  map[uint32]*decorations
+	members    map[uint32]map[uint32]*decorations
+	types      map[uint32][]uint32
+	constants  map[uint32]uint64
+	specs      [][2]uint32
+	variables  []variable
+	entries    []EntryPoint
+	interfaces [][]uint32
+	// sizes are the sizes of types that have been worked out, by type and
+	// matrix stride
+	sizes map[[2]uint32]uint32
+}
+
+// Parse reads the interface of a SPIR-V module
+func Parse(data []byte) (*Module, error) {
+	if len(data) < 20 || len(data)%4 != 0 {
+		return nil, errors.New("spirv: module must be a whole number of words with a header")
+	}
+	var order binary.ByteOrder = binary.LittleEndian
+	switch uint32(Magic) {
+	case binary.LittleEndian.Uint32(data):
+	case binary.BigEndian.Uint32(data):
+		order = binary.BigEndian
+	default:
+		return nil, errors.New("spirv: missing magic number")
+	}
+	words := make([]uint32, len(data)/4)
+	for i := range words {
+		words[i] = order.Uint32(data[i*4:])
+	}
+	p := parser{
+		names:     make(map[uint32]string),
+		decorated: make(map[uint32]*decorations),
+		members:   make(map[uint32]map[uint32]*decorations),
+		types:     make(map[uint32][]uint32),
+		sizes:     make(map[[2]uint32]uint32),
+		constants: make(map[uint32]uint64),
+	}
+	for i := 5; i < len(words); {
+		count := int(words[i] >> 16)
+		if count == 0 || i+count > len(words) {
+			return nil, fmt.Errorf("spirv: bad instruction length at word %d", i)
+		}
+		if err := p.instruction(words[i]&0xffff, words[i+1:i+count]); err != nil {
+			return nil, err
+		}
+		i += count
+	}
+	return p.module()
+}
+
+func (p *parser) decoration(id uint32) *decorations {
+	d, ok := p.decorated[id]
+	if !ok {
+		d = &decorations{}
+		p.decorated[id] = d
+	}
+	return d
+}
+
+func (p *parser) member(id, member uint32) *decorations {
+	m, ok := p.members[id]
+	if !ok {
+		m = make(map[uint32]*decorations)
+		p.members[id] = m
+	}
+	d, ok := m[member]
+	if !ok {
+		d = &decorations{}
+		m[member] = d
+	}
+	return d
+}
+
+func decorate(d *decorations, kind uint32, args []uint32) {
+	arg := uint32(0)
+	if len(args) > 0 {
+		arg = args[0]
+	}
+	switch kind {
+	case decorationSpecID:
+		d.specID, d.hasSpecID = arg, true
+	case decorationBlock:
+		d.block = true
+	case decorationBufferBlock:
+		d.bufferBlock = true
+	case decorationArrayStride:
+		d.arrayStride = arg
+	case decorationMatrixStride:
+		d.matrixStride = arg
+	case decorationBuiltIn:
+		d.builtIn = true
+	case decorationLocation:
+		d.location, d.hasLocation = arg, true
+	case decorationBinding:
+		d.binding = arg
+	case decorationSet:
+		d.set = arg
+	case decorationOffset:
+		d.offset, d.hasOffset = arg, true
+	}
+}
+
+func (p *parser) instruction(op uint32, args []uint32) error {
+	short := func(n int) error {
+		if len(args) < n {
+			return fmt.Errorf("spirv: instruction %d is too short", op)
+		}
+		return nil
+	}
+	switch op {
+	case opName:
+		if err := short(1); err != nil {
+			return err
+		}
+		p.names[args[0]], _ = literalString(args[1:])
+	case opEntryPoint:
+		if err := short(2); err != nil {
+			return err
+		}
+		name, n := literalString(args[2:])
+		p.entries = append(p.entries, EntryPoint{Name: name, Stage: Stage(args[0])})
+		p.interfaces = append(p.interfaces, args[2+n:])
+	case opTypeBool, opTypeInt, opTypeFloat, opTypeVector, opTypeMatrix, opTypeImage,
+		opTypeSampler, opTypeSampledImage, opTypeArray, opTypeRuntimeArray, opTypeStruct, opTypePointer:
+		if err := short(1); err != nil {
+			return err
+		}
+		p.types[args[0]] = append([]uint32{op}, args...)
+	case opConstant, opSpecConstant:
+		if err := short(3); err != nil {
+			return err
+		}
+		v := uint64(args[2])
+		if len(args) > 3 {
+			v |= uint64(args[3]) << 32
+		}
+		p.constants[args[1]] = v
+		if op == opSpecConstant {
+			p.specs = append(p.specs, [2]uint32{args[1], args[0]})
+		}
+	case opSpecConstantTrue, opSpecConstantFalse:
+		if err := short(2); err != nil {
+			return err
+		}
+		p.constants[args[1]] = 0
+		if op == opSpecConstantTrue {
+			p.constants[args[1]] = 1
+		}
+		p.specs = append(p.specs, [2]uint32{args[1], args[0]})
+	case opVariable:
+		if err := short(3); err != nil {
+			return err
+		}
+		p.variables = append(p.variables, variable{args[1], args[0], args[2]})
+	case opDecorate:
+		if err := short(2); err != nil {
+			return err
+		}
+		decorate(p.decoration(args[0]), args[1], args[2:])
+	case opMemberDecorate:
+		if err := short(3); err != nil {
+			return err
+		}
+		decorate(p.member(args[0], args[1]), args[2], args[3:])
+	}
+	return nil
+}
+
+// literalString decodes a nul terminated string and returns it with the number
+// of words it used.
+func literalString(words []uint32) (string, int) {
+	var b []byte
+	for i, w := range words {
+		for j := uint(0); j < 4; j++ {
+			c := byte(w >> (8 * j))
+			if c == 0 {
+				return string(b), i + 1
+			}
+			b = append(b, c)
+		}
+	}
+	return string(b), len(words)
+}
+
+func (p *parser) module() (*Module, error) {
+	m := &Module{}
+	for i, e := range p.entries {
+		for _, id := range p.interfaces[i] {
+			v, ok := p.variable(id)
+			if !ok || (v.storage != storageInput && v.storage != storageOutput) || p.builtIn(v) {
+				continue
+			}
+			iv, err := p.interfaceVariable(v)
+			if err != nil {
+				return nil, fmt.Errorf("spirv: %s entry point %s: %v", e.Stage, e.Name, err)
+			}
+			if v.storage == storageInput {
+				e.Inputs = append(e.Inputs, iv)
+			} else {
+				e.Outputs = append(e.Outputs, iv)
+			}
+		}
+		sort.Slice(e.Inputs, func(a, b int) bool { return e.Inputs[a].Location < e.Inputs[b].Location })
+		sort.Slice(e.Outputs, func(a, b int) bool { return e.Outputs[a].Location < e.Outputs[b].Location })
+		m.EntryPoints = append(m.EntryPoints, e)
+	}
+	for _, v := range p.variables {
+		switch v.storage {
+		case storageUniformConst, storageUniform, storageStorageBuffer:
+			b, ok, err := p.binding(v)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				m.Bindings = append(m.Bindings, b)
+			}
+		case storagePushConstant:
+			if m.PushConstants != nil {
+				return nil, errors.New("spirv: more than one push constant block")
+			}
+			pc, err := p.pushConstants(v)
+			if err != nil {
+				return nil, err
+			}
+			m.PushConstants = pc
+		}
+	}
+	sort.Slice(m.Bindings, func(a, b int) bool {
+		if m.Bindings[a].Set != m.Bindings[b].Set {
+			return m.Bindings[a].Set < m.Bindings[b].Set
+		}
+		return m.Bindings[a].Binding < m.Bindings[b].Binding
+	})
+	for _, spec := range p.specs {
+		d := p.decorated[spec[0]]
+		if d == nil || !d.hasSpecID {
+			continue
+		}
+		f, _ := p.format(spec[1], 0)
+		m.SpecConstants = append(m.SpecConstants, SpecConstant{
+			Name:    p.names[spec[0]],
+			ID:      d.specID,
+			Format:  f,
+			Default: p.constants[spec[0]],
+		})
+	}
+	return m, nil
+}
+
+func (p *parser) variable(id uint32) (variable, bool) {
+	for _, v := range p.variables {
+		if v.id == id {
+			return v, true
+		}
+	}
+	return variable{}, false
+}
+
+// pointee returns the type a pointer type points to
+func (p *parser) pointee(pointer uint32) (uint32, error) {
+	t := p.types[pointer]
+	if len(t) < 4 || t[0] != opTypePointer {
+		return 0, fmt.Errorf("type %d isn't a pointer", pointer)
+	}
+	return t[3], nil
+}
+
+// builtIn reports whether the variable, or the members of its block, are built
+// in variables like gl_Position.
+func (p *parser) builtIn(v variable) bool {
+	if d := p.decorated[v.id]; d != nil && d.builtIn {
+		return true
+	}
+	t, err := p.pointee(v.typeID)
+	if err != nil {
+		return false
+	}
+	t = p.element(t)
+	for _, d := range p.members[t] {
+		if d.builtIn {
+			return true
+		}
+	}
+	return false
+}
+
+// maxTypeDepth is how deeply types can nest. Types that go deeper, like ones
+// that refer to themselves in a malformed module, are treated as unknown.
+const maxTypeDepth = 64
+
+// element returns the element type of arrays, or the type itself
+func (p *parser) element(t uint32) uint32 {
+	for i := 0; i < maxTypeDepth; i++ {
+		tt := p.types[t]
+		if len(tt) < 3 || (tt[0] != opTypeArray && tt[0] != opTypeRuntimeArray) {
+			return t
+		}
+		t = tt[2]
+	}
+	return t
+}
+
+// length returns the number of elements of an array type, and one for
+// anything else. Runtime sized arrays have zero.
+func (p *parser) length(t uint32) uint32 {
+	n := uint32(1)
+	for i := 0; i < maxTypeDepth; i++ {
+		tt := p.types[t]
+		if len(tt) < 3 {
+			return n
+		}
+		switch tt[0] {
+		case opTypeArray:
+			if len(tt) < 4 {
+				return n
+			}
+			n *= uint32(p.constants[tt[3]])
+		case opTypeRuntimeArray:
+			return 0
+		default:
+			return n
+		}
+		t = tt[2]
+	}
+	return 0
+}
+
+// format returns the format of a scalar, vector or matrix type. depth is how
+// many types deep t is.
+func (p *parser) format(t uint32, depth int) (Format, bool) {
+	tt := p.types[t]
+	if len(tt) == 0 || depth > maxTypeDepth {
+		return Format{}, false
+	}
+	switch tt[0] {
+	case opTypeBool:
+		return Format{Kind: Bool, Width: 32, Components: 1, Columns: 1}, true
+	case opTypeInt:
+		if len(tt) < 4 {
+			return Format{}, false
+		}
+		kind := Uint
+		if tt[3] != 0 {
+			kind = Int
+		}
+		return Format{Kind: kind, Width: tt[2], Components: 1, Columns: 1}, true
+	case opTypeFloat:
+		if len(tt) < 3 {
+			return Format{}, false
+		}
+		return Format{Kind: Float, Width: tt[2], Components: 1, Columns: 1}, true
+	case opTypeVector:
+		if len(tt) < 4 {
+			return Format{}, false
+		}
+		f, ok := p.format(tt[2], depth+1)
+		f.Components = tt[3]
+		return f, ok
+	case opTypeMatrix:
+		if len(tt) < 4 {
+			return Format{}, false
+		}
+		f, ok := p.format(tt[2], depth+1)
+		f.Columns = tt[3]
+		return f, ok
+	}
+	return Format{}, false
+}
+
+func (p *parser) interfaceVariable(v variable) (Variable, error) {
+	name := p.names[v.id]
+	d := p.decorated[v.id]
+	if d == nil || !d.hasLocation {
+		return Variable{}, fmt.Errorf("%s has no location", name)
+	}
+	t, err := p.pointee(v.typeID)
+	if err != nil {
+		return Variable{}, err
+	}
+	f, ok := p.format(p.element(t), 0)
+	if !ok {
+		return Variable{}, fmt.Errorf("%s isn't a scalar, vector or matrix", name)
+	}
+	return Variable{
+		Name:      name,
+		Location:  d.location,
+		Format:    f,
+		Locations: p.length(t) * f.Columns,
+	}, nil
+}
+
+func (p *parser) binding(v variable) (Binding, bool, error) {
+	d := p.decorated[v.id]
+	t, err := p.pointee(v.typeID)
+	if err != nil {
+		return Binding{}, false, err
+	}
+	b := Binding{Name: p.names[v.id], Count: p.length(t)}
+	if d != nil {
+		b.Set, b.Binding = d.set, d.binding
+	}
+	elem := p.element(t)
+	tt := p.types[elem]
+	if len(tt) == 0 {
+		return b, false, nil
+	}
+	switch {
+	case tt[0] == opTypeStruct:
+		sd := p.decorated[elem]
+		switch {
+		case v.storage == storageStorageBuffer || (sd != nil && sd.bufferBlock):
+			b.Type = StorageBuffer
+		case v.storage == storageUniform:
+			b.Type = UniformBuffer
+		default:
+			return b, false, nil
+		}
+		if b.Name == "" {
+			b.Name = p.names[elem]
+		}
+		b.Size = p.size(elem, 0, 0)
+	case tt[0] == opTypeSampledImage:
+		b.Type = CombinedImageSampler
+		if len(tt) > 2 {
+			b.Dim = p.dim(tt[2])
+		}
+	case tt[0] == opTypeSampler:
+		b.Type = Sampler
+	case tt[0] == opTypeImage && len(tt) >= 8:
+		b.Dim = p.dim(elem)
+		switch {
+		case tt[3] == dimSubpassData:
+			b.Type = InputAttachment
+		case tt[3] == dimBuffer && tt[7] == 2:
+			b.Type = StorageTexelBuffer
+		case tt[3] == dimBuffer:
+			b.Type = UniformTexelBuffer
+		case tt[7] == 2:
+			b.Type = StorageImage
+		default:
+			b.Type = SampledImage
+		}
+	default:
+		return b, false, nil
+	}
+	if d == nil {
+		return b, false, fmt.Errorf("spirv: %s %s has no binding", b.Type, b.Name)
+	}
+	return b, true, nil
+}
+
+// dim returns the dimensionality of an image type
+func (p *parser) dim(t uint32) uint32 {
+	tt := p.types[t]
+	if len(tt) < 4 || tt[0] != opTypeImage || tt[3] > 2 {
+		return 0
+	}
+	return tt[3] + 1
+}
+
+// size returns the size in bytes of a type laid out in a block. matrixStride
+// is the stride of the columns of a matrix member, or zero to use the size of
+// the columns. depth is how many types deep t is.
+func (p *parser) size(t, matrixStride uint32, depth int) uint32 {
+	key := [2]uint32{t, matrixStride}
+	if size, ok := p.sizes[key]; ok {
+		return size
+	}
+	size := p.typeSize(t, matrixStride, depth)
+	p.sizes[key] = size
+	return size
+}
+
+// typeSize works out the size of a type for size, which remembers it so
+// structs that share members aren't laid out again.
+func (p *parser) typeSize(t, matrixStride uint32, depth int) uint32 {
+	tt := p.types[t]
+	if len(tt) == 0 || depth > maxTypeDepth {
+		return 0
+	}
+	switch tt[0] {
+	case opTypeBool:
+		return 4
+	case opTypeInt, opTypeFloat:
+		if len(tt) < 3 {
+			return 0
+		}
+		return tt[2] / 8
+	case opTypeVector:
+		if len(tt) < 4 {
+			return 0
+		}
+		return tt[3] * p.size(tt[2], 0, depth+1)
+	case opTypeMatrix:
+		if len(tt) < 4 {
+			return 0
+		}
+		if matrixStride == 0 {
+			matrixStride = p.size(tt[2], 0, depth+1)
+		}
+		return tt[3] * matrixStride
+	case opTypeArray:
+		if len(tt) < 4 {
+			return 0
+		}
+		stride := uint32(0)
+		if d := p.decorated[t]; d != nil {
+			stride = d.arrayStride
+		}
+		if stride == 0 {
+			stride = p.size(tt[2], matrixStride, depth+1)
+		}
+		return uint32(p.constants[tt[3]]) * stride
+	case opTypeRuntimeArray:
+		return 0
+	case opTypeStruct:
+		var end uint32
+		offset := uint32(0)
+		for i, member := range tt[2:] {
+			var stride uint32
+			if d := p.members[t][uint32(i)]; d != nil {
+				if d.hasOffset {
+					offset = d.offset
+				}
+				stride = d.matrixStride
+			}
+			size := p.size(member, stride, depth+1)
+			if offset+size > end {
+				end = offset + size
+			}
+			offset += size
+		}
+		return end
+	}
+	return 0
+}
+
+func (p *parser) pushConstants(v variable) (*PushConstants, error) {
+	t, err := p.pointee(v.typeID)
+	if err != nil {
+		return nil, err
+	}
+	tt := p.types[t]
+	if len(tt) == 0 || tt[0] != opTypeStruct {
+		return nil, errors.New("spirv: push constants aren't a block")
+	}
+	pc := &PushConstants{Name: p.names[v.id]}
+	if pc.Name == "" {
+		pc.Name = p.names[t]
+	}
+	first := true
+	for _, d := range p.members[t] {
+		if d.hasOffset && (first || d.offset < pc.Offset) {
+			pc.Offset, first = d.offset, false
+		}
+	}
+	pc.Size = p.size(t, 0, 0) - pc.Offset
+	return pc, nil
+}
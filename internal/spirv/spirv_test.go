@@ -0,0 +1,252 @@
+package spirv
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+var (
+	vec2 = Format{Kind: Float, Width: 32, Components: 2, Columns: 1}
+	vec4 = Format{Kind: Float, Width: 32, Components: 4, Columns: 1}
+)
+
+func readShader(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := ioutil.ReadFile(filepath.Join("..", "shaders", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestParseShaders(t *testing.T) {
+	for _, test := range []struct {
+		file          string
+		entry         EntryPoint
+		bindings      []Binding
+		pushConstants *PushConstants
+	}{
+		{
+			file: "vert.spv",
+			entry: EntryPoint{
+				Name:  "main",
+				Stage: StageVertex,
+				Inputs: []Variable{
+					{"inPosition", 0, vec2, 1},
+					{"inColor", 1, vec4, 1},
+					{"inTexCoord", 2, vec2, 1},
+				},
+				Outputs: []Variable{
+					{"fragColor", 0, vec4, 1},
+					{"fragTexCoord", 1, vec2, 1},
+				},
+			},
+			bindings: []Binding{{Name: "ubo", Binding: 0, Type: UniformBuffer, Count: 1, Size: 192}},
+		},
+		{
+			file: "frag.spv",
+			entry: EntryPoint{
+				Name:  "main",
+				Stage: StageFragment,
+				Inputs: []Variable{
+					{"fragColor", 0, vec4, 1},
+					{"fragTexCoord", 1, vec2, 1},
+				},
+				Outputs: []Variable{{"outColor", 0, vec4, 1}},
+			},
+			bindings: []Binding{{Name: "texSampler", Binding: 1, Type: CombinedImageSampler, Count: 1, Dim: 2}},
+		},
+		{
+			file: "fullscreen.spv",
+			entry: EntryPoint{
+				Name:    "main",
+				Stage:   StageVertex,
+				Outputs: []Variable{{"uv", 0, vec2, 1}},
+			},
+		},
+		{
+			file: "grade.spv",
+			entry: EntryPoint{
+				Name:    "main",
+				Stage:   StageFragment,
+				Inputs:  []Variable{{"uv", 0, vec2, 1}},
+				Outputs: []Variable{{"outColor", 0, vec4, 1}},
+			},
+			bindings: []Binding{
+				{Name: "source", Binding: 0, Type: CombinedImageSampler, Count: 1, Dim: 2},
+				{Name: "lutA", Binding: 2, Type: CombinedImageSampler, Count: 1, Dim: 3},
+				{Name: "lutB", Binding: 3, Type: CombinedImageSampler, Count: 1, Dim: 3},
+			},
+			pushConstants: &PushConstants{Name: "constants", Offset: 0, Size: 128},
+		},
+	} {
+		m, err := Parse(readShader(t, test.file))
+		if err != nil {
+			t.Errorf("%s: %v", test.file, err)
+			continue
+		}
+		if len(m.EntryPoints) != 1 {
+			t.Errorf("%s has %d entry points, want 1", test.file, len(m.EntryPoints))
+			continue
+		}
+		e, ok := m.EntryPoint("main")
+		if !ok {
+			t.Errorf("%s has no main entry point", test.file)
+			continue
+		}
+		// modules without inputs or outputs can have nil or empty lists
+		if len(e.Inputs) == 0 {
+			e.Inputs = nil
+		}
+		if len(e.Outputs) == 0 {
+			e.Outputs = nil
+		}
+		if !reflect.DeepEqual(e, test.entry) {
+			t.Errorf("%s entry point is %+v, want %+v", test.file, e, test.entry)
+		}
+		if len(m.Bindings) != len(test.bindings) || (len(test.bindings) > 0 && !reflect.DeepEqual(m.Bindings, test.bindings)) {
+			t.Errorf("%s bindings are %+v, want %+v", test.file, m.Bindings, test.bindings)
+		}
+		if !reflect.DeepEqual(m.PushConstants, test.pushConstants) {
+			t.Errorf("%s push constants are %+v, want %+v", test.file, m.PushConstants, test.pushConstants)
+		}
+	}
+}
+
+func TestParseAllShaders(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("..", "shaders", "*.spv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no shaders found")
+	}
+	for _, f := range files {
+		data, err := ioutil.ReadFile(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Parse(data); err != nil {
+			t.Errorf("%s: %v", f, err)
+		}
+	}
+}
+
+func TestParseBigEndian(t *testing.T) {
+	data := readShader(t, "vert.spv")
+	swapped := make([]byte, len(data))
+	for i := 0; i < len(data); i += 4 {
+		binary.BigEndian.PutUint32(swapped[i:], binary.LittleEndian.Uint32(data[i:]))
+	}
+	want, err := Parse(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := Parse(swapped)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("big endian module is %+v, want %+v", got, want)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	data := readShader(t, "frag.spv")
+	badMagic := append([]byte(nil), data...)
+	badMagic[0] ^= 0xff
+	// the first instruction claims to run past the end of the module
+	badLength := append([]byte(nil), data[:24]...)
+	binary.LittleEndian.PutUint32(badLength[20:], 100<<16)
+	zeroLength := append([]byte(nil), data[:24]...)
+	binary.LittleEndian.PutUint32(zeroLength[20:], 0)
+	for name, d := range map[string][]byte{
+		"empty":              nil,
+		"short header":       data[:16],
+		"partial word":       data[:len(data)-1],
+		"bad magic":          badMagic,
+		"long instruction":   badLength,
+		"zero length opcode": zeroLength,
+	} {
+		if _, err := Parse(d); err == nil {
+			t.Errorf("%s module parsed without an error", name)
+		}
+	}
+}
+
+func TestFormatString(t *testing.T) {
+	for f, want := range map[Format]string{
+		{Kind: Float, Width: 32, Components: 1, Columns: 1}: "float",
+		vec2: "vec2",
+		{Kind: Int, Width: 32, Components: 3, Columns: 1}:   "ivec3",
+		{Kind: Uint, Width: 32, Components: 1, Columns: 1}:  "uint",
+		{Kind: Bool, Width: 32, Components: 2, Columns: 1}:  "bvec2",
+		{Kind: Float, Width: 64, Components: 4, Columns: 1}: "dvec4",
+		{Kind: Float, Width: 32, Components: 4, Columns: 4}: "mat4x4",
+	} {
+		if s := f.String(); s != want {
+			t.Errorf("%+v is %q, want %q", f, s, want)
+		}
+	}
+}
+
+// assemble makes a module from instructions, each an opcode and its operands
+func assemble(instructions ...[]uint32) []byte {
+	words := []uint32{Magic, 0x10000, 0, 100, 0}
+	for _, inst := range instructions {
+		words = append(words, uint32(len(inst))<<16|inst[0])
+		words = append(words, inst[1:]...)
+	}
+	data := make([]byte, len(words)*4)
+	for i, w := range words {
+		binary.LittleEndian.PutUint32(data[i*4:], w)
+	}
+	return data
+}
+
+func TestParseMalformedTypes(t *testing.T) {
+	// an input variable at location 0 of the type with id 1, read by a
+	// vertex shader
+	input := [][]uint32{
+		{opTypePointer, 10, storageInput, 1},
+		{opVariable, 10, 11, storageInput},
+		{opDecorate, 11, decorationLocation, 0},
+		{opEntryPoint, uint32(StageVertex), 12, 0x6e69616d, 0, 11},
+	}
+	// a uniform block of the type with id 1
+	uniform := [][]uint32{
+		{opTypePointer, 10, storageUniform, 1},
+		{opVariable, 10, 11, storageUniform},
+		{opDecorate, 1, decorationBlock},
+		{opDecorate, 11, decorationBinding, 0},
+	}
+	for _, test := range []struct {
+		name  string
+		types [][]uint32
+		use   [][]uint32
+	}{
+		{"short int in a block", [][]uint32{{opTypeInt, 2}, {opTypeStruct, 1, 2}}, uniform},
+		{"short float in a block", [][]uint32{{opTypeFloat, 2}, {opTypeStruct, 1, 2}}, uniform},
+		{"short vector in a block", [][]uint32{{opTypeVector, 2}, {opTypeStruct, 1, 2}}, uniform},
+		{"short array in a block", [][]uint32{{opTypeArray, 2, 3}, {opTypeStruct, 1, 2}}, uniform},
+		{"vector of itself", [][]uint32{{opTypeVector, 1, 1, 4}}, input},
+		{"matrix of itself", [][]uint32{{opTypeMatrix, 1, 1, 4}}, input},
+		{"array of itself", [][]uint32{{opConstant, 2, 3, 4}, {opTypeArray, 1, 1, 3}}, input},
+		{"array of itself in a block", [][]uint32{{opConstant, 2, 3, 4}, {opTypeArray, 4, 4, 3}, {opTypeStruct, 1, 4}}, uniform},
+		{"block of itself", [][]uint32{{opTypeStruct, 1, 1, 1, 1, 1}}, uniform},
+		{"vector of itself in a block", [][]uint32{{opTypeVector, 2, 2, 4}, {opTypeStruct, 1, 2, 2}}, uniform},
+	} {
+		data := assemble(append(test.types, test.use...)...)
+		func() {
+			defer func() {
+				if v := recover(); v != nil {
+					t.Errorf("%s: parsing panicked: %v", test.name, v)
+				}
+			}()
+			Parse(data)
+		}()
+	}
+}
@@ -0,0 +1,308 @@
+package vulkanRenderSystem
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Noofbiz/vulkanRenderSystem/internal/spirv"
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// uniformBufferSize is the size of the UniformBufferObject shaders get
+const uniformBufferSize = 4 * 16 * 3
+
+// shaderInterface is what a shader needs from the renderer, read from its
+// SPIR-V
+type shaderInterface struct {
+	// bindings are the descriptor bindings of set 0
+	bindings []vk.DescriptorSetLayoutBinding
+	// uniformBinding and textureBinding are where the UniformBufferObject and
+	// the drawn texture are bound, or -1 if the shader doesn't use them
+	uniformBinding, textureBinding int
+	pushConstants                  []vk.PushConstantRange
+	attributes                     []vk.VertexInputAttributeDescription
+}
+
+// vertexFormats are the formats of the vertex inputs shaders can read
+var vertexFormats = map[vk.Format]spirv.Format{
+	vk.FormatR32Sfloat:          {Kind: spirv.Float, Width: 32, Components: 1, Columns: 1},
+	vk.FormatR32g32Sfloat:       {Kind: spirv.Float, Width: 32, Components: 2, Columns: 1},
+	vk.FormatR32g32b32Sfloat:    {Kind: spirv.Float, Width: 32, Components: 3, Columns: 1},
+	vk.FormatR32g32b32a32Sfloat: {Kind: spirv.Float, Width: 32, Components: 4, Columns: 1},
+}
+
+// reflectShader reads the interface of a vertex and fragment shader, and
+// checks that they fit each other, the vertex layout and what the renderer
+// binds.
+func reflectShader(vert, frag []byte) (shaderInterface, error) {
+	iface := shaderInterface{uniformBinding: -1, textureBinding: -1}
+	vm, err := spirv.Parse(vert)
+	if err != nil {
+		return iface, errors.New("invalid vertex shader: " + err.Error())
+	}
+	fm, err := spirv.Parse(frag)
+	if err != nil {
+		return iface, errors.New("invalid fragment shader: " + err.Error())
+	}
+	ve, ok := vm.EntryPoint("main")
+	if !ok || ve.Stage != spirv.StageVertex {
+		return iface, errors.New("vertex shader has no vertex entry point named main")
+	}
+	fe, ok := fm.EntryPoint("main")
+	if !ok || fe.Stage != spirv.StageFragment {
+		return iface, errors.New("fragment shader has no fragment entry point named main")
+	}
+	if iface.attributes, err = vertexAttributes(ve.Inputs); err != nil {
+		return iface, err
+	}
+	if err = matchStages(ve.Outputs, fe.Inputs); err != nil {
+		return iface, err
+	}
+	for _, stage := range []struct {
+		m     *spirv.Module
+		flags vk.ShaderStageFlagBits
+	}{{vm, vk.ShaderStageVertexBit}, {fm, vk.ShaderStageFragmentBit}} {
+		for _, b := range stage.m.Bindings {
+			if err := iface.addBinding(b, stage.flags); err != nil {
+				return iface, err
+			}
+		}
+		if pc := stage.m.PushConstants; pc != nil {
+			iface.pushConstants = append(iface.pushConstants, vk.PushConstantRange{
+				StageFlags: vk.ShaderStageFlags(stage.flags),
+				Offset:     pc.Offset,
+				Size:       pc.Size,
+			})
+		}
+	}
+	return iface, nil
+}
+
+// effectConstantsSize is the size of the push constants effect shaders get:
+// the resolution and the time in seconds as floats, the pass index as a float,
+// and maxEffectParams params
+const effectConstantsSize = 128
+
+// maxEffectParams is the number of params passed to effect shaders
+const maxEffectParams = (effectConstantsSize - 16) / 4
+
+// effectSamplers are the bindings effect shaders can sample: the output of the
+// last pass, what the effect started from and the LUTs of the effect
+var effectSamplers = []struct {
+	name string
+	dim  uint32
+}{
+	{"sampler2D of the output of the last pass", 2},
+	{"sampler2D of the input of the effect", 2},
+	{"sampler3D of the first LUT", 3},
+	{"sampler3D of the second LUT", 3},
+}
+
+// reflectEffect reads the interface of the fragment shader of an effect pass,
+// which is drawn with the full screen vertex shader, and checks it only uses
+// what effects are given.
+func reflectEffect(vert, frag []byte) (shaderInterface, error) {
+	iface := shaderInterface{uniformBinding: -1, textureBinding: -1}
+	vm, err := spirv.Parse(vert)
+	if err != nil {
+		return iface, errors.New("invalid vertex shader: " + err.Error())
+	}
+	fm, err := spirv.Parse(frag)
+	if err != nil {
+		return iface, errors.New("invalid fragment shader: " + err.Error())
+	}
+	ve, _ := vm.EntryPoint("main")
+	fe, ok := fm.EntryPoint("main")
+	if !ok || fe.Stage != spirv.StageFragment {
+		return iface, errors.New("fragment shader has no fragment entry point named main")
+	}
+	if err = matchStages(ve.Outputs, fe.Inputs); err != nil {
+		return iface, err
+	}
+	for _, b := range fm.Bindings {
+		if b.Set != 0 || b.Type != spirv.CombinedImageSampler || b.Count != 1 || int(b.Binding) >= len(effectSamplers) || b.Dim != effectSamplers[b.Binding].dim {
+			names := make([]string, len(effectSamplers))
+			for i, s := range effectSamplers {
+				names[i] = s.name
+			}
+			return iface, fmt.Errorf("%s %s at set %d binding %d isn't bound, effects can sample the %s at bindings 0 to %d",
+				b.Type, b.Name, b.Set, b.Binding, strings.Join(names, ", the "), len(effectSamplers)-1)
+		}
+		iface.bindings = append(iface.bindings, vk.DescriptorSetLayoutBinding{
+			Binding:         b.Binding,
+			DescriptorType:  vk.DescriptorTypeCombinedImageSampler,
+			DescriptorCount: 1,
+			StageFlags:      vk.ShaderStageFlags(vk.ShaderStageFragmentBit),
+		})
+	}
+	if pc := fm.PushConstants; pc != nil && pc.Offset+pc.Size > effectConstantsSize {
+		return iface, fmt.Errorf("push constants %s end at byte %d, but effects only get %d", pc.Name, pc.Offset+pc.Size, effectConstantsSize)
+	}
+	// the constants are always pushed, so the range is there even if the
+	// shader doesn't read them
+	iface.pushConstants = []vk.PushConstantRange{{
+		StageFlags: vk.ShaderStageFlags(vk.ShaderStageFragmentBit),
+		Offset:     0,
+		Size:       effectConstantsSize,
+	}}
+	return iface, nil
+}
+
+// vertexAttributes returns the attributes of the vertex layout the vertex
+// shader reads.
+func vertexAttributes(inputs []spirv.Variable) ([]vk.VertexInputAttributeDescription, error) {
+	var v vertex
+	provided := v.getAttributeDescriptions()
+	var attributes []vk.VertexInputAttributeDescription
+	for _, in := range inputs {
+		found := false
+		for _, a := range provided {
+			if a.Location != in.Location {
+				continue
+			}
+			// inputs can leave off components the vertex has, like the alpha
+			// of a vec3 color
+			have := vertexFormats[a.Format]
+			if in.Locations != 1 || in.Format.Kind != have.Kind || in.Format.Width != have.Width ||
+				in.Format.Columns != have.Columns || in.Format.Components > have.Components {
+				return nil, fmt.Errorf("vertex shader input %s at location %d is a %s, but the vertex has a %s there",
+					in.Name, in.Location, in.Format, have)
+			}
+			attributes = append(attributes, a)
+			found = true
+		}
+		if !found {
+			return nil, fmt.Errorf("vertex shader input %s at location %d isn't in the vertex, which has a vec2 position, vec4 color and vec2 texture coordinate at locations 0 to 2",
+				in.Name, in.Location)
+		}
+	}
+	return attributes, nil
+}
+
+// matchStages checks that the vertex shader writes every input of the
+// fragment shader.
+func matchStages(outputs, inputs []spirv.Variable) error {
+	for _, in := range inputs {
+		found := false
+		for _, out := range outputs {
+			if out.Location != in.Location {
+				continue
+			}
+			if out.Format != in.Format {
+				return fmt.Errorf("fragment shader input %s at location %d is a %s, but the vertex shader writes a %s there",
+					in.Name, in.Location, in.Format, out.Format)
+			}
+			found = true
+		}
+		if !found {
+			return fmt.Errorf("fragment shader input %s at location %d isn't written by the vertex shader", in.Name, in.Location)
+		}
+	}
+	return nil
+}
+
+// addBinding adds a descriptor binding used by a stage. The renderer binds
+// the UniformBufferObject and the drawn texture in set 0, so that's all
+// shaders can use.
+func (iface *shaderInterface) addBinding(b spirv.Binding, stage vk.ShaderStageFlagBits) error {
+	if b.Set != 0 {
+		return fmt.Errorf("%s %s is in descriptor set %d, only set 0 is bound", b.Type, b.Name, b.Set)
+	}
+	var kind vk.DescriptorType
+	switch {
+	case b.Type == spirv.UniformBuffer && b.Count == 1:
+		if b.Size > uniformBufferSize {
+			return fmt.Errorf("uniform buffer %s is %d bytes, but the bound UniformBufferObject is only %d", b.Name, b.Size, uniformBufferSize)
+		}
+		kind = vk.DescriptorTypeUniformBuffer
+	case b.Type == spirv.CombinedImageSampler && b.Count == 1 && b.Dim == 2:
+		kind = vk.DescriptorTypeCombinedImageSampler
+	default:
+		return fmt.Errorf("%s %s at binding %d isn't bound, shaders can only use a uniform buffer and a 2D sampler",
+			b.Type, b.Name, b.Binding)
+	}
+	for i, have := range iface.bindings {
+		if have.Binding != b.Binding {
+			continue
+		}
+		if have.DescriptorType != kind {
+			return fmt.Errorf("binding %d is a different type in the vertex and fragment shaders", b.Binding)
+		}
+		iface.bindings[i].StageFlags |= vk.ShaderStageFlags(stage)
+		return nil
+	}
+	binding := &iface.uniformBinding
+	if kind == vk.DescriptorTypeCombinedImageSampler {
+		binding = &iface.textureBinding
+	}
+	if *binding >= 0 {
+		return fmt.Errorf("%s %s at binding %d is the second one, shaders can only use one", b.Type, b.Name, b.Binding)
+	}
+	*binding = int(b.Binding)
+	iface.bindings = append(iface.bindings, vk.DescriptorSetLayoutBinding{
+		Binding:         b.Binding,
+		DescriptorType:  kind,
+		DescriptorCount: 1,
+		StageFlags:      vk.ShaderStageFlags(stage),
+	})
+	return nil
+}
+
+// key identifies the layouts of the interface, so shaders with the same
+// bindings and push constants share them.
+func (iface *shaderInterface) key() string {
+	return fmt.Sprint(iface.bindings, iface.pushConstants)
+}
+
+// shaderLayout is the descriptor set layout and pipeline layout of shaders
+// with the same interface
+type shaderLayout struct {
+	setLayout                      vk.DescriptorSetLayout
+	pipelineLayout                 vk.PipelineLayout
+	uniformBinding, textureBinding int
+}
+
+// layout returns the layouts of the shader, creating them the first time
+// they're used.
+func (r *RenderSystem) layout(s *Shader) (*shaderLayout, error) {
+	key := s.iface.key()
+	if l, ok := r.layouts[key]; ok {
+		return l, nil
+	}
+	l := &shaderLayout{
+		uniformBinding: s.iface.uniformBinding,
+		textureBinding: s.iface.textureBinding,
+	}
+	if res := vk.CreateDescriptorSetLayout(r.device, &vk.DescriptorSetLayoutCreateInfo{
+		SType:        vk.StructureTypeDescriptorSetLayoutCreateInfo,
+		BindingCount: uint32(len(s.iface.bindings)),
+		PBindings:    s.iface.bindings,
+	}, nil, &l.setLayout); res != vk.Success {
+		return nil, errors.New("unable to create descriptor set layout")
+	}
+	if res := vk.CreatePipelineLayout(r.device, &vk.PipelineLayoutCreateInfo{
+		SType:                  vk.StructureTypePipelineLayoutCreateInfo,
+		SetLayoutCount:         1,
+		PSetLayouts:            []vk.DescriptorSetLayout{l.setLayout},
+		PushConstantRangeCount: uint32(len(s.iface.pushConstants)),
+		PPushConstantRanges:    s.iface.pushConstants,
+	}, nil, &l.pipelineLayout); res != vk.Success {
+		vk.DestroyDescriptorSetLayout(r.device, l.setLayout, nil)
+		return nil, errors.New("failed to create pipeline layout")
+	}
+	if r.layouts == nil {
+		r.layouts = make(map[string]*shaderLayout)
+	}
+	r.layouts[key] = l
+	return l, nil
+}
+
+// destroyLayouts destroys the layouts of all shaders.
+func (r *RenderSystem) destroyLayouts() {
+	for key, l := range r.layouts {
+		vk.DestroyPipelineLayout(r.device, l.pipelineLayout, nil)
+		vk.DestroyDescriptorSetLayout(r.device, l.setLayout, nil)
+		delete(r.layouts, key)
+	}
+}
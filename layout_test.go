@@ -0,0 +1,110 @@
+package vulkanRenderSystem
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Noofbiz/vulkanRenderSystem/internal/shaders"
+	"github.com/Noofbiz/vulkanRenderSystem/internal/spirv"
+)
+
+func shaderAsset(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := shaders.Asset(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestReflectShader(t *testing.T) {
+	iface, err := reflectShader(shaderAsset(t, "vert.spv"), shaderAsset(t, "frag.spv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if iface.uniformBinding != 0 || iface.textureBinding != 1 {
+		t.Errorf("uniforms are at binding %d and the texture at %d, want 0 and 1", iface.uniformBinding, iface.textureBinding)
+	}
+	if len(iface.attributes) != 3 {
+		t.Errorf("got %d vertex attributes, want 3", len(iface.attributes))
+	}
+	if len(iface.pushConstants) != 0 {
+		t.Errorf("got %d push constant ranges, want none", len(iface.pushConstants))
+	}
+	// the full screen vertex shader writes a vec2 where the fragment shader
+	// reads its color
+	if _, err := reflectShader(shaderAsset(t, "fullscreen.spv"), shaderAsset(t, "frag.spv")); err == nil {
+		t.Error("mismatched stages reflected without an error")
+	}
+}
+
+func TestReflectEffect(t *testing.T) {
+	iface, err := reflectEffect(shaderAsset(t, "fullscreen.spv"), shaderAsset(t, "grade.spv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(iface.bindings) != 3 {
+		t.Errorf("got %d bindings, want 3", len(iface.bindings))
+	}
+	if len(iface.pushConstants) != 1 || iface.pushConstants[0].Size != effectConstantsSize {
+		t.Errorf("push constants are %+v, want a single range of %d bytes", iface.pushConstants, effectConstantsSize)
+	}
+	if _, err := reflectEffect(shaderAsset(t, "fullscreen.spv"), shaderAsset(t, "frag.spv")); err == nil {
+		t.Error("a fragment shader reading vertex colors reflected as an effect without an error")
+	}
+}
+
+func TestMatchStages(t *testing.T) {
+	vec2 := spirv.Format{Kind: spirv.Float, Width: 32, Components: 2, Columns: 1}
+	vec4 := spirv.Format{Kind: spirv.Float, Width: 32, Components: 4, Columns: 1}
+	ivec2 := spirv.Format{Kind: spirv.Int, Width: 32, Components: 2, Columns: 1}
+	outputs := []spirv.Variable{
+		{Name: "color", Location: 0, Format: vec4, Locations: 1},
+		{Name: "uv", Location: 1, Format: vec2, Locations: 1},
+	}
+	for _, test := range []struct {
+		name   string
+		inputs []spirv.Variable
+		err    string
+	}{
+		{"no inputs", nil, ""},
+		{"same", outputs, ""},
+		{"unread outputs", outputs[1:], ""},
+		{"different format", []spirv.Variable{{Name: "uv", Location: 1, Format: ivec2, Locations: 1}}, "is a ivec2, but the vertex shader writes a vec2"},
+		{"fewer components", []spirv.Variable{{Name: "color", Location: 0, Format: vec2, Locations: 1}}, "is a vec2, but the vertex shader writes a vec4"},
+		{"unwritten", []spirv.Variable{{Name: "normal", Location: 2, Format: vec2, Locations: 1}}, "normal at location 2 isn't written"},
+	} {
+		err := matchStages(outputs, test.inputs)
+		switch {
+		case test.err == "" && err != nil:
+			t.Errorf("%s: %v", test.name, err)
+		case test.err != "" && err == nil:
+			t.Errorf("%s matched without an error", test.name)
+		case test.err != "" && !strings.Contains(err.Error(), test.err):
+			t.Errorf("%s: error %q doesn't contain %q", test.name, err, test.err)
+		}
+	}
+}
+
+func TestVertexAttributes(t *testing.T) {
+	vec2 := spirv.Format{Kind: spirv.Float, Width: 32, Components: 2, Columns: 1}
+	vec3 := spirv.Format{Kind: spirv.Float, Width: 32, Components: 3, Columns: 1}
+	vec4 := spirv.Format{Kind: spirv.Float, Width: 32, Components: 4, Columns: 1}
+	// shaders can read the color without its alpha
+	a, err := vertexAttributes([]spirv.Variable{{Name: "color", Location: 1, Format: vec3, Locations: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(a) != 1 || a[0].Location != 1 || a[0].Offset != 2*4 {
+		t.Errorf("attributes are %+v, want the color at offset 8", a)
+	}
+	for name, in := range map[string]spirv.Variable{
+		"more components": {Name: "uv", Location: 2, Format: vec4, Locations: 1},
+		"matrix":          {Name: "uv", Location: 2, Format: spirv.Format{Kind: spirv.Float, Width: 32, Components: 2, Columns: 2}, Locations: 2},
+		"missing":         {Name: "normal", Location: 3, Format: vec2, Locations: 1},
+	} {
+		if _, err := vertexAttributes([]spirv.Variable{in}); err == nil {
+			t.Errorf("%s input read from the vertex without an error", name)
+		}
+	}
+}
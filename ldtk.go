@@ -0,0 +1,416 @@
+package vulkanRenderSystem
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"path"
+	"strconv"
+
+	"github.com/EngoEngine/engo"
+)
+
+type ldtkField struct {
+	Identifier string          `json:"__identifier"`
+	Value      json.RawMessage `json:"__value"`
+}
+
+type ldtkTile struct {
+	Px [2]int `json:"px"`
+	F  int    `json:"f"`
+	T  uint32 `json:"t"`
+}
+
+type ldtkEntity struct {
+	Identifier string      `json:"__identifier"`
+	IID        string      `json:"iid"`
+	Pivot      [2]float32  `json:"__pivot"`
+	Px         [2]float32  `json:"px"`
+	Width      float32     `json:"width"`
+	Height     float32     `json:"height"`
+	Fields     []ldtkField `json:"fieldInstances"`
+}
+
+type ldtkLayer struct {
+	Identifier     string       `json:"__identifier"`
+	Type           string       `json:"__type"`
+	CWid           int          `json:"__cWid"`
+	CHei           int          `json:"__cHei"`
+	GridSize       int          `json:"__gridSize"`
+	Opacity        float32      `json:"__opacity"`
+	OffsetX        int          `json:"__pxTotalOffsetX"`
+	OffsetY        int          `json:"__pxTotalOffsetY"`
+	TilesetDefUID  *int         `json:"__tilesetDefUid"`
+	Visible        bool         `json:"visible"`
+	IntGridCSV     []int        `json:"intGridCsv"`
+	GridTiles      []ldtkTile   `json:"gridTiles"`
+	AutoLayerTiles []ldtkTile   `json:"autoLayerTiles"`
+	Entities       []ldtkEntity `json:"entityInstances"`
+}
+
+type ldtkLevel struct {
+	Identifier      string      `json:"identifier"`
+	WorldX          int         `json:"worldX"`
+	WorldY          int         `json:"worldY"`
+	PxWid           int         `json:"pxWid"`
+	PxHei           int         `json:"pxHei"`
+	Fields          []ldtkField `json:"fieldInstances"`
+	Layers          []ldtkLayer `json:"layerInstances"`
+	ExternalRelPath *string     `json:"externalRelPath"`
+}
+
+type ldtkTileset struct {
+	UID          int     `json:"uid"`
+	Identifier   string  `json:"identifier"`
+	RelPath      *string `json:"relPath"`
+	PxWid        int     `json:"pxWid"`
+	PxHei        int     `json:"pxHei"`
+	TileGridSize int     `json:"tileGridSize"`
+	Spacing      int     `json:"spacing"`
+	Padding      int     `json:"padding"`
+	CustomData   []struct {
+		TileID uint32 `json:"tileId"`
+		Data   string `json:"data"`
+	} `json:"customData"`
+}
+
+type ldtkProject struct {
+	Defs struct {
+		Tilesets []ldtkTileset `json:"tilesets"`
+	} `json:"defs"`
+	Levels []ldtkLevel `json:"levels"`
+	// projects with multiple worlds keep their levels in the worlds
+	Worlds []struct {
+		Levels []ldtkLevel `json:"levels"`
+	} `json:"worlds"`
+}
+
+// LDtkResource is the resource of a loaded .ldtk project
+type LDtkResource struct {
+	// Levels are the levels of every world in the project. Their layers are
+	// offset by the level's position in the world, so adding all of them to
+	// the RenderSystem draws the whole world.
+	Levels []*Level
+	url    string
+}
+
+// URL returns the url of the .ldtk file
+func (r LDtkResource) URL() string {
+	return r.url
+}
+
+// Level returns the level with the given identifier, or nil if there isn't
+// one.
+func (r LDtkResource) Level(name string) *Level {
+	for _, l := range r.Levels {
+		if l.Name == name {
+			return l
+		}
+	}
+	return nil
+}
+
+// ldtkProperties converts field instances to properties. Strings are kept as
+// they are, other values are stored as json.
+func ldtkProperties(fields []ldtkField) map[string]string {
+	m := make(map[string]string, len(fields))
+	for _, f := range fields {
+		var s *string
+		if err := json.Unmarshal(f.Value, &s); err == nil {
+			if s != nil {
+				m[f.Identifier] = *s
+			} else {
+				m[f.Identifier] = ""
+			}
+			continue
+		}
+		m[f.Identifier] = string(f.Value)
+	}
+	return m
+}
+
+func newLDtkTileset(t *ldtkTileset, url string) (*Tileset, error) {
+	ts := &Tileset{
+		Name:           t.Identifier,
+		TileWidth:      t.TileGridSize,
+		TileHeight:     t.TileGridSize,
+		Spacing:        t.Spacing,
+		Margin:         t.Padding,
+		ImageWidth:     t.PxWid,
+		ImageHeight:    t.PxHei,
+		Properties:     make(map[string]string),
+		TileProperties: make(map[uint32]map[string]string),
+		Animations:     make(map[uint32][]AnimationFrame),
+		tileImages:     make(map[uint32]tmxImage),
+	}
+	if t.TileGridSize > 0 {
+		ts.Columns = (t.PxWid - 2*t.Padding + t.Spacing) / (t.TileGridSize + t.Spacing)
+		rows := (t.PxHei - 2*t.Padding + t.Spacing) / (t.TileGridSize + t.Spacing)
+		ts.TileCount = ts.Columns * rows
+	}
+	for _, d := range t.CustomData {
+		ts.TileProperties[d.TileID] = map[string]string{"data": d.Data}
+	}
+	// tilesets without a path, such as the embedded icons, can't be drawn
+	if t.RelPath == nil {
+		return ts, nil
+	}
+	ts.Image = path.Join(path.Dir(url), *t.RelPath)
+	if err := loadTilesetImage(ts.Image); err != nil {
+		return nil, err
+	}
+	return ts, nil
+}
+
+// newLDtkLevel creates a level. Tiles that are stacked on the same cell are
+// put into separate tile layers, drawn on top of each other.
+func newLDtkLevel(l *ldtkLevel, tilesets map[int]*Tileset) (*Level, error) {
+	level := &Level{
+		Name:        l.Identifier,
+		Orientation: OrientationOrthogonal,
+		RenderOrder: "right-down",
+		Properties:  ldtkProperties(l.Fields),
+	}
+	var tileSize, gridSize int
+	// the layers are listed from the top down
+	for i := len(l.Layers) - 1; i >= 0; i-- {
+		t := &l.Layers[i]
+		offsetX := float32(l.WorldX + t.OffsetX)
+		offsetY := float32(l.WorldY + t.OffsetY)
+		if t.Type == "Entities" {
+			layer := &ObjectLayer{
+				Name:       t.Identifier,
+				Opacity:    t.Opacity,
+				Visible:    t.Visible,
+				OffsetX:    offsetX,
+				OffsetY:    offsetY,
+				ParallaxX:  1,
+				ParallaxY:  1,
+				Properties: make(map[string]string),
+			}
+			for _, e := range t.Entities {
+				layer.Objects = append(layer.Objects, newLDtkObject(&e))
+			}
+			level.ObjectLayers = append(level.ObjectLayers, layer)
+			level.layers = append(level.layers, layer)
+			continue
+		}
+
+		tiles := append(t.GridTiles, t.AutoLayerTiles...)
+		var ts *Tileset
+		if t.TilesetDefUID != nil {
+			var ok bool
+			if ts, ok = tilesets[*t.TilesetDefUID]; !ok {
+				return nil, errors.New("layer " + t.Identifier + " uses unknown tileset " + strconv.Itoa(*t.TilesetDefUID))
+			}
+			level.addTileset(ts)
+		}
+		// the tiles of all layers are placed on the level's grid
+		if len(tiles) > 0 {
+			if tileSize == 0 {
+				tileSize = t.GridSize
+			} else if t.GridSize != tileSize {
+				return nil, errors.New("layer " + t.Identifier + " has a different grid size than the other tile layers")
+			}
+		}
+		if gridSize == 0 {
+			gridSize = t.GridSize
+		}
+
+		var stack []*TileLayer
+		depth := make([]int, t.CWid*t.CHei)
+		newLayer := func() *TileLayer {
+			layer := &TileLayer{
+				Name:       t.Identifier,
+				Width:      t.CWid,
+				Height:     t.CHei,
+				Opacity:    t.Opacity,
+				Visible:    t.Visible,
+				OffsetX:    offsetX,
+				OffsetY:    offsetY,
+				ParallaxX:  1,
+				ParallaxY:  1,
+				Properties: make(map[string]string),
+				Tiles:      make([]Tile, t.CWid*t.CHei),
+			}
+			stack = append(stack, layer)
+			return layer
+		}
+		if t.Type == "IntGrid" {
+			newLayer().Values = t.IntGridCSV
+		}
+		for _, tile := range tiles {
+			if ts == nil || t.GridSize == 0 {
+				break
+			}
+			x, y := tile.Px[0]/t.GridSize, tile.Px[1]/t.GridSize
+			if x < 0 || y < 0 || x >= t.CWid || y >= t.CHei {
+				continue
+			}
+			idx := y*t.CWid + x
+			for len(stack) <= depth[idx] {
+				newLayer()
+			}
+			stack[depth[idx]].Tiles[idx] = Tile{
+				ID:      tile.T,
+				Tileset: ts,
+				FlipH:   tile.F&1 != 0,
+				FlipV:   tile.F&2 != 0,
+			}
+			depth[idx]++
+		}
+		for _, layer := range stack {
+			level.TileLayers = append(level.TileLayers, layer)
+			level.layers = append(level.layers, layer)
+		}
+	}
+	if tileSize == 0 {
+		tileSize = gridSize
+	}
+	if tileSize > 0 {
+		level.TileWidth, level.TileHeight = tileSize, tileSize
+		level.Width, level.Height = l.PxWid/tileSize, l.PxHei/tileSize
+	}
+	return level, nil
+}
+
+func (l *Level) addTileset(ts *Tileset) {
+	for _, t := range l.Tilesets {
+		if t == ts {
+			return
+		}
+	}
+	l.Tilesets = append(l.Tilesets, ts)
+}
+
+// newLDtkObject creates an object from an entity. The entity's identifier is
+// used as the object's name and type.
+func newLDtkObject(e *ldtkEntity) *Object {
+	o := &Object{
+		Name:       e.Identifier,
+		Type:       e.Identifier,
+		Kind:       ObjectKindRectangle,
+		X:          e.Px[0] - e.Pivot[0]*e.Width,
+		Y:          e.Px[1] - e.Pivot[1]*e.Height,
+		Width:      e.Width,
+		Height:     e.Height,
+		Visible:    true,
+		Properties: ldtkProperties(e.Fields),
+	}
+	if o.Width == 0 && o.Height == 0 {
+		o.Kind = ObjectKindPoint
+	}
+	o.Properties["iid"] = e.IID
+	return o
+}
+
+type ldtkLoader struct {
+	projects map[string]LDtkResource
+}
+
+var theLDtkLoader = ldtkLoader{projects: make(map[string]LDtkResource)}
+
+func (t *ldtkLoader) Load(url string, data io.Reader) error {
+	var p ldtkProject
+	if err := json.NewDecoder(data).Decode(&p); err != nil {
+		return err
+	}
+	tilesets := make(map[int]*Tileset, len(p.Defs.Tilesets))
+	for i := range p.Defs.Tilesets {
+		ts, err := newLDtkTileset(&p.Defs.Tilesets[i], url)
+		if err != nil {
+			return err
+		}
+		tilesets[p.Defs.Tilesets[i].UID] = ts
+	}
+	levels := p.Levels
+	for _, w := range p.Worlds {
+		levels = append(levels, w.Levels...)
+	}
+	res := LDtkResource{url: url}
+	for i := range levels {
+		l := &levels[i]
+		if l.ExternalRelPath != nil {
+			var err error
+			if l, err = loadLDtkLevel(path.Join(path.Dir(url), *l.ExternalRelPath)); err != nil {
+				return err
+			}
+		}
+		level, err := newLDtkLevel(l, tilesets)
+		if err != nil {
+			return errors.New("unable to load ldtk level " + l.Identifier + " of " + url + ": " + err.Error())
+		}
+		res.Levels = append(res.Levels, level)
+	}
+	t.projects[url] = res
+	return nil
+}
+
+func (t *ldtkLoader) Unload(url string) error {
+	delete(t.projects, url)
+	return nil
+}
+
+func (t *ldtkLoader) Resource(url string) (engo.Resource, error) {
+	if res, ok := t.projects[url]; ok {
+		return res, nil
+	}
+	return LDtkResource{}, errors.New("unable to locate resource with url: " + url)
+}
+
+// ldtkLevelResource is a level saved in its own .ldtkl file. It's only used
+// while loading the project.
+type ldtkLevelResource struct {
+	level *ldtkLevel
+	url   string
+}
+
+func (r ldtkLevelResource) URL() string {
+	return r.url
+}
+
+type ldtkLevelLoader struct {
+	levels map[string]ldtkLevelResource
+}
+
+var theLDtkLevelLoader = ldtkLevelLoader{levels: make(map[string]ldtkLevelResource)}
+
+func (t *ldtkLevelLoader) Load(url string, data io.Reader) error {
+	var l ldtkLevel
+	if err := json.NewDecoder(data).Decode(&l); err != nil {
+		return err
+	}
+	t.levels[url] = ldtkLevelResource{&l, url}
+	return nil
+}
+
+func (t *ldtkLevelLoader) Unload(url string) error {
+	delete(t.levels, url)
+	return nil
+}
+
+func (t *ldtkLevelLoader) Resource(url string) (engo.Resource, error) {
+	if res, ok := t.levels[url]; ok {
+		return res, nil
+	}
+	return ldtkLevelResource{}, errors.New("unable to locate resource with url: " + url)
+}
+
+// loadLDtkLevel loads an external level file, unless it's already loaded.
+func loadLDtkLevel(url string) (*ldtkLevel, error) {
+	if _, ok := theLDtkLevelLoader.levels[url]; !ok {
+		if err := engo.Files.Load(url); err != nil {
+			return nil, err
+		}
+	}
+	res, err := theLDtkLevelLoader.Resource(url)
+	if err != nil {
+		return nil, err
+	}
+	return res.(ldtkLevelResource).level, nil
+}
+
+func init() {
+	engo.Files.Register(".ldtk", &theLDtkLoader)
+	engo.Files.Register(".ldtkl", &theLDtkLevelLoader)
+}
@@ -0,0 +1,354 @@
+package vulkanRenderSystem
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/EngoEngine/engo"
+)
+
+// LineJoin is how the segments of a Polyline are connected
+type LineJoin uint8
+
+const (
+	// LineJoinMiter extends the edges of the segments until they meet
+	LineJoinMiter LineJoin = iota
+	// LineJoinBevel cuts the corner off between the segments
+	LineJoinBevel
+	// LineJoinRound rounds the corner off
+	LineJoinRound
+)
+
+// LineCap is how the ends of a line are drawn
+type LineCap uint8
+
+const (
+	// LineCapButt ends the line at its end points
+	LineCapButt LineCap = iota
+	// LineCapSquare extends the line by half its thickness
+	LineCapSquare
+	// LineCapRound adds half a circle to the ends of the line
+	LineCapRound
+)
+
+// Polyline is a thick line through a list of points. It's tessellated into
+// triangles, so it doesn't need wide line support from the device.
+type Polyline struct {
+	shapeBase
+	// Points are the points the line goes through, in pixels from the
+	// entity's position
+	Points []engo.Point
+	// Colors are the colors of the points. Points without a color use the
+	// RenderComponent's Color.
+	Colors    []color.Color
+	Thickness float32
+	Join      LineJoin
+	Cap       LineCap
+	// MiterLimit is the longest a miter join can be, compared to the line's
+	// thickness, before it's beveled instead. Zero uses 4.
+	MiterLimit float32
+	// Dashes are the lengths of the dashes and the gaps between them, in
+	// pixels. Empty draws a solid line.
+	Dashes []float32
+	// DashOffset is how far into the dash pattern the line starts
+	DashOffset float32
+	// Closed connects the last point back to the first one
+	Closed bool
+}
+
+// Line is a thick line between two points
+type Line struct {
+	shapeBase
+	// From and To are the end points of the line, in pixels from the
+	// entity's position
+	From, To engo.Point
+	// FromColor and ToColor are the colors of the end points. Nil uses the
+	// RenderComponent's Color.
+	FromColor, ToColor color.Color
+	Thickness          float32
+	Cap                LineCap
+	// Dashes are the lengths of the dashes and the gaps between them, in
+	// pixels. Empty draws a solid line.
+	Dashes []float32
+	// DashOffset is how far into the dash pattern the line starts
+	DashOffset float32
+}
+
+func (l Line) appendShape(b *geometryBatch, w, h float32, p placement, fill color.Color) {
+	Polyline{
+		Points:     []engo.Point{l.From, l.To},
+		Colors:     []color.Color{l.FromColor, l.ToColor},
+		Thickness:  l.Thickness,
+		Cap:        l.Cap,
+		Dashes:     l.Dashes,
+		DashOffset: l.DashOffset,
+	}.appendShape(b, w, h, p, fill)
+}
+
+// linePoint is a point of a line with its color
+type linePoint struct {
+	pos [2]float32
+	c   [4]float32
+}
+
+func (l Polyline) appendShape(b *geometryBatch, w, h float32, p placement, fill color.Color) {
+	if l.Thickness <= 0 || len(l.Points) < 2 {
+		return
+	}
+	points := make([]linePoint, 0, len(l.Points)+1)
+	for i, pt := range l.Points {
+		c := fill
+		if i < len(l.Colors) && l.Colors[i] != nil {
+			c = l.Colors[i]
+		}
+		lp := linePoint{[2]float32{pt.X, pt.Y}, colorToVertex(c)}
+		// points on top of each other have no direction
+		if n := len(points); n > 0 && points[n-1].pos == lp.pos {
+			continue
+		}
+		points = append(points, lp)
+	}
+	closed := l.Closed && len(points) > 2
+	if closed && points[0].pos == points[len(points)-1].pos {
+		points = points[:len(points)-1]
+	}
+	if len(points) < 2 {
+		return
+	}
+	s := stroke{
+		b:          b,
+		tex:        shapeTexture(),
+		p:          p,
+		hw:         l.Thickness / 2,
+		join:       l.Join,
+		cap:        l.Cap,
+		miterLimit: l.MiterLimit,
+	}
+	if s.miterLimit <= 0 {
+		s.miterLimit = 4
+	}
+	if len(l.Dashes) == 0 {
+		s.polyline(points, closed)
+		return
+	}
+	if closed {
+		points = append(points, points[0])
+	}
+	for _, dash := range dashes(points, l.Dashes, l.DashOffset) {
+		s.polyline(dash, false)
+	}
+}
+
+// dashes splits the line into the dashes of the pattern. Colors are
+// interpolated where the line is cut.
+func dashes(points []linePoint, pattern []float32, offset float32) [][]linePoint {
+	pattern = append([]float32(nil), pattern...)
+	var total float32
+	for i, d := range pattern {
+		pattern[i] = max32(d, 0)
+		total += pattern[i]
+	}
+	if total <= 0 {
+		return [][]linePoint{points}
+	}
+	// find where in the pattern the line starts
+	i, left := 0, float32(math.Mod(float64(offset), float64(total)))
+	if left < 0 {
+		left += total
+	}
+	for left >= pattern[i] {
+		left -= pattern[i]
+		i = (i + 1) % len(pattern)
+	}
+	left = pattern[i] - left
+
+	var out [][]linePoint
+	var current []linePoint
+	on := i%2 == 0
+	if on {
+		current = []linePoint{points[0]}
+	}
+	for j := 1; j < len(points); j++ {
+		a, z := points[j-1], points[j]
+		length := float32(math.Hypot(float64(z.pos[0]-a.pos[0]), float64(z.pos[1]-a.pos[1])))
+		var done float32
+		for length-done > left {
+			done += left
+			cut := lerpPoint(a, z, done/length)
+			if on {
+				out = append(out, append(current, cut))
+				current = nil
+			} else {
+				current = []linePoint{cut}
+			}
+			on = !on
+			i = (i + 1) % len(pattern)
+			left = pattern[i]
+		}
+		left -= length - done
+		if on {
+			current = append(current, z)
+		}
+	}
+	if on && len(current) > 1 {
+		out = append(out, current)
+	}
+	return out
+}
+
+func lerpPoint(a, b linePoint, t float32) linePoint {
+	var p linePoint
+	for i := range p.pos {
+		p.pos[i] = a.pos[i] + (b.pos[i]-a.pos[i])*t
+	}
+	for i := range p.c {
+		p.c[i] = a.c[i] + (b.c[i]-a.c[i])*t
+	}
+	return p
+}
+
+// stroke tessellates lines into the batch
+type stroke struct {
+	b          *geometryBatch
+	tex        *Texture
+	p          placement
+	hw         float32
+	join       LineJoin
+	cap        LineCap
+	miterLimit float32
+}
+
+func (s *stroke) add(points [][2]float32, colors [][4]float32, indices []uint32, c [4]float32) {
+	s.p.apply(points)
+	s.b.addMesh(s.tex, points, colors, indices, c)
+}
+
+// polyline draws a quad for each segment, then fills the gaps at the joins
+// and adds the caps.
+func (s *stroke) polyline(points []linePoint, closed bool) {
+	n := len(points)
+	if n < 2 {
+		return
+	}
+	segments := n - 1
+	if closed {
+		segments = n
+	}
+	dirs := make([][2]float32, segments)
+	for i := range dirs {
+		a, z := points[i].pos, points[(i+1)%n].pos
+		dirs[i] = normalize(z[0]-a[0], z[1]-a[1])
+	}
+	for i := 0; i < segments; i++ {
+		a, z := points[i], points[(i+1)%n]
+		d := dirs[i]
+		// square caps lengthen the first and last segment
+		if !closed && s.cap == LineCapSquare {
+			if i == 0 {
+				a.pos[0] -= d[0] * s.hw
+				a.pos[1] -= d[1] * s.hw
+			}
+			if i == segments-1 {
+				z.pos[0] += d[0] * s.hw
+				z.pos[1] += d[1] * s.hw
+			}
+		}
+		nx, ny := -d[1]*s.hw, d[0]*s.hw
+		s.add([][2]float32{
+			{a.pos[0] + nx, a.pos[1] + ny},
+			{z.pos[0] + nx, z.pos[1] + ny},
+			{z.pos[0] - nx, z.pos[1] - ny},
+			{a.pos[0] - nx, a.pos[1] - ny},
+		}, [][4]float32{a.c, z.c, z.c, a.c}, []uint32{0, 1, 2, 2, 3, 0}, a.c)
+	}
+	for i := 0; i < segments; i++ {
+		if !closed && i == segments-1 {
+			break
+		}
+		s.joint(points[(i+1)%n], dirs[i], dirs[(i+1)%segments])
+	}
+	if !closed && s.cap == LineCapRound {
+		first, last := dirs[0], dirs[segments-1]
+		start := math.Atan2(float64(first[0]), float64(-first[1]))
+		end := math.Atan2(float64(-last[0]), float64(last[1]))
+		s.fan(points[0], start, math.Pi)
+		s.fan(points[n-1], end, math.Pi)
+	}
+}
+
+// joint fills the outside of the corner between two segments meeting at p
+func (s *stroke) joint(p linePoint, in, out [2]float32) {
+	turn := in[0]*out[1] - in[1]*out[0]
+	if float32(math.Abs(float64(turn))) < 1e-6 && in[0]*out[0]+in[1]*out[1] > 0 {
+		return
+	}
+	// the outside of the corner is left of the line for right turns on screen
+	side := float32(1)
+	if turn > 0 {
+		side = -1
+	}
+	n0 := [2]float32{-in[1] * s.hw * side, in[0] * s.hw * side}
+	n1 := [2]float32{-out[1] * s.hw * side, out[0] * s.hw * side}
+	c := p.pos
+	switch s.join {
+	case LineJoinRound:
+		start := math.Atan2(float64(n0[1]), float64(n0[0]))
+		end := math.Atan2(float64(n1[1]), float64(n1[0]))
+		sweep := end - start
+		for sweep > math.Pi {
+			sweep -= 2 * math.Pi
+		}
+		for sweep < -math.Pi {
+			sweep += 2 * math.Pi
+		}
+		s.fan(p, start, sweep)
+		return
+	case LineJoinMiter:
+		m := normalize(n0[0]+n1[0], n0[1]+n1[1])
+		cos := (m[0]*n0[0] + m[1]*n0[1]) / s.hw
+		if cos > 1e-6 && 1/cos <= s.miterLimit {
+			l := s.hw / cos
+			s.add([][2]float32{
+				c,
+				{c[0] + n0[0], c[1] + n0[1]},
+				{c[0] + m[0]*l, c[1] + m[1]*l},
+				{c[0] + n1[0], c[1] + n1[1]},
+			}, nil, []uint32{0, 1, 2, 0, 2, 3}, p.c)
+			return
+		}
+	}
+	s.add([][2]float32{
+		c,
+		{c[0] + n0[0], c[1] + n0[1]},
+		{c[0] + n1[0], c[1] + n1[1]},
+	}, nil, []uint32{0, 1, 2}, p.c)
+}
+
+// fan adds a slice of a circle around p, sweeping from the start angle
+func (s *stroke) fan(p linePoint, start, sweep float64) {
+	steps := int(math.Ceil(math.Abs(sweep) * float64(s.hw) / 4))
+	if steps < 2 {
+		steps = 2
+	} else if steps > 64 {
+		steps = 64
+	}
+	points := make([][2]float32, 0, steps+2)
+	points = append(points, p.pos)
+	indices := make([]uint32, 0, 3*steps)
+	for i := 0; i <= steps; i++ {
+		sin, cos := math.Sincos(start + sweep*float64(i)/float64(steps))
+		points = append(points, [2]float32{p.pos[0] + float32(cos)*s.hw, p.pos[1] + float32(sin)*s.hw})
+		if i > 0 {
+			indices = append(indices, 0, uint32(i), uint32(i+1))
+		}
+	}
+	s.add(points, nil, indices, p.c)
+}
+
+func normalize(x, y float32) [2]float32 {
+	l := float32(math.Hypot(float64(x), float64(y)))
+	if l == 0 {
+		return [2]float32{}
+	}
+	return [2]float32{x / l, y / l}
+}
@@ -0,0 +1,404 @@
+package vulkanRenderSystem
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unsafe"
+
+	"github.com/EngoEngine/engo"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// lutFormat is the format of LUT textures. Half floats keep the precision of
+// the table, and every device can filter them.
+const lutFormat = vk.FormatR16g16b16a16Sfloat
+
+// maxLUTSize is the largest LUT_3D_SIZE a LUT can have
+const maxLUTSize = 256
+
+// LUT is a 3D color lookup table, like the .cube files color grading tools
+// export. Effects look the colors of the screen up in it.
+type LUT struct {
+	// Title is the title of the table, if it has one
+	Title string
+
+	texture Texture
+	size    int
+}
+
+// NewLUT creates a LUT of size entries along each side from its colors, with
+// red changing fastest, then green, then blue, for inputs from 0 to 1.
+func NewLUT(size int, colors [][3]float32) (*LUT, error) {
+	if theRenderSystem == nil {
+		return nil, errors.New("tried to create a LUT without a vulkan render system setup")
+	}
+	if size < 2 || size > maxLUTSize {
+		return nil, fmt.Errorf("LUTs must be 2 to %d entries on a side, not %d", maxLUTSize, size)
+	}
+	if len(colors) != size*size*size {
+		return nil, fmt.Errorf("a LUT of size %d needs %d colors, not %d", size, size*size*size, len(colors))
+	}
+	l := &LUT{size: size}
+	if err := theRenderSystem.createLUTImage(&l.texture, size, colors); err != nil {
+		l.texture.Destroy(theRenderSystem.device)
+		return nil, err
+	}
+	return l, nil
+}
+
+// Size returns the number of entries along each side of the table.
+func (l *LUT) Size() int {
+	return l.size
+}
+
+// Destroy frees the LUT. Effects using it shouldn't be drawn anymore. LUTs
+// loaded from .cube files are unloaded, and destroying a LUT again does
+// nothing.
+func (l *LUT) Destroy() {
+	for url, res := range theLUTLoader.luts {
+		if res.LUT == l {
+			delete(theLUTLoader.luts, url)
+		}
+	}
+	if l.texture == (Texture{}) {
+		return
+	}
+	vk.DeviceWaitIdle(theRenderSystem.device)
+	l.texture.Destroy(theRenderSystem.device)
+	l.texture = Texture{}
+}
+
+// createLUTImage uploads the colors to a 3D image and creates its view and
+// sampler.
+func (r *RenderSystem) createLUTImage(tex *Texture, size int, colors [][3]float32) error {
+	data := make([]byte, 8*len(colors))
+	for i, c := range colors {
+		binary.LittleEndian.PutUint16(data[i*8:], halfFloat(c[0]))
+		binary.LittleEndian.PutUint16(data[i*8+2:], halfFloat(c[1]))
+		binary.LittleEndian.PutUint16(data[i*8+4:], halfFloat(c[2]))
+		binary.LittleEndian.PutUint16(data[i*8+6:], halfFloat(1))
+	}
+	dataSize := vk.DeviceSize(len(data))
+	stagingBuffer, stagingBufferMemory, err := r.createBuffer(dataSize, vk.BufferUsageFlags(vk.BufferUsageTransferSrcBit), vk.MemoryPropertyFlags(vk.MemoryPropertyHostVisibleBit|vk.MemoryPropertyHostCoherentBit))
+	if err != nil {
+		return err
+	}
+	defer vk.DestroyBuffer(r.device, stagingBuffer, nil)
+	defer vk.FreeMemory(r.device, stagingBufferMemory, nil)
+	var mapped unsafe.Pointer
+	vk.MapMemory(r.device, stagingBufferMemory, 0, dataSize, 0, &mapped)
+	vk.Memcopy(mapped, data)
+	vk.UnmapMemory(r.device, stagingBufferMemory)
+
+	tex.texWidth, tex.texHeight = int32(size), int32(size)
+	if res := vk.CreateImage(r.device, &vk.ImageCreateInfo{
+		SType:     vk.StructureTypeImageCreateInfo,
+		ImageType: vk.ImageType3d,
+		Extent: vk.Extent3D{
+			Width:  uint32(size),
+			Height: uint32(size),
+			Depth:  uint32(size),
+		},
+		MipLevels:     1,
+		ArrayLayers:   1,
+		Format:        lutFormat,
+		Tiling:        vk.ImageTilingOptimal,
+		InitialLayout: vk.ImageLayoutUndefined,
+		Usage:         vk.ImageUsageFlags(vk.ImageUsageTransferDstBit | vk.ImageUsageSampledBit),
+		SharingMode:   vk.SharingModeExclusive,
+		Samples:       vk.SampleCount1Bit,
+	}, nil, &tex.image); res != vk.Success {
+		return errors.New("unable to create LUT image")
+	}
+	if tex.mem, err = r.bindImageMemory(tex.image); err != nil {
+		return err
+	}
+	if err = r.transitionImageLayout(tex.image, lutFormat, vk.ImageLayoutUndefined, vk.ImageLayoutTransferDstOptimal); err != nil {
+		return err
+	}
+	if err = r.copyBufferToImage(stagingBuffer, tex.image, uint32(size), uint32(size), uint32(size)); err != nil {
+		return err
+	}
+	if err = r.transitionImageLayout(tex.image, lutFormat, vk.ImageLayoutTransferDstOptimal, vk.ImageLayoutShaderReadOnlyOptimal); err != nil {
+		return err
+	}
+	if res := vk.CreateImageView(r.device, &vk.ImageViewCreateInfo{
+		SType:    vk.StructureTypeImageViewCreateInfo,
+		Image:    tex.image,
+		ViewType: vk.ImageViewType3d,
+		Format:   lutFormat,
+		SubresourceRange: vk.ImageSubresourceRange{
+			AspectMask: vk.ImageAspectFlags(vk.ImageAspectColorBit),
+			LevelCount: 1,
+			LayerCount: 1,
+		},
+	}, nil, &tex.view); res != vk.Success {
+		return errors.New("failed to create LUT image view")
+	}
+	if res := vk.CreateSampler(r.device, &vk.SamplerCreateInfo{
+		SType:                   vk.StructureTypeSamplerCreateInfo,
+		MagFilter:               vk.FilterLinear,
+		MinFilter:               vk.FilterLinear,
+		AddressModeU:            vk.SamplerAddressModeClampToEdge,
+		AddressModeV:            vk.SamplerAddressModeClampToEdge,
+		AddressModeW:            vk.SamplerAddressModeClampToEdge,
+		BorderColor:             vk.BorderColorIntOpaqueBlack,
+		UnnormalizedCoordinates: vk.Bool32(vk.False),
+		CompareOp:               vk.CompareOpAlways,
+		MipmapMode:              vk.SamplerMipmapModeNearest,
+	}, nil, &tex.sampler); res != vk.Success {
+		return errors.New("failed to create LUT sampler")
+	}
+	return nil
+}
+
+// halfFloat converts f to the bits of the nearest half float.
+func halfFloat(f float32) uint16 {
+	b := math.Float32bits(f)
+	sign := uint16(b>>16) & 0x8000
+	exp := int(b>>23&0xff) - 127 + 15
+	mant := b & 0x7fffff
+	switch {
+	case b&0x7fffffff > 0x7f800000:
+		return sign | 0x7e00
+	case exp >= 31:
+		return sign | 0x7c00
+	case exp <= 0:
+		// too small for a normal half, so it's subnormal or zero
+		if exp < -10 {
+			return sign
+		}
+		mant |= 0x800000
+		shift := uint(14 - exp)
+		h := mant >> shift
+		if mant>>(shift-1)&1 != 0 {
+			h++
+		}
+		return sign | uint16(h)
+	}
+	// rounding can carry into the exponent, which is still the nearest half
+	h := uint32(exp)<<10 | mant>>13
+	if mant&0x1000 != 0 {
+		h++
+	}
+	return sign | uint16(h)
+}
+
+// cubeFile is a parsed .cube file
+type cubeFile struct {
+	title                string
+	size                 int
+	domainMin, domainMax [3]float32
+	colors               [][3]float32
+}
+
+// parseCube reads a 3D LUT in the .cube format.
+func parseCube(data io.Reader) (*cubeFile, error) {
+	cube := &cubeFile{domainMax: [3]float32{1, 1, 1}}
+	scanner := bufio.NewScanner(data)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		fields := strings.Fields(text)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		var err error
+		switch fields[0] {
+		case "TITLE":
+			cube.title = strings.Trim(strings.TrimSpace(strings.TrimPrefix(text, "TITLE")), `"`)
+		case "LUT_3D_SIZE":
+			if len(fields) != 2 {
+				return nil, fmt.Errorf("line %d: LUT_3D_SIZE needs a size", line)
+			}
+			if cube.size, err = strconv.Atoi(fields[1]); err != nil || cube.size < 2 || cube.size > maxLUTSize {
+				return nil, fmt.Errorf("line %d: LUT_3D_SIZE must be 2 to %d", line, maxLUTSize)
+			}
+		case "LUT_1D_SIZE":
+			return nil, fmt.Errorf("line %d: 1D LUTs aren't supported", line)
+		case "DOMAIN_MIN":
+			err = parseCubeTriple(fields[1:], &cube.domainMin)
+		case "DOMAIN_MAX":
+			err = parseCubeTriple(fields[1:], &cube.domainMax)
+		case "LUT_3D_INPUT_RANGE":
+			var r [2]float64
+			if len(fields) != 3 {
+				err = errors.New("LUT_3D_INPUT_RANGE needs a min and a max")
+				break
+			}
+			for i := range r {
+				if r[i], err = strconv.ParseFloat(fields[i+1], 32); err != nil {
+					break
+				}
+			}
+			cube.domainMin = [3]float32{float32(r[0]), float32(r[0]), float32(r[0])}
+			cube.domainMax = [3]float32{float32(r[1]), float32(r[1]), float32(r[1])}
+		default:
+			if c := fields[0][0]; c != '-' && c != '+' && c != '.' && (c < '0' || c > '9') {
+				// other keywords don't change the table
+				continue
+			}
+			var color [3]float32
+			if err = parseCubeTriple(fields, &color); err == nil {
+				cube.colors = append(cube.colors, color)
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if cube.size == 0 {
+		return nil, errors.New("no LUT_3D_SIZE")
+	}
+	if len(cube.colors) != cube.size*cube.size*cube.size {
+		return nil, fmt.Errorf("LUT_3D_SIZE %d needs %d colors, but there are %d", cube.size, cube.size*cube.size*cube.size, len(cube.colors))
+	}
+	for i := range cube.domainMin {
+		if cube.domainMax[i] <= cube.domainMin[i] {
+			return nil, errors.New("DOMAIN_MAX must be more than DOMAIN_MIN")
+		}
+	}
+	return cube, nil
+}
+
+// parseCubeTriple parses three floats into v.
+func parseCubeTriple(fields []string, v *[3]float32) error {
+	if len(fields) != 3 {
+		return fmt.Errorf("expected 3 numbers, got %d", len(fields))
+	}
+	for i, field := range fields {
+		f, err := strconv.ParseFloat(field, 32)
+		if err != nil {
+			return err
+		}
+		v[i] = float32(f)
+	}
+	return nil
+}
+
+// normalized returns the colors of the table for inputs from 0 to 1. Tables
+// with another domain are resampled, so shaders can look any LUT up the same
+// way.
+func (c *cubeFile) normalized() [][3]float32 {
+	if c.domainMin == [3]float32{0, 0, 0} && c.domainMax == [3]float32{1, 1, 1} {
+		return c.colors
+	}
+	n := c.size
+	colors := make([][3]float32, 0, len(c.colors))
+	for b := 0; b < n; b++ {
+		for g := 0; g < n; g++ {
+			for r := 0; r < n; r++ {
+				var p [3]float32
+				for i, v := range [3]int{r, g, b} {
+					in := float32(v) / float32(n-1)
+					t := (in - c.domainMin[i]) / (c.domainMax[i] - c.domainMin[i])
+					p[i] = float32(math.Max(0, math.Min(1, float64(t)))) * float32(n-1)
+				}
+				colors = append(colors, c.sample(p))
+			}
+		}
+	}
+	return colors
+}
+
+// sample interpolates the table trilinearly at p, in entries.
+func (c *cubeFile) sample(p [3]float32) [3]float32 {
+	n := c.size
+	var lo, hi [3]int
+	var frac [3]float32
+	for i := range p {
+		lo[i] = int(p[i])
+		if lo[i] > n-2 {
+			lo[i] = n - 2
+		}
+		hi[i] = lo[i] + 1
+		frac[i] = p[i] - float32(lo[i])
+	}
+	var out [3]float32
+	for corner := 0; corner < 8; corner++ {
+		idx := [3]int{lo[0], lo[1], lo[2]}
+		w := float32(1)
+		for i := range idx {
+			if corner>>uint(i)&1 != 0 {
+				idx[i] = hi[i]
+				w *= frac[i]
+			} else {
+				w *= 1 - frac[i]
+			}
+		}
+		color := c.colors[idx[0]+idx[1]*n+idx[2]*n*n]
+		for i := range out {
+			out[i] += color[i] * w
+		}
+	}
+	return out
+}
+
+// LUTResource is a LUT loaded from a .cube file
+type LUTResource struct {
+	LUT *LUT
+	url string
+}
+
+// URL returns the url the LUT was loaded from.
+func (l LUTResource) URL() string {
+	return l.url
+}
+
+type lutLoader struct {
+	luts map[string]LUTResource
+}
+
+var theLUTLoader lutLoader
+
+// lutsToAdd are the .cube files loaded before the RenderSystem was set up
+var lutsToAdd []string
+
+func (l *lutLoader) Load(url string, data io.Reader) error {
+	if theRenderSystem == nil {
+		lutsToAdd = append(lutsToAdd, url)
+		return nil
+	}
+	cube, err := parseCube(data)
+	if err != nil {
+		return errors.New("unable to parse LUT " + url + ": " + err.Error())
+	}
+	lut, err := NewLUT(cube.size, cube.normalized())
+	if err != nil {
+		return err
+	}
+	lut.Title = cube.title
+	theRenderSystem.nameTexture(&lut.texture, url)
+	l.luts[url] = LUTResource{lut, url}
+	return nil
+}
+
+func (l *lutLoader) Unload(url string) error {
+	if res, ok := l.luts[url]; ok {
+		res.LUT.Destroy()
+	}
+	return nil
+}
+
+func (l *lutLoader) Resource(url string) (engo.Resource, error) {
+	if res, ok := l.luts[url]; ok {
+		return res, nil
+	}
+	return LUTResource{}, errors.New("unable to locate resource with url: " + url)
+}
+
+func init() {
+	theLUTLoader = lutLoader{luts: make(map[string]LUTResource)}
+	engo.Files.Register(".cube", &theLUTLoader)
+}
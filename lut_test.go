@@ -0,0 +1,202 @@
+package vulkanRenderSystem
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+// identityCube returns a cube of the given size whose entries are their own
+// coordinates in the domain
+func identityCube(size int, min, max float32) *cubeFile {
+	c := &cubeFile{
+		size:      size,
+		domainMin: [3]float32{min, min, min},
+		domainMax: [3]float32{max, max, max},
+	}
+	for b := 0; b < size; b++ {
+		for g := 0; g < size; g++ {
+			for r := 0; r < size; r++ {
+				var color [3]float32
+				for i, v := range [3]int{r, g, b} {
+					color[i] = min + float32(v)/float32(size-1)*(max-min)
+				}
+				c.colors = append(c.colors, color)
+			}
+		}
+	}
+	return c
+}
+
+func closeTo(a, b [3]float32) bool {
+	for i := range a {
+		if math.Abs(float64(a[i]-b[i])) > 1e-5 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestParseCube(t *testing.T) {
+	cube, err := parseCube(strings.NewReader(`# made by hand
+TITLE "Warm Grade"
+LUT_3D_SIZE 2
+DOMAIN_MIN 0 0 0
+DOMAIN_MAX 1 2 1
+LUT_3D_INPUT_SCALE 1
+
+0 0 0
+1 0 0
+0 1 0
+1 1 0
+0 0 1
+1 0 1
+0 1 1
+1 1 1
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cube.title != "Warm Grade" || cube.size != 2 || len(cube.colors) != 8 {
+		t.Errorf("parsed %q of size %d with %d colors, want \"Warm Grade\" of size 2 with 8", cube.title, cube.size, len(cube.colors))
+	}
+	if cube.domainMax != [3]float32{1, 2, 1} {
+		t.Errorf("DOMAIN_MAX is %v, want [1 2 1]", cube.domainMax)
+	}
+	if cube.colors[3] != [3]float32{1, 1, 0} {
+		t.Errorf("the fourth color is %v, want [1 1 0]", cube.colors[3])
+	}
+
+	cube, err = parseCube(strings.NewReader("LUT_3D_SIZE 2\nLUT_3D_INPUT_RANGE -1 1\n" + strings.Repeat("0 0 0\n", 8)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cube.domainMin != [3]float32{-1, -1, -1} || cube.domainMax != [3]float32{1, 1, 1} {
+		t.Errorf("LUT_3D_INPUT_RANGE gave a domain of %v to %v, want -1 to 1", cube.domainMin, cube.domainMax)
+	}
+}
+
+func TestParseCubeErrors(t *testing.T) {
+	colors := strings.Repeat("0 0 0\n", 8)
+	for name, text := range map[string]string{
+		"no size":       colors,
+		"small size":    "LUT_3D_SIZE 1\n0 0 0\n",
+		"big size":      "LUT_3D_SIZE 257\n",
+		"1D":            "LUT_1D_SIZE 2\n0 0 0\n1 1 1\n",
+		"few colors":    "LUT_3D_SIZE 2\n" + colors[6:],
+		"many colors":   "LUT_3D_SIZE 2\n" + colors + "0 0 0\n",
+		"short color":   "LUT_3D_SIZE 2\n0 0\n" + colors[6:],
+		"bad number":    "LUT_3D_SIZE 2\n0 0 x\n" + colors[6:],
+		"empty domain":  "LUT_3D_SIZE 2\nDOMAIN_MIN 0 0 0\nDOMAIN_MAX 1 0 1\n" + colors,
+		"bad range":     "LUT_3D_SIZE 2\nLUT_3D_INPUT_RANGE 0\n" + colors,
+		"reverse range": "LUT_3D_SIZE 2\nLUT_3D_INPUT_RANGE 1 0\n" + colors,
+	} {
+		if _, err := parseCube(strings.NewReader(text)); err == nil {
+			t.Errorf("%s parsed without an error", name)
+		}
+	}
+}
+
+func TestCubeSample(t *testing.T) {
+	c := identityCube(3, 0, 1)
+	for _, test := range []struct {
+		p, want [3]float32
+	}{
+		{[3]float32{0, 0, 0}, [3]float32{0, 0, 0}},
+		{[3]float32{1, 2, 0}, [3]float32{0.5, 1, 0}},
+		// between entries the colors are interpolated
+		{[3]float32{0.5, 1.5, 1.25}, [3]float32{0.25, 0.75, 0.625}},
+		// the last entry is reached from the one before it
+		{[3]float32{2, 2, 2}, [3]float32{1, 1, 1}},
+	} {
+		if got := c.sample(test.p); !closeTo(got, test.want) {
+			t.Errorf("sample at %v is %v, want %v", test.p, got, test.want)
+		}
+	}
+}
+
+func TestCubeNormalized(t *testing.T) {
+	c := identityCube(3, 0, 1)
+	if colors := c.normalized(); &colors[0] != &c.colors[0] {
+		t.Error("a table with the default domain was resampled")
+	}
+	// the entries of a table over 0 to 2 are its inputs, so resampled to 0 to
+	// 1 they're their new inputs
+	c = identityCube(5, 0, 2)
+	colors := c.normalized()
+	if len(colors) != len(c.colors) {
+		t.Fatalf("got %d colors, want %d", len(colors), len(c.colors))
+	}
+	for i, color := range colors {
+		r, g, b := i%5, i/5%5, i/25
+		want := [3]float32{float32(r) / 4, float32(g) / 4, float32(b) / 4}
+		if !closeTo(color, want) {
+			t.Errorf("color %d is %v, want %v", i, color, want)
+		}
+	}
+	// inputs outside the domain are clamped to its edge
+	c = identityCube(3, 0.5, 1)
+	if got := c.normalized()[0]; !closeTo(got, [3]float32{0.5, 0.5, 0.5}) {
+		t.Errorf("the first color of a table over 0.5 to 1 is %v, want its first entry", got)
+	}
+}
+
+func TestHalfFloat(t *testing.T) {
+	for _, test := range []struct {
+		f    float32
+		want uint16
+	}{
+		{0, 0x0000},
+		{float32(math.Copysign(0, -1)), 0x8000},
+		{1, 0x3c00},
+		{-2, 0xc000},
+		{0.5, 0x3800},
+		{0.1, 0x2e66},
+		{1e-3, 0x1419},
+		{65504, 0x7bff},
+		// too big for a half
+		{1e6, 0x7c00},
+		{float32(math.Inf(-1)), 0xfc00},
+		{float32(math.NaN()), 0x7e00},
+		// the smallest normal, and subnormals below it
+		{1.0 / (1 << 14), 0x0400},
+		{1.0 / (1 << 15), 0x0200},
+		{1.0 / (1 << 24), 0x0001},
+		{3.0 / (1 << 25), 0x0002},
+		// too small even for a subnormal
+		{1.0 / (1 << 26), 0x0000},
+	} {
+		if got := halfFloat(test.f); got != test.want {
+			t.Errorf("halfFloat(%g) is %#04x, want %#04x", test.f, got, test.want)
+		}
+	}
+}
+
+func TestDestroyUnloadsLUT(t *testing.T) {
+	saved := theLUTLoader.luts
+	defer func() { theLUTLoader.luts = saved }()
+	l := &LUT{size: 2}
+	theLUTLoader.luts = map[string]LUTResource{"grade.cube": {l, "grade.cube"}}
+	// the LUT has no texture, so nothing has to be freed on the device
+	l.Destroy()
+	l.Destroy()
+	if len(theLUTLoader.luts) != 0 {
+		t.Error("the destroyed LUT is still loaded")
+	}
+}
+
+func TestGradeEffectNeedsLUTs(t *testing.T) {
+	loaded := &LUT{size: 2, texture: Texture{texWidth: 2}}
+	if _, err := NewGradeEffect(loaded); err != nil {
+		t.Fatal(err)
+	}
+	for name, luts := range map[string][2]*LUT{
+		"nil":        {nil, nil},
+		"nil second": {loaded, nil},
+		"destroyed":  {loaded, {size: 2}},
+	} {
+		if _, err := NewBlendedGradeEffect(luts[0], luts[1], 0.5); err == nil {
+			t.Errorf("a grade effect with a %s LUT was created without an error", name)
+		}
+	}
+}
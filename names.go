@@ -0,0 +1,66 @@
+package vulkanRenderSystem
+
+import (
+	"unsafe"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// The colors of the command buffer labels, so the kinds of pass are easy to
+// tell apart in capture tools
+var (
+	targetLabelColor = [4]float32{0.2, 0.6, 1, 1}
+	sceneLabelColor  = [4]float32{0.3, 0.8, 0.3, 1}
+	effectLabelColor = [4]float32{0.9, 0.5, 0.1, 1}
+	screenLabelColor = [4]float32{0.8, 0.2, 0.8, 1}
+	batchLabelColor  = [4]float32{0.6, 0.6, 0.6, 1}
+)
+
+// debugUtils returns whether VK_EXT_debug_utils is enabled, so objects can be
+// named and command buffers labeled.
+func (r *RenderSystem) debugUtils() bool {
+	return r.debug != nil && r.debug.utils
+}
+
+// setName names a vulkan object, so it's shown by name in the validation
+// layer's messages and in capture tools like RenderDoc. It does nothing
+// without VK_EXT_debug_utils.
+func (r *RenderSystem) setName(kind vk.ObjectType, handle unsafe.Pointer, name string) {
+	if !r.debugUtils() || handle == nil || name == "" {
+		return
+	}
+	vk.SetDebugUtilsObjectName(r.device, &vk.DebugUtilsObjectNameInfo{
+		SType:        vk.StructureTypeDebugUtilsObjectNameInfo,
+		ObjectType:   kind,
+		ObjectHandle: uint64(uintptr(handle)),
+		PObjectName:  safeString(name),
+	})
+}
+
+// nameTexture names the image, view and sampler of a texture.
+func (r *RenderSystem) nameTexture(tex *Texture, name string) {
+	r.setName(vk.ObjectTypeImage, unsafe.Pointer(tex.image), name)
+	r.setName(vk.ObjectTypeImageView, unsafe.Pointer(tex.view), name)
+	r.setName(vk.ObjectTypeSampler, unsafe.Pointer(tex.sampler), name)
+}
+
+// beginLabel opens a labeled group of commands in the command buffer, which
+// has to be closed with endLabel. It does nothing without VK_EXT_debug_utils.
+func (r *RenderSystem) beginLabel(buffer vk.CommandBuffer, name string, color [4]float32) {
+	if !r.debugUtils() {
+		return
+	}
+	vk.CmdBeginDebugUtilsLabel(buffer, &vk.DebugUtilsLabel{
+		SType:      vk.StructureTypeDebugUtilsLabel,
+		PLabelName: safeString(name),
+		Color:      color,
+	})
+}
+
+// endLabel closes the last group opened by beginLabel.
+func (r *RenderSystem) endLabel(buffer vk.CommandBuffer) {
+	if !r.debugUtils() {
+		return
+	}
+	vk.CmdEndDebugUtilsLabel(buffer)
+}
@@ -0,0 +1,373 @@
+package vulkanRenderSystem
+
+import (
+	"errors"
+	"image/color"
+	"math"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/EngoEngine/engo"
+)
+
+type tmxPoints struct {
+	Points string `xml:"points,attr"`
+}
+
+type tmxText struct {
+	FontFamily string `xml:"fontfamily,attr"`
+	PixelSize  *int   `xml:"pixelsize,attr"`
+	Wrap       int    `xml:"wrap,attr"`
+	Color      string `xml:"color,attr"`
+	Bold       int    `xml:"bold,attr"`
+	Italic     int    `xml:"italic,attr"`
+	Underline  int    `xml:"underline,attr"`
+	Strikeout  int    `xml:"strikeout,attr"`
+	HAlign     string `xml:"halign,attr"`
+	VAlign     string `xml:"valign,attr"`
+	Text       string `xml:",chardata"`
+}
+
+type tmxObject struct {
+	ID         int           `xml:"id,attr"`
+	Name       string        `xml:"name,attr"`
+	Type       string        `xml:"type,attr"`
+	Class      string        `xml:"class,attr"`
+	X          float32       `xml:"x,attr"`
+	Y          float32       `xml:"y,attr"`
+	Width      float32       `xml:"width,attr"`
+	Height     float32       `xml:"height,attr"`
+	Rotation   float32       `xml:"rotation,attr"`
+	GID        uint32        `xml:"gid,attr"`
+	Visible    *int          `xml:"visible,attr"`
+	Ellipse    *struct{}     `xml:"ellipse"`
+	Point      *struct{}     `xml:"point"`
+	Polygon    *tmxPoints    `xml:"polygon"`
+	Polyline   *tmxPoints    `xml:"polyline"`
+	Text       *tmxText      `xml:"text"`
+	Properties []tmxProperty `xml:"properties>property"`
+}
+
+// ObjectKind is the shape of an Object
+type ObjectKind int
+
+// The kinds of objects in an ObjectLayer
+const (
+	ObjectKindRectangle ObjectKind = iota
+	ObjectKindEllipse
+	ObjectKindPoint
+	ObjectKindPolygon
+	ObjectKindPolyline
+	ObjectKindTile
+	ObjectKindText
+)
+
+// ObjectLayer is a layer of objects in a Level. Only tile objects are drawn,
+// the other kinds are there to be used by the game.
+type ObjectLayer struct {
+	Name string
+	// Color is the color the objects are shown with in Tiled, or nil
+	Color color.Color
+	// Opacity is the alpha the tile objects are drawn with, from 0 to 1
+	Opacity float32
+	Visible bool
+	// OffsetX and OffsetY are the offset of the layer in pixels
+	OffsetX, OffsetY float32
+	// ParallaxX and ParallaxY are how fast the layer scrolls compared to the
+	// camera. 1 moves with the camera, 0 stays on screen.
+	ParallaxX, ParallaxY float32
+	Properties           map[string]string
+	Objects              []*Object
+	// drawOrder are the tile objects in the order they're drawn in
+	drawOrder []*Object
+}
+
+// Object is a single object of an ObjectLayer.
+type Object struct {
+	ID   int
+	Name string
+	// Type is the type, or class, of the object set in Tiled
+	Type string
+	Kind ObjectKind
+	// X and Y are the position of the object in the map's object space. For
+	// tile objects it's the bottom left corner, or the bottom center on
+	// isometric maps. Use Level.ObjectToWorld to get the world position.
+	X, Y          float32
+	Width, Height float32
+	// Rotation is the clockwise rotation around X and Y in degrees
+	Rotation float32
+	Visible  bool
+	// Points are the points of polygons and polylines, relative to X and Y
+	Points []engo.Point
+	// Tile is the tile of tile objects
+	Tile *Tile
+	// Text is the text of text objects
+	Text       *ObjectText
+	Properties map[string]string
+}
+
+// ObjectText is the text of a text object.
+type ObjectText struct {
+	Text       string
+	FontFamily string
+	PixelSize  int
+	Wrap       bool
+	Color      color.Color
+	Bold       bool
+	Italic     bool
+	Underline  bool
+	Strikeout  bool
+	// HAlign is "left", "center", "right" or "justify"
+	HAlign string
+	// VAlign is "top", "center" or "bottom"
+	VAlign string
+}
+
+// ImageLayer is a layer in a Level that draws a single image.
+type ImageLayer struct {
+	Name string
+	// Image is the url of the layer's image
+	Image string
+	// Opacity is the alpha the image is drawn with, from 0 to 1
+	Opacity float32
+	Visible bool
+	// OffsetX and OffsetY are the offset of the layer in pixels
+	OffsetX, OffsetY float32
+	// ParallaxX and ParallaxY are how fast the layer scrolls compared to the
+	// camera. 1 moves with the camera, 0 stays on screen.
+	ParallaxX, ParallaxY float32
+	Properties           map[string]string
+}
+
+// Object returns the first object with the given name, or nil if there
+// isn't one.
+func (l *ObjectLayer) Object(name string) *Object {
+	for _, o := range l.Objects {
+		if o.Name == name {
+			return o
+		}
+	}
+	return nil
+}
+
+// tmxColor parses the #AARRGGBB and #RRGGBB colors used by Tiled
+func tmxColor(s string) (color.Color, error) {
+	s = strings.TrimPrefix(s, "#")
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return nil, errors.New("invalid color " + s)
+	}
+	switch len(s) {
+	case 6:
+		return color.NRGBA{uint8(v >> 16), uint8(v >> 8), uint8(v), 0xff}, nil
+	case 8:
+		return color.NRGBA{uint8(v >> 16), uint8(v >> 8), uint8(v), uint8(v >> 24)}, nil
+	}
+	return nil, errors.New("invalid color " + s)
+}
+
+// tmxPointList parses a list of points such as "0,0 10,5 3,8"
+func tmxPointList(s string) ([]engo.Point, error) {
+	var points []engo.Point
+	for _, pair := range strings.Fields(s) {
+		xy := strings.Split(pair, ",")
+		if len(xy) != 2 {
+			return nil, errors.New("invalid point " + pair)
+		}
+		x, err := strconv.ParseFloat(xy[0], 32)
+		if err != nil {
+			return nil, err
+		}
+		y, err := strconv.ParseFloat(xy[1], 32)
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, engo.Point{X: float32(x), Y: float32(y)})
+	}
+	return points, nil
+}
+
+func (l *Level) newObjectLayer(t *tmxLayer) (*ObjectLayer, error) {
+	layer := &ObjectLayer{
+		Name:       t.Name,
+		Opacity:    t.opacity(),
+		Visible:    t.visible(),
+		OffsetX:    t.OffsetX,
+		OffsetY:    t.OffsetY,
+		Properties: tmxProperties(t.Properties),
+	}
+	layer.ParallaxX, layer.ParallaxY = t.parallax()
+	if t.Color != "" {
+		c, err := tmxColor(t.Color)
+		if err != nil {
+			return nil, errors.New("object layer " + t.Name + ": " + err.Error())
+		}
+		layer.Color = c
+	}
+	for i := range t.Objects {
+		o, err := l.newObject(&t.Objects[i])
+		if err != nil {
+			return nil, errors.New("object layer " + t.Name + ": " + err.Error())
+		}
+		layer.Objects = append(layer.Objects, o)
+		if o.Kind == ObjectKindTile {
+			layer.drawOrder = append(layer.drawOrder, o)
+		}
+	}
+	// objects are drawn in the order they were added unless the layer is set
+	// to draw them from the top down
+	if t.DrawOrder != "index" {
+		sort.SliceStable(layer.drawOrder, func(i, j int) bool {
+			return layer.drawOrder[i].Y < layer.drawOrder[j].Y
+		})
+	}
+	return layer, nil
+}
+
+func (l *Level) newObject(t *tmxObject) (*Object, error) {
+	o := &Object{
+		ID:         t.ID,
+		Name:       t.Name,
+		Type:       t.Type,
+		X:          t.X,
+		Y:          t.Y,
+		Width:      t.Width,
+		Height:     t.Height,
+		Rotation:   t.Rotation,
+		Visible:    t.Visible == nil || *t.Visible != 0,
+		Properties: tmxProperties(t.Properties),
+	}
+	if o.Type == "" {
+		o.Type = t.Class
+	}
+	var err error
+	switch {
+	case t.GID != 0:
+		o.Kind = ObjectKindTile
+		tile := l.tile(t.GID)
+		o.Tile = &tile
+	case t.Ellipse != nil:
+		o.Kind = ObjectKindEllipse
+	case t.Point != nil:
+		o.Kind = ObjectKindPoint
+	case t.Polygon != nil:
+		o.Kind = ObjectKindPolygon
+		o.Points, err = tmxPointList(t.Polygon.Points)
+	case t.Polyline != nil:
+		o.Kind = ObjectKindPolyline
+		o.Points, err = tmxPointList(t.Polyline.Points)
+	case t.Text != nil:
+		o.Kind = ObjectKindText
+		o.Text, err = newObjectText(t.Text)
+	}
+	if err != nil {
+		return nil, errors.New("object " + strconv.Itoa(t.ID) + ": " + err.Error())
+	}
+	return o, nil
+}
+
+func newObjectText(t *tmxText) (*ObjectText, error) {
+	text := &ObjectText{
+		Text:       t.Text,
+		FontFamily: t.FontFamily,
+		PixelSize:  16,
+		Wrap:       t.Wrap != 0,
+		Color:      color.Black,
+		Bold:       t.Bold != 0,
+		Italic:     t.Italic != 0,
+		Underline:  t.Underline != 0,
+		Strikeout:  t.Strikeout != 0,
+		HAlign:     t.HAlign,
+		VAlign:     t.VAlign,
+	}
+	if t.PixelSize != nil {
+		text.PixelSize = *t.PixelSize
+	}
+	if text.HAlign == "" {
+		text.HAlign = "left"
+	}
+	if text.VAlign == "" {
+		text.VAlign = "top"
+	}
+	if t.Color != "" {
+		c, err := tmxColor(t.Color)
+		if err != nil {
+			return nil, err
+		}
+		text.Color = c
+	}
+	return text, nil
+}
+
+func newImageLayer(t *tmxLayer, url string) (*ImageLayer, error) {
+	layer := &ImageLayer{
+		Name:       t.Name,
+		Opacity:    t.opacity(),
+		Visible:    t.visible(),
+		OffsetX:    t.OffsetX,
+		OffsetY:    t.OffsetY,
+		Properties: tmxProperties(t.Properties),
+	}
+	layer.ParallaxX, layer.ParallaxY = t.parallax()
+	if t.Image == nil || t.Image.Source == "" {
+		return layer, nil
+	}
+	layer.Image = path.Join(path.Dir(url), t.Image.Source)
+	if err := loadTilesetImage(layer.Image); err != nil {
+		return nil, err
+	}
+	return layer, nil
+}
+
+// appendObjectLayer adds the visible tile objects of the layer to the batch.
+func (l *Level) appendObjectLayer(b *geometryBatch, cam *Camera, layer *ObjectLayer) {
+	if !layer.Visible {
+		return
+	}
+	dx, dy := parallax(cam, layer.ParallaxX, layer.ParallaxY)
+	dx += layer.OffsetX
+	dy += layer.OffsetY
+	for _, o := range layer.drawOrder {
+		if !o.Visible || o.Tile.Empty() {
+			continue
+		}
+		url, uv, w, h := o.Tile.Tileset.region(o.Tile.Tileset.frame(o.Tile.ID, l.elapsed))
+		res, ok := theTextureLoader.images[url]
+		if !ok {
+			continue
+		}
+		// the object's size scales the tile
+		if o.Width != 0 && o.Height != 0 {
+			w, h = o.Width, o.Height
+		}
+		x, y := l.ObjectToWorld(o.X, o.Y)
+		x += float32(o.Tile.Tileset.OffsetX) + dx
+		y += float32(o.Tile.Tileset.OffsetY) + dy
+		left := float32(0)
+		if l.Orientation == OrientationIsometric {
+			left = -w / 2
+		}
+		corners := [4][2]float32{{left, -h}, {left + w, -h}, {left + w, 0}, {left, 0}}
+		sin, cos := math.Sincos(float64(o.Rotation) * math.Pi / 180)
+		for i, p := range corners {
+			corners[i][0] = x + p[0]*float32(cos) - p[1]*float32(sin)
+			corners[i][1] = y + p[0]*float32(sin) + p[1]*float32(cos)
+		}
+		b.addQuad(res.Texture, corners, tileUVs(o.Tile, uv), opacityVertex(layer.Opacity))
+	}
+}
+
+// appendTo adds the image of the layer to the batch.
+func (l *ImageLayer) appendTo(b *geometryBatch, cam *Camera) {
+	if !l.Visible || l.Image == "" {
+		return
+	}
+	res, ok := theTextureLoader.images[l.Image]
+	if !ok {
+		return
+	}
+	x, y := parallax(cam, l.ParallaxX, l.ParallaxY)
+	b.addSprite(res.Texture, x+l.OffsetX, y+l.OffsetY, 0, 1, 1, opacityVertex(l.Opacity))
+}
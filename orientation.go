@@ -0,0 +1,384 @@
+package vulkanRenderSystem
+
+import (
+	"errors"
+	"math"
+)
+
+// The map orientations supported by Level
+const (
+	OrientationOrthogonal = "orthogonal"
+	OrientationIsometric  = "isometric"
+	OrientationStaggered  = "staggered"
+	OrientationHexagonal  = "hexagonal"
+)
+
+// staggerParams are the measurements used to place tiles on staggered and
+// hexagonal maps. Staggered maps are hexagonal maps with no side length.
+type staggerParams struct {
+	staggerX, staggerEven    bool
+	tileWidth, tileHeight    int
+	sideLengthX, sideLengthY int
+	sideOffsetX, sideOffsetY int
+	columnWidth, rowHeight   int
+}
+
+func (l *Level) stagger() staggerParams {
+	p := staggerParams{
+		staggerX:    l.StaggerAxis == "x",
+		staggerEven: l.StaggerIndex == "even",
+		// odd tile sizes don't line up, so they're rounded down
+		tileWidth:  l.TileWidth &^ 1,
+		tileHeight: l.TileHeight &^ 1,
+	}
+	if l.Orientation == OrientationHexagonal {
+		if p.staggerX {
+			p.sideLengthX = l.HexSideLength
+		} else {
+			p.sideLengthY = l.HexSideLength
+		}
+	}
+	p.sideOffsetX = (p.tileWidth - p.sideLengthX) / 2
+	p.sideOffsetY = (p.tileHeight - p.sideLengthY) / 2
+	p.columnWidth = p.sideOffsetX + p.sideLengthX
+	p.rowHeight = p.sideOffsetY + p.sideLengthY
+	return p
+}
+
+// staggered reports whether the row or column at index i is pushed out
+// along the stagger axis.
+func (p *staggerParams) staggered(i int) bool {
+	return (i&1 != 0) != p.staggerEven
+}
+
+func validateOrientation(l *Level) error {
+	switch l.Orientation {
+	case OrientationOrthogonal, OrientationIsometric:
+		return nil
+	case OrientationStaggered, OrientationHexagonal:
+		if l.StaggerAxis != "x" && l.StaggerAxis != "y" {
+			return errors.New("invalid stagger axis " + l.StaggerAxis)
+		}
+		if l.StaggerIndex != "odd" && l.StaggerIndex != "even" {
+			return errors.New("invalid stagger index " + l.StaggerIndex)
+		}
+		return nil
+	}
+	return errors.New("unsupported orientation " + l.Orientation)
+}
+
+// TileToWorld returns the top left corner of the bounding box of the tile at
+// the given tile coordinates.
+func (l *Level) TileToWorld(tx, ty int) (float32, float32) {
+	switch l.Orientation {
+	case OrientationIsometric:
+		// the map is moved right so the left most tile starts at zero
+		tw, th := float32(l.TileWidth), float32(l.TileHeight)
+		originX := float32(l.Height) * tw / 2
+		return float32(tx-ty)*tw/2 + originX - tw/2, float32(tx+ty) * th / 2
+	case OrientationStaggered, OrientationHexagonal:
+		p := l.stagger()
+		var x, y int
+		if p.staggerX {
+			y = ty * (p.tileHeight + p.sideLengthY)
+			if p.staggered(tx) {
+				y += p.rowHeight
+			}
+			x = tx * p.columnWidth
+		} else {
+			x = tx * (p.tileWidth + p.sideLengthX)
+			if p.staggered(ty) {
+				x += p.columnWidth
+			}
+			y = ty * p.rowHeight
+		}
+		return float32(x), float32(y)
+	}
+	return float32(tx * l.TileWidth), float32(ty * l.TileHeight)
+}
+
+// TileCenter returns the center of the tile at the given tile coordinates.
+func (l *Level) TileCenter(tx, ty int) (float32, float32) {
+	x, y := l.TileToWorld(tx, ty)
+	return x + float32(l.TileWidth)/2, y + float32(l.TileHeight)/2
+}
+
+// WorldToTile returns the coordinates of the tile that contains the point.
+// The coordinates can be outside of the map.
+func (l *Level) WorldToTile(x, y float32) (int, int) {
+	switch l.Orientation {
+	case OrientationIsometric:
+		x -= float32(l.Height * l.TileWidth / 2)
+		ty := y / float32(l.TileHeight)
+		tx := x / float32(l.TileWidth)
+		return floor(ty + tx), floor(ty - tx)
+	case OrientationStaggered:
+		return l.worldToStaggered(x, y)
+	case OrientationHexagonal:
+		return l.worldToHexagonal(x, y)
+	}
+	return floor(x / float32(l.TileWidth)), floor(y / float32(l.TileHeight))
+}
+
+// worldToStaggered finds the cell of the grid the point is in, then moves to
+// the neighbouring tile if the point is outside of the diamond in its center.
+func (l *Level) worldToStaggered(x, y float32) (int, int) {
+	p := l.stagger()
+	if p.staggerEven {
+		if p.staggerX {
+			x -= float32(p.sideOffsetX)
+		} else {
+			y -= float32(p.sideOffsetY)
+		}
+	}
+	tw, th := float32(p.tileWidth), float32(p.tileHeight)
+	tx, ty := floor(x/tw), floor(y/th)
+	relX, relY := x-float32(tx)*tw, y-float32(ty)*th
+	if p.staggerX {
+		tx *= 2
+		if p.staggerEven {
+			tx++
+		}
+	} else {
+		ty *= 2
+		if p.staggerEven {
+			ty++
+		}
+	}
+
+	yPos := relX * (th / tw)
+	offY := float32(p.sideOffsetY)
+	switch {
+	case offY-yPos > relY:
+		return p.topLeft(tx, ty)
+	case -offY+yPos > relY:
+		return p.topRight(tx, ty)
+	case offY+yPos < relY:
+		return p.bottomLeft(tx, ty)
+	case offY*3-yPos < relY:
+		return p.bottomRight(tx, ty)
+	}
+	return tx, ty
+}
+
+// worldToHexagonal finds the block of the grid the point is in, then picks
+// the tile whose center is closest to the point.
+func (l *Level) worldToHexagonal(x, y float32) (int, int) {
+	p := l.stagger()
+	if p.staggerX {
+		if p.staggerEven {
+			x -= float32(p.tileWidth)
+		} else {
+			x -= float32(p.sideOffsetX)
+		}
+	} else {
+		if p.staggerEven {
+			y -= float32(p.tileHeight)
+		} else {
+			y -= float32(p.sideOffsetY)
+		}
+	}
+
+	cw, rh := float32(p.columnWidth), float32(p.rowHeight)
+	tx, ty := floor(x/(cw*2)), floor(y/(rh*2))
+	relX, relY := x-float32(tx)*cw*2, y-float32(ty)*rh*2
+	if p.staggerX {
+		tx *= 2
+		if p.staggerEven {
+			tx++
+		}
+	} else {
+		ty *= 2
+		if p.staggerEven {
+			ty++
+		}
+	}
+
+	var centers [4][2]float32
+	var offsets [4][2]int
+	if p.staggerX {
+		left := float32(p.sideLengthX) / 2
+		centerX := left + cw
+		centerY := float32(p.tileHeight) / 2
+		centers = [4][2]float32{
+			{left, centerY},
+			{centerX, centerY - rh},
+			{centerX, centerY + rh},
+			{centerX + cw, centerY},
+		}
+		offsets = [4][2]int{{0, 0}, {1, -1}, {1, 0}, {2, 0}}
+	} else {
+		top := float32(p.sideLengthY) / 2
+		centerX := float32(p.tileWidth) / 2
+		centerY := top + rh
+		centers = [4][2]float32{
+			{centerX, top},
+			{centerX - cw, centerY},
+			{centerX + cw, centerY},
+			{centerX, centerY + rh},
+		}
+		offsets = [4][2]int{{0, 0}, {-1, 1}, {0, 1}, {0, 2}}
+	}
+
+	nearest := 0
+	minDist := float32(math.MaxFloat32)
+	for i, c := range centers {
+		dx, dy := c[0]-relX, c[1]-relY
+		if d := dx*dx + dy*dy; d < minDist {
+			minDist = d
+			nearest = i
+		}
+	}
+	return tx + offsets[nearest][0], ty + offsets[nearest][1]
+}
+
+func (p *staggerParams) topLeft(x, y int) (int, int) {
+	if p.staggerX {
+		if p.staggered(x) {
+			return x - 1, y
+		}
+		return x - 1, y - 1
+	}
+	if p.staggered(y) {
+		return x, y - 1
+	}
+	return x - 1, y - 1
+}
+
+func (p *staggerParams) topRight(x, y int) (int, int) {
+	if p.staggerX {
+		if p.staggered(x) {
+			return x + 1, y
+		}
+		return x + 1, y - 1
+	}
+	if p.staggered(y) {
+		return x + 1, y - 1
+	}
+	return x, y - 1
+}
+
+func (p *staggerParams) bottomLeft(x, y int) (int, int) {
+	if p.staggerX {
+		if p.staggered(x) {
+			return x - 1, y + 1
+		}
+		return x - 1, y
+	}
+	if p.staggered(y) {
+		return x, y + 1
+	}
+	return x - 1, y + 1
+}
+
+func (p *staggerParams) bottomRight(x, y int) (int, int) {
+	if p.staggerX {
+		if p.staggered(x) {
+			return x + 1, y + 1
+		}
+		return x + 1, y
+	}
+	if p.staggered(y) {
+		return x + 1, y + 1
+	}
+	return x, y + 1
+}
+
+// ObjectToWorld converts the position of an object in an object layer to the
+// world. Isometric maps place objects in tile height units along the axes of
+// the map, the other orientations already use world positions.
+func (l *Level) ObjectToWorld(x, y float32) (float32, float32) {
+	if l.Orientation != OrientationIsometric {
+		return x, y
+	}
+	tw, th := float32(l.TileWidth), float32(l.TileHeight)
+	tx, ty := x/th, y/th
+	originX := float32(l.Height) * tw / 2
+	return (tx-ty)*tw/2 + originX, (tx + ty) * th / 2
+}
+
+// PixelSize returns the size of the whole map in pixels.
+func (l *Level) PixelSize() (float32, float32) {
+	switch l.Orientation {
+	case OrientationIsometric:
+		side := l.Width + l.Height
+		return float32(side * l.TileWidth / 2), float32(side * l.TileHeight / 2)
+	case OrientationStaggered, OrientationHexagonal:
+		p := l.stagger()
+		var w, h int
+		if p.staggerX {
+			w = p.columnWidth*l.Width + p.sideOffsetX
+			h = l.Height * (p.tileHeight + p.sideLengthY)
+			if l.Width > 1 {
+				h += p.rowHeight
+			}
+		} else {
+			w = l.Width * (p.tileWidth + p.sideLengthX)
+			if l.Height > 1 {
+				w += p.columnWidth
+			}
+			h = p.rowHeight*l.Height + p.sideOffsetY
+		}
+		return float32(w), float32(h)
+	}
+	return float32(l.Width * l.TileWidth), float32(l.Height * l.TileHeight)
+}
+
+// tileOrder calls fn for a grid of tiles of the given size in the order they
+// have to be drawn in. Only orthogonal maps have a configurable render order,
+// the other orientations are drawn so tiles further down overlap the ones
+// above them.
+func (l *Level) tileOrder(width, height int, fn func(x, y int)) {
+	switch l.Orientation {
+	case OrientationIsometric:
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				fn(x, y)
+			}
+		}
+		return
+	case OrientationStaggered, OrientationHexagonal:
+		p := l.stagger()
+		for y := 0; y < height; y++ {
+			if !p.staggerX {
+				for x := 0; x < width; x++ {
+					fn(x, y)
+				}
+				continue
+			}
+			// staggered columns sit lower than the others in the same row
+			for x := 0; x < width; x++ {
+				if !p.staggered(x) {
+					fn(x, y)
+				}
+			}
+			for x := 0; x < width; x++ {
+				if p.staggered(x) {
+					fn(x, y)
+				}
+			}
+		}
+		return
+	}
+
+	xs, xe, xd := 0, width, 1
+	ys, ye, yd := 0, height, 1
+	switch l.RenderOrder {
+	case "right-up":
+		ys, ye, yd = height-1, -1, -1
+	case "left-down":
+		xs, xe, xd = width-1, -1, -1
+	case "left-up":
+		xs, xe, xd = width-1, -1, -1
+		ys, ye, yd = height-1, -1, -1
+	}
+	for y := ys; y != ye; y += yd {
+		for x := xs; x != xe; x += xd {
+			fn(x, y)
+		}
+	}
+}
+
+func floor(f float32) int {
+	return int(math.Floor(float64(f)))
+}
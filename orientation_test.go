@@ -0,0 +1,69 @@
+package vulkanRenderSystem
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestWorldToTileRoundTrip(t *testing.T) {
+	var levels []*Level
+	levels = append(levels,
+		&Level{Orientation: OrientationOrthogonal, TileWidth: 16, TileHeight: 24},
+		&Level{Orientation: OrientationIsometric, TileWidth: 64, TileHeight: 32},
+	)
+	for _, axis := range []string{"x", "y"} {
+		for _, index := range []string{"odd", "even"} {
+			levels = append(levels,
+				&Level{Orientation: OrientationStaggered, TileWidth: 64, TileHeight: 32, StaggerAxis: axis, StaggerIndex: index},
+				&Level{Orientation: OrientationHexagonal, TileWidth: 32, TileHeight: 28, HexSideLength: 14, StaggerAxis: axis, StaggerIndex: index},
+			)
+		}
+	}
+	for _, l := range levels {
+		l.Width, l.Height = 6, 6
+		name := l.Orientation
+		if l.StaggerAxis != "" {
+			name = fmt.Sprintf("%s %s %s", l.Orientation, l.StaggerAxis, l.StaggerIndex)
+		}
+		for ty := 0; ty < l.Height; ty++ {
+			for tx := 0; tx < l.Width; tx++ {
+				x, y := l.TileCenter(tx, ty)
+				if gx, gy := l.WorldToTile(x, y); gx != tx || gy != ty {
+					t.Errorf("%s: the center of tile %d,%d at %v,%v is in tile %d,%d", name, tx, ty, x, y, gx, gy)
+				}
+			}
+		}
+	}
+}
+
+func TestTileToWorld(t *testing.T) {
+	staggered := func(axis, index string) *Level {
+		return &Level{Orientation: OrientationStaggered, TileWidth: 64, TileHeight: 32, StaggerAxis: axis, StaggerIndex: index}
+	}
+	hexagonal := func(axis, index string) *Level {
+		return &Level{Orientation: OrientationHexagonal, TileWidth: 32, TileHeight: 28, HexSideLength: 14, StaggerAxis: axis, StaggerIndex: index}
+	}
+	for _, test := range []struct {
+		name   string
+		level  *Level
+		tx, ty int
+		x, y   float32
+	}{
+		{"isometric", &Level{Orientation: OrientationIsometric, TileWidth: 64, TileHeight: 32, Height: 4}, 1, 2, 64, 48},
+		// odd rows are pushed right by half a tile
+		{"staggered y odd", staggered("y", "odd"), 1, 1, 96, 16},
+		{"staggered y even", staggered("y", "even"), 1, 0, 96, 0},
+		{"staggered y even", staggered("y", "even"), 0, 1, 0, 16},
+		// odd columns are pushed down by half a tile
+		{"staggered x odd", staggered("x", "odd"), 1, 1, 32, 48},
+		{"staggered x even", staggered("x", "even"), 0, 1, 0, 48},
+		{"hexagonal y odd", hexagonal("y", "odd"), 0, 1, 16, 21},
+		{"hexagonal y even", hexagonal("y", "even"), 0, 2, 16, 42},
+		{"hexagonal x odd", hexagonal("x", "odd"), 1, 0, 23, 14},
+		{"hexagonal x even", hexagonal("x", "even"), 2, 1, 46, 42},
+	} {
+		if x, y := test.level.TileToWorld(test.tx, test.ty); x != test.x || y != test.y {
+			t.Errorf("%s: tile %d,%d is at %v,%v, want %v,%v", test.name, test.tx, test.ty, x, y, test.x, test.y)
+		}
+	}
+}
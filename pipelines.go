@@ -0,0 +1,129 @@
+package vulkanRenderSystem
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// passSamples is the number of samples the render passes draw with
+const passSamples = vk.SampleCount1Bit
+
+// renderState is everything a pipeline is built for
+type renderState struct {
+	shader   *Shader
+	topology vk.PrimitiveTopology
+	// blend is whether the pipeline blends with what's drawn, by alpha
+	blend bool
+	// format and samples are what the render pass draws into
+	format  vk.Format
+	samples vk.SampleCountFlagBits
+	// depth is whether the render pass has a depth attachment, which is
+	// tested and written
+	depth bool
+}
+
+// hash returns the key of the state in the pipeline cache
+func (s renderState) hash() uint64 {
+	blend, depth := uint32(0), uint32(0)
+	if s.blend {
+		blend = 1
+	}
+	if s.depth {
+		depth = 1
+	}
+	h := fnv.New64a()
+	binary.Write(h, binary.LittleEndian, []uint32{
+		uint32(s.shader.id), uint32(s.shader.id >> 32),
+		uint32(s.topology), blend,
+		uint32(s.format), uint32(s.samples), depth,
+	})
+	return h.Sum64()
+}
+
+// cachedPipeline is a pipeline with the state it was built for
+type cachedPipeline struct {
+	state    renderState
+	pipeline vk.Pipeline
+}
+
+// drawState is the render state entities are drawn to the screen with
+func (r *RenderSystem) drawState(s *Shader) renderState {
+	if s == nil {
+		s = r.defaultShader
+	}
+	return renderState{
+		shader:   s,
+		topology: vk.PrimitiveTopologyTriangleList,
+		blend:    true,
+		format:   r.swapChainImageFormat,
+		samples:  passSamples,
+	}
+}
+
+// pipeline returns the pipeline for the render state, creating it the first
+// time it's used. If a reloaded shader fails to build it, the error is logged
+// and there's no pipeline, so the draws are skipped until the shader's
+// previous code is put back.
+func (r *RenderSystem) pipeline(state renderState) (vk.Pipeline, error) {
+	h := state.hash()
+	for _, c := range r.pipelines[h] {
+		if c.state == state {
+			return c.pipeline, nil
+		}
+	}
+	p, err := r.createPipeline(state)
+	if err != nil && state.shader.previous != nil {
+		r.failedReload(state.shader, err)
+		return vk.NullPipeline, nil
+	}
+	if err != nil {
+		return p, err
+	}
+	r.pipelines[h] = append(r.pipelines[h], cachedPipeline{state, p})
+	return p, nil
+}
+
+// compatiblePass returns a render pass pipelines of the state can be created
+// for. Pipelines can be used in any render pass with the same formats and
+// sample counts, so the format, depth attachment and samples pick it.
+func (r *RenderSystem) compatiblePass(state renderState) (vk.RenderPass, error) {
+	if state.samples != passSamples {
+		return vk.NullRenderPass, fmt.Errorf("no render pass draws with %d samples", state.samples)
+	}
+	switch {
+	case state.depth:
+		return r.targetPasses[1], nil
+	case state.format == r.swapChainImageFormat:
+		return r.renderPass, nil
+	default:
+		return r.targetPasses[0], nil
+	}
+}
+
+// invalidatePipelines destroys the cached pipelines whose state matches. They
+// are created again when they're next used.
+func (r *RenderSystem) invalidatePipelines(match func(renderState) bool) {
+	for h, bucket := range r.pipelines {
+		kept := bucket[:0]
+		for _, c := range bucket {
+			if match(c.state) {
+				vk.DestroyPipeline(r.device, c.pipeline, nil)
+			} else {
+				kept = append(kept, c)
+			}
+		}
+		if len(kept) == 0 {
+			delete(r.pipelines, h)
+		} else {
+			r.pipelines[h] = kept
+		}
+	}
+}
+
+// destroyPipelines destroys all the pipelines that were created.
+func (r *RenderSystem) destroyPipelines() {
+	r.invalidatePipelines(func(renderState) bool { return true })
+}
@@ -0,0 +1,390 @@
+package vulkanRenderSystem
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/EngoEngine/engo"
+
+	"github.com/Noofbiz/vulkanRenderSystem/internal/shaders"
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// Effect is a full screen pass over the drawn frame, like a blur or a color
+// grade. Effects run in the order they're added to the RenderSystem, each on
+// the output of the one before, and the output of the last is what's shown.
+type Effect struct {
+	// Disabled skips the effect
+	Disabled bool
+	// Params are passed to the shaders of the effect. Only the first 28,
+	// maxEffectParams, fit in the push constants and are passed.
+	Params []float32
+	// LUTs are the color lookup tables the shaders of the effect can sample
+	LUTs [2]*LUT
+
+	passes []effectPass
+}
+
+// effectPass is one full screen draw of an effect
+type effectPass struct {
+	shader *Shader
+	// scale is the size of the image the pass draws into, relative to the
+	// screen
+	scale float32
+	// sets bind the images the pass samples, per swap chain image
+	sets []vk.DescriptorSet
+}
+
+// NewEffect creates an effect from SPIR-V fragment shaders, one per pass, that
+// are run one after another. Each pass draws a triangle that covers the
+// screen, and its fragment shader can use
+//
+//	layout(location = 0) in vec2 uv;
+//	layout(binding = 0) uniform sampler2D source;      // the output of the last pass
+//	layout(binding = 1) uniform sampler2D effectInput; // what the effect started from
+//	layout(binding = 2) uniform sampler3D lutA;        // LUTs[0]
+//	layout(binding = 3) uniform sampler3D lutB;        // LUTs[1]
+//	layout(push_constant) uniform Constants {
+//		vec2 resolution; // the size in pixels of what the pass draws
+//		float time;      // seconds since the RenderSystem started
+//		float pass;      // the index of the pass in the effect
+//		vec4 params[7];  // the Params of the effect
+//	};
+func NewEffect(frags ...[]byte) (*Effect, error) {
+	if len(frags) == 0 {
+		return nil, errors.New("effects need at least one pass")
+	}
+	e := &Effect{}
+	for _, frag := range frags {
+		s, err := newEffectShader(frag)
+		if err != nil {
+			return nil, err
+		}
+		e.passes = append(e.passes, effectPass{shader: s, scale: 1})
+	}
+	return e, nil
+}
+
+// LoadEffect creates an effect from .spv fragment shaders loaded through
+// engo.Files, one per pass.
+func LoadEffect(urls ...string) (*Effect, error) {
+	frags := make([][]byte, len(urls))
+	for i, url := range urls {
+		res, err := engo.Files.Resource(url)
+		if err != nil {
+			return nil, err
+		}
+		frags[i] = res.(ShaderResource).Data
+	}
+	return NewEffect(frags...)
+}
+
+// newEffectShader creates the shader of an effect pass, which uses the full
+// screen vertex shader.
+func newEffectShader(frag []byte) (*Shader, error) {
+	vert, err := shaders.Asset("fullscreen.spv")
+	if err != nil {
+		return nil, err
+	}
+	iface, err := reflectEffect(vert, frag)
+	if err != nil {
+		return nil, err
+	}
+	return &Shader{
+		id:     atomic.AddUint64(&shaderIDs, 1),
+		vert:   vert,
+		frag:   frag,
+		iface:  iface,
+		effect: true,
+	}, nil
+}
+
+// AddEffect adds an effect to the end of the effects the frame is drawn
+// through.
+func (r *RenderSystem) AddEffect(e *Effect) {
+	r.post.effects = append(r.post.effects, e)
+}
+
+// RemoveEffect removes an effect from the RenderSystem.
+func (r *RenderSystem) RemoveEffect(e *Effect) {
+	for i, effect := range r.post.effects {
+		if effect == e {
+			r.post.effects = append(r.post.effects[:i], r.post.effects[i+1:]...)
+			vk.DeviceWaitIdle(r.device)
+			for j := range e.passes {
+				r.freeEffectSets(&e.passes[j])
+			}
+			return
+		}
+	}
+}
+
+// postImage is an image effect passes draw into and sample from
+type postImage struct {
+	texture     Texture
+	scale       float32
+	framebuffer vk.Framebuffer
+}
+
+func (img *postImage) extent() vk.Extent2D {
+	return vk.Extent2D{Width: uint32(img.texture.texWidth), Height: uint32(img.texture.texHeight)}
+}
+
+// postChain is the effects the frame is drawn through
+type postChain struct {
+	effects []*Effect
+	// images are what the scene and the passes are drawn into. They're made
+	// when they're first needed and reused every frame, so passes of the same
+	// size ping-pong between two images.
+	images []*postImage
+	// blit copies the output of the last effect to the screen
+	blit effectPass
+	// time is the number of seconds the RenderSystem has been updated for
+	time float32
+}
+
+// recordEffects records drawing the scene into an image and running the
+// enabled effects over it. It returns the output of the last effect, or nil if
+// there are no effects to run and the scene is drawn to the screen.
+func (r *RenderSystem) recordEffects(buffer vk.CommandBuffer, imageIdx uint32) (*Texture, error) {
+	active := false
+	for _, e := range r.post.effects {
+		active = active || !e.Disabled
+	}
+	if !active {
+		return nil, nil
+	}
+	pass, err := r.targetPass(false)
+	if err != nil {
+		return nil, err
+	}
+	src, err := r.postImage(1)
+	if err != nil {
+		return nil, err
+	}
+	r.beginPass(buffer, "scene", sceneLabelColor)
+	beginPostPass(buffer, pass, src)
+	err = r.recordDraws(buffer, imageIdx, &r.batch, r.batchVertexBuffers[imageIdx].buffer, r.batchIndexBuffers[imageIdx].buffer, nil, targetFormat)
+	vk.CmdEndRenderPass(buffer)
+	r.endPass(buffer)
+	if err != nil {
+		return nil, err
+	}
+	for ei, e := range r.post.effects {
+		if e.Disabled {
+			continue
+		}
+		input := src
+		for i := range e.passes {
+			p := &e.passes[i]
+			out, err := r.postImage(p.scale, src, input)
+			if err != nil {
+				return nil, err
+			}
+			r.beginPass(buffer, fmt.Sprintf("effect %d pass %d", ei, i), effectLabelColor)
+			beginPostPass(buffer, pass, out)
+			err = r.drawFullscreen(buffer, imageIdx, p, targetFormat, out.extent(), i, e.Params, &src.texture, &input.texture, lutTexture(e.LUTs[0]), lutTexture(e.LUTs[1]))
+			vk.CmdEndRenderPass(buffer)
+			r.endPass(buffer)
+			if err != nil {
+				return nil, err
+			}
+			src = out
+		}
+	}
+	return &src.texture, nil
+}
+
+// recordBlit records copying the output of the effects to the screen, in the
+// render pass of the swap chain image. It's drawn rather than blitted, so the
+// swap chain doesn't need to be a transfer destination and any format works.
+func (r *RenderSystem) recordBlit(buffer vk.CommandBuffer, imageIdx uint32, final *Texture) error {
+	if r.post.blit.shader == nil {
+		frag, err := shaders.Asset("blit.spv")
+		if err != nil {
+			return err
+		}
+		if r.post.blit.shader, err = newEffectShader(frag); err != nil {
+			return err
+		}
+	}
+	return r.drawFullscreen(buffer, imageIdx, &r.post.blit, r.swapChainImageFormat, r.swapChainExtent, 0, nil, final, final)
+}
+
+// beginPostPass begins the render pass that draws into the image.
+func beginPostPass(buffer vk.CommandBuffer, pass vk.RenderPass, img *postImage) {
+	renderPassInfo := vk.RenderPassBeginInfo{
+		SType:           vk.StructureTypeRenderPassBeginInfo,
+		RenderPass:      pass,
+		Framebuffer:     img.framebuffer,
+		ClearValueCount: 1,
+		PClearValues:    []vk.ClearValue{vk.NewClearValue([]float32{0, 0, 0, 1})},
+	}
+	renderPassInfo.RenderArea.Extent = img.extent()
+	vk.CmdBeginRenderPass(buffer, &renderPassInfo, vk.SubpassContentsInline)
+}
+
+// drawFullscreen records a full screen pass into a render pass that has begun.
+// The images are bound at the bindings of their index.
+func (r *RenderSystem) drawFullscreen(buffer vk.CommandBuffer, imageIdx uint32, p *effectPass, format vk.Format, extent vk.Extent2D, index int, params []float32, images ...*Texture) error {
+	pipeline, err := r.pipeline(renderState{
+		shader:   p.shader,
+		topology: vk.PrimitiveTopologyTriangleList,
+		format:   format,
+		samples:  passSamples,
+	})
+	if err != nil || pipeline == vk.NullPipeline {
+		return err
+	}
+	layout, err := r.layout(p.shader)
+	if err != nil {
+		return err
+	}
+	set, err := r.effectSet(p, imageIdx, layout, images)
+	if err != nil {
+		return err
+	}
+	setViewport(buffer, extent)
+	vk.CmdBindPipeline(buffer, vk.PipelineBindPointGraphics, pipeline)
+	vk.CmdBindDescriptorSets(buffer, vk.PipelineBindPointGraphics, layout.pipelineLayout, 0, 1, []vk.DescriptorSet{set}, 0, nil)
+	constants := effectConstants(extent, r.post.time, index, params)
+	vk.CmdPushConstants(buffer, layout.pipelineLayout, vk.ShaderStageFlags(vk.ShaderStageFragmentBit), 0, effectConstantsSize, unsafe.Pointer(&constants[0]))
+	vk.CmdDraw(buffer, 3, 1, 0, 0)
+	return nil
+}
+
+// effectSet returns the descriptor set of the pass for the swap chain image,
+// pointed at the images. The command buffer of the image has finished, so the
+// set can be rewritten every frame.
+func (r *RenderSystem) effectSet(p *effectPass, imageIdx uint32, layout *shaderLayout, images []*Texture) (vk.DescriptorSet, error) {
+	if p.sets == nil {
+		p.sets = make([]vk.DescriptorSet, len(r.images))
+	}
+	if p.sets[imageIdx] == nil {
+		if res := vk.AllocateDescriptorSets(r.device, &vk.DescriptorSetAllocateInfo{
+			SType:              vk.StructureTypeDescriptorSetAllocateInfo,
+			DescriptorPool:     r.descriptorPool,
+			DescriptorSetCount: 1,
+			PSetLayouts:        []vk.DescriptorSetLayout{layout.setLayout},
+		}, &p.sets[imageIdx]); res != vk.Success {
+			return nil, errors.New("unable to allocate effect descriptor set")
+		}
+	}
+	set := p.sets[imageIdx]
+	var writes []vk.WriteDescriptorSet
+	for _, b := range p.shader.iface.bindings {
+		tex := images[b.Binding]
+		if tex == nil {
+			return nil, fmt.Errorf("the effect samples binding %d, but has no LUT there", b.Binding)
+		}
+		writes = append(writes, vk.WriteDescriptorSet{
+			SType:           vk.StructureTypeWriteDescriptorSet,
+			DstSet:          set,
+			DstBinding:      b.Binding,
+			DescriptorType:  vk.DescriptorTypeCombinedImageSampler,
+			DescriptorCount: 1,
+			PImageInfo: []vk.DescriptorImageInfo{{
+				ImageLayout: vk.ImageLayoutShaderReadOnlyOptimal,
+				ImageView:   tex.view,
+				Sampler:     tex.sampler,
+			}},
+		})
+	}
+	if len(writes) > 0 {
+		vk.UpdateDescriptorSets(r.device, uint32(len(writes)), writes, 0, nil)
+	}
+	return set, nil
+}
+
+// lutTexture returns the texture of the LUT, or nil if there's no LUT.
+func lutTexture(l *LUT) *Texture {
+	if l == nil {
+		return nil
+	}
+	return &l.texture
+}
+
+// freeEffectSets frees the descriptor sets of a pass that's no longer drawn.
+func (r *RenderSystem) freeEffectSets(p *effectPass) {
+	for _, set := range p.sets {
+		if set != nil {
+			vk.FreeDescriptorSets(r.device, r.descriptorPool, 1, []vk.DescriptorSet{set})
+		}
+	}
+	p.sets = nil
+}
+
+// effectConstants returns the push constants of an effect pass.
+func effectConstants(extent vk.Extent2D, time float32, pass int, params []float32) []byte {
+	values := make([]float32, effectConstantsSize/4)
+	values[0], values[1] = float32(extent.Width), float32(extent.Height)
+	values[2], values[3] = time, float32(pass)
+	if len(params) > maxEffectParams {
+		params = params[:maxEffectParams]
+	}
+	copy(values[4:], params)
+	data := make([]byte, effectConstantsSize)
+	for i, v := range values {
+		binary.LittleEndian.PutUint32(data[i*4:], math.Float32bits(v))
+	}
+	return data
+}
+
+// postImage returns an image of the scale that isn't one of the busy ones,
+// creating it if there isn't one.
+func (r *RenderSystem) postImage(scale float32, busy ...*postImage) (*postImage, error) {
+next:
+	for _, img := range r.post.images {
+		if img.scale != scale {
+			continue
+		}
+		for _, b := range busy {
+			if b == img {
+				continue next
+			}
+		}
+		return img, nil
+	}
+	pass, err := r.targetPass(false)
+	if err != nil {
+		return nil, err
+	}
+	img := &postImage{scale: scale}
+	extent := vk.Extent2D{
+		Width:  uint32(math.Max(1, float64(float32(r.swapChainExtent.Width)*scale))),
+		Height: uint32(math.Max(1, float64(float32(r.swapChainExtent.Height)*scale))),
+	}
+	if err := r.createColorImage(&img.texture, extent); err != nil {
+		img.texture.Destroy(r.device)
+		return nil, err
+	}
+	if res := vk.CreateFramebuffer(r.device, &vk.FramebufferCreateInfo{
+		SType:           vk.StructureTypeFramebufferCreateInfo,
+		RenderPass:      pass,
+		AttachmentCount: 1,
+		PAttachments:    []vk.ImageView{img.texture.view},
+		Width:           extent.Width,
+		Height:          extent.Height,
+		Layers:          1,
+	}, nil, &img.framebuffer); res != vk.Success {
+		img.texture.Destroy(r.device)
+		return nil, errors.New("failed to create effect framebuffer")
+	}
+	r.nameTexture(&img.texture, fmt.Sprintf("effect image %d", len(r.post.images)))
+	r.post.images = append(r.post.images, img)
+	return img, nil
+}
+
+// destroyPostImages destroys the images of the effects. They're made again at
+// the size of the swap chain when they're next used.
+func (r *RenderSystem) destroyPostImages() {
+	for _, img := range r.post.images {
+		vk.DestroyFramebuffer(r.device, img.framebuffer, nil)
+		img.texture.Destroy(r.device)
+	}
+	r.post.images = nil
+}
@@ -0,0 +1,228 @@
+package vulkanRenderSystem
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+	"unsafe"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// profileFrames is the number of recent frames Profile keeps
+const profileFrames = 120
+
+// maxTimestamps is the number of timestamps a frame can write, two per pass.
+// Passes past it are left out of the profile.
+const maxTimestamps = 128
+
+// PassTiming is how long a pass of a frame took on the GPU
+type PassTiming struct {
+	// Name is the name of the pass, the same as its label in capture tools
+	Name string
+	GPU  time.Duration
+}
+
+// FrameProfile is how long a frame took to draw
+type FrameProfile struct {
+	// Frame is the number of the frame, counting from the first one drawn
+	Frame uint64
+	// Record is the CPU time spent recording the command buffer of the
+	// frame, and Submit the time spent submitting it
+	Record, Submit time.Duration
+	// GPU is the time from the start of the first pass to the end of the
+	// last. It and Passes are zero when the device can't write timestamps.
+	GPU    time.Duration
+	Passes []PassTiming
+}
+
+// profiler times the frames that are drawn
+type profiler struct {
+	// pools hold the timestamps of each frame in flight. They're nil when
+	// the graphics queue can't write timestamps.
+	pools []vk.QueryPool
+	// period is the number of nanoseconds a timestamp tick lasts, and mask
+	// the bits of the timestamps that are valid
+	period float64
+	mask   uint64
+	// frames are what's been written by each frame in flight, and current
+	// the one that's being recorded
+	frames  []profiledFrame
+	current *profiledFrame
+	count   uint64
+
+	lock sync.Mutex
+	// history is a ring of the last frames, and next is where the next one
+	// goes
+	history [profileFrames]FrameProfile
+	next    int
+	full    bool
+}
+
+// profiledFrame is a frame that's been submitted, waiting for its timestamps
+type profiledFrame struct {
+	frame          uint64
+	record, submit time.Duration
+	// passes are the names of the passes, whose timestamps are at twice
+	// their index, and queries is the number of timestamps written
+	passes  []string
+	queries uint32
+	open    bool
+	pending bool
+}
+
+// Profile returns how long the recent frames took to draw, oldest first.
+// Frames are added once the GPU has finished them, so the last few frames
+// drawn aren't in it yet.
+func (r *RenderSystem) Profile() []FrameProfile {
+	p := &r.profiler
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	var frames []FrameProfile
+	if p.full {
+		frames = append(frames, p.history[p.next:]...)
+	}
+	frames = append(frames, p.history[:p.next]...)
+	for i := range frames {
+		frames[i].Passes = append([]PassTiming(nil), frames[i].Passes...)
+	}
+	return frames
+}
+
+// createProfiler creates the timestamp query pools of the frames in flight,
+// if the graphics queue can write timestamps.
+func (r *RenderSystem) createProfiler() error {
+	p := &r.profiler
+	p.frames = make([]profiledFrame, maxFramesInFlight)
+	var count uint32
+	vk.GetPhysicalDeviceQueueFamilyProperties(r.gpu, &count, nil)
+	families := make([]vk.QueueFamilyProperties, count)
+	vk.GetPhysicalDeviceQueueFamilyProperties(r.gpu, &count, families)
+	if r.graphicsIdx >= count {
+		return errors.New("unable to get the graphics queue family")
+	}
+	families[r.graphicsIdx].Deref()
+	bits := families[r.graphicsIdx].TimestampValidBits
+	if bits == 0 {
+		log.Println("[VULKAN RENDER SYSTEM]: the graphics queue can't write timestamps, so frames won't be timed on the GPU")
+		return nil
+	}
+	var props vk.PhysicalDeviceProperties
+	vk.GetPhysicalDeviceProperties(r.gpu, &props)
+	props.Deref()
+	props.Limits.Deref()
+	p.period = float64(props.Limits.TimestampPeriod)
+	p.mask = ^uint64(0)
+	if bits < 64 {
+		p.mask = 1<<bits - 1
+	}
+	p.pools = make([]vk.QueryPool, maxFramesInFlight)
+	for i := range p.pools {
+		if res := vk.CreateQueryPool(r.device, &vk.QueryPoolCreateInfo{
+			SType:      vk.StructureTypeQueryPoolCreateInfo,
+			QueryType:  vk.QueryTypeTimestamp,
+			QueryCount: maxTimestamps,
+		}, nil, &p.pools[i]); res != vk.Success {
+			return errors.New("failed to create timestamp query pool")
+		}
+		r.setName(vk.ObjectTypeQueryPool, unsafe.Pointer(p.pools[i]), fmt.Sprintf("timestamps %d", i))
+	}
+	return nil
+}
+
+// destroyProfiler destroys the query pools.
+func (r *RenderSystem) destroyProfiler() {
+	for _, pool := range r.profiler.pools {
+		vk.DestroyQueryPool(r.device, pool, nil)
+	}
+	r.profiler.pools = nil
+}
+
+// beginProfile starts timing the frame in flight, whose command buffer is
+// being recorded into buffer. It has to be called outside a render pass.
+func (r *RenderSystem) beginProfile(buffer vk.CommandBuffer) {
+	p := &r.profiler
+	f := &p.frames[r.currentFrame]
+	*f = profiledFrame{passes: f.passes[:0]}
+	p.current = f
+	if p.pools != nil {
+		vk.CmdResetQueryPool(buffer, p.pools[r.currentFrame], 0, maxTimestamps)
+	}
+}
+
+// beginPass labels the commands of a pass until endPass and writes a
+// timestamp at its start.
+func (r *RenderSystem) beginPass(buffer vk.CommandBuffer, name string, color [4]float32) {
+	r.beginLabel(buffer, name, color)
+	p := &r.profiler
+	f := p.current
+	if p.pools == nil || f == nil || f.queries+2 > maxTimestamps {
+		return
+	}
+	vk.CmdWriteTimestamp(buffer, vk.PipelineStageTopOfPipeBit, p.pools[r.currentFrame], f.queries)
+	f.passes = append(f.passes, name)
+	f.queries++
+	f.open = true
+}
+
+// endPass ends the label of the pass begun last and writes a timestamp once
+// its commands have finished.
+func (r *RenderSystem) endPass(buffer vk.CommandBuffer) {
+	p := &r.profiler
+	if f := p.current; f != nil && f.open {
+		vk.CmdWriteTimestamp(buffer, vk.PipelineStageBottomOfPipeBit, p.pools[r.currentFrame], f.queries)
+		f.queries++
+		f.open = false
+	}
+	r.endLabel(buffer)
+}
+
+// finishProfile keeps the CPU times of the frame in flight once it's
+// submitted. Its timestamps are read once it's finished.
+func (r *RenderSystem) finishProfile(record, submit time.Duration) {
+	p := &r.profiler
+	f := &p.frames[r.currentFrame]
+	f.frame, f.record, f.submit, f.pending = p.count, record, submit, true
+	p.count++
+	p.current = nil
+}
+
+// collectProfile adds the frame in flight, which has finished, to the
+// history.
+func (r *RenderSystem) collectProfile() {
+	p := &r.profiler
+	f := &p.frames[r.currentFrame]
+	if !f.pending {
+		return
+	}
+	f.pending = false
+	profile := FrameProfile{Frame: f.frame, Record: f.record, Submit: f.submit}
+	if f.queries > 0 {
+		stamps := make([]uint64, f.queries)
+		if res := vk.GetQueryPoolResults(r.device, p.pools[r.currentFrame], 0, f.queries, uint(8*f.queries), unsafe.Pointer(&stamps[0]), 8,
+			vk.QueryResultFlags(vk.QueryResult64Bit|vk.QueryResultWaitBit)); res != vk.Success {
+			log.Println("[VULKAN RENDER SYSTEM]: unable to read the timestamps of a frame")
+		} else {
+			profile.GPU = p.elapsed(stamps[0], stamps[len(stamps)-1])
+			profile.Passes = make([]PassTiming, len(f.passes))
+			for i, name := range f.passes {
+				profile.Passes[i] = PassTiming{Name: name, GPU: p.elapsed(stamps[2*i], stamps[2*i+1])}
+			}
+		}
+	}
+	p.lock.Lock()
+	p.history[p.next] = profile
+	p.next++
+	if p.next == profileFrames {
+		p.next, p.full = 0, true
+	}
+	p.lock.Unlock()
+}
+
+// elapsed returns the time between two timestamps. Only the valid bits are
+// compared, so the time is right when the counter wraps between them.
+func (p *profiler) elapsed(start, end uint64) time.Duration {
+	return time.Duration(float64((end-start)&p.mask) * p.period)
+}
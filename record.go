@@ -0,0 +1,342 @@
+package vulkanRenderSystem
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"image/png"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"unsafe"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// recordingQueue is the number of read back frames that can wait to be
+// encoded. Frames past it are dropped rather than stalling the render loop.
+const recordingQueue = 32
+
+// RecordingFormat is what a recording is written as
+type RecordingFormat int
+
+const (
+	// RecordPNG writes every frame to a numbered .png file in a directory
+	RecordPNG RecordingFormat = iota
+	// RecordGIF writes the frames to an animated .gif, each quantized to its
+	// own palette. The quantized frames are kept in memory until
+	// StopRecording writes the file, a byte a pixel each, so long recordings
+	// are better made as .png files.
+	RecordGIF
+)
+
+// RecordingOptions are how the frames are recorded
+type RecordingOptions struct {
+	// Path is the directory the .png files are written to, or the .gif file
+	Path   string
+	Format RecordingFormat
+	// FPS is how many frames are recorded a second, 30 by default. Only drawn
+	// frames are recorded, so it's at most the frame rate.
+	FPS float32
+}
+
+// recording is the state of a recording that's started
+type recording struct {
+	opts RecordingOptions
+	// clock is how long the recording has gone for, and next is when the
+	// next frame is recorded
+	clock, next float32
+	// slots are the buffers frames are read back into, one per frame in
+	// flight, and capture is the one the frame that's drawn now is read into
+	// if it's recorded
+	slots   []captureSlot
+	capture *captureSlot
+	frames  chan capturedFrame
+	done    chan error
+	dropped int
+}
+
+// captureSlot is a buffer a frame is read back into
+type captureSlot struct {
+	buffer hostBuffer
+	// frame is the frame in flight that reads into it, while it's pending
+	frame   int
+	pending bool
+	format  vk.Format
+	extent  vk.Extent2D
+}
+
+// capturedFrame is a frame that's been read back, for the encoder
+type capturedFrame struct {
+	data   []byte
+	format vk.Format
+	extent vk.Extent2D
+}
+
+// StartRecording starts recording the frames that are drawn. They're read
+// back without waiting on the GPU and encoded in the background until
+// StopRecording is called.
+func (r *RenderSystem) StartRecording(opts RecordingOptions) error {
+	if r.recording != nil {
+		return errors.New("already recording")
+	}
+	if !r.swapChainReadable {
+		return errors.New("the surface doesn't let the swap chain images be copied")
+	}
+	if _, _, ok := pixelLayout(r.swapChainImageFormat); !ok {
+		return errors.New("unable to read images of the swap chain format")
+	}
+	if opts.FPS <= 0 {
+		opts.FPS = 30
+	}
+	if opts.Format == RecordPNG {
+		if err := os.MkdirAll(opts.Path, 0755); err != nil {
+			return err
+		}
+	}
+	rec := &recording{
+		opts:   opts,
+		slots:  make([]captureSlot, maxFramesInFlight),
+		frames: make(chan capturedFrame, recordingQueue),
+		done:   make(chan error, 1),
+	}
+	go encodeRecording(opts, rec.frames, rec.done)
+	r.recording = rec
+	return nil
+}
+
+// StopRecording stops recording and waits for the recorded frames to be
+// written.
+func (r *RenderSystem) StopRecording() error {
+	rec := r.recording
+	if rec == nil {
+		return errors.New("not recording")
+	}
+	vk.DeviceWaitIdle(r.device)
+	for i := range rec.slots {
+		r.collectCapture(&rec.slots[i])
+		r.destroyHostBuffer(&rec.slots[i].buffer)
+	}
+	r.recording = nil
+	close(rec.frames)
+	if rec.dropped > 0 {
+		log.Println("[VULKAN RENDER SYSTEM]: recording dropped", rec.dropped, "frames the encoder couldn't keep up with")
+	}
+	return <-rec.done
+}
+
+// collectCaptures sends the frames read back by the frame in flight, which has
+// finished, to the encoder.
+func (r *RenderSystem) collectCaptures() {
+	if r.recording == nil {
+		return
+	}
+	for i := range r.recording.slots {
+		if s := &r.recording.slots[i]; s.frame == r.currentFrame {
+			r.collectCapture(s)
+		}
+	}
+}
+
+// collectCapture sends the frame in the slot to the encoder, if it has one.
+// The frame that read it back has to have finished.
+func (r *RenderSystem) collectCapture(s *captureSlot) {
+	if !s.pending {
+		return
+	}
+	s.pending = false
+	size, _, _ := pixelLayout(s.format)
+	n := vk.DeviceSize(size * int(s.extent.Width) * int(s.extent.Height))
+	var ptr unsafe.Pointer
+	if res := vk.MapMemory(r.device, s.buffer.memory, 0, n, 0, &ptr); res != vk.Success {
+		log.Println("[VULKAN RENDER SYSTEM]: unable to map a recorded frame")
+		return
+	}
+	data := make([]byte, n)
+	copy(data, (*[1 << 30]byte)(ptr)[:n:n])
+	vk.UnmapMemory(r.device, s.buffer.memory)
+	select {
+	case r.recording.frames <- capturedFrame{data, s.format, s.extent}:
+	default:
+		r.recording.dropped++
+	}
+}
+
+// prepareCapture advances the clock of the recording and picks the slot the
+// frame is read back into, if it's time to record one.
+func (r *RenderSystem) prepareCapture(dt float32) {
+	rec := r.recording
+	if rec == nil {
+		return
+	}
+	rec.clock += dt
+	rec.capture = nil
+	if rec.clock < rec.next {
+		return
+	}
+	interval := 1 / rec.opts.FPS
+	rec.next += interval
+	if rec.next <= rec.clock {
+		// frames are slower than the recording, so it doesn't catch up with
+		// a burst
+		rec.next = rec.clock + interval
+	}
+	for i := range rec.slots {
+		if !rec.slots[i].pending {
+			rec.capture = &rec.slots[i]
+			return
+		}
+	}
+}
+
+// recordCapture records reading the swap chain image back into the slot of
+// the recording, if the frame is recorded.
+func (r *RenderSystem) recordCapture(buffer vk.CommandBuffer, imageIdx uint32) error {
+	if r.recording == nil || r.recording.capture == nil {
+		return nil
+	}
+	s := r.recording.capture
+	r.recording.capture = nil
+	size, _, _ := pixelLayout(r.swapChainImageFormat)
+	n := vk.DeviceSize(size * int(r.swapChainExtent.Width) * int(r.swapChainExtent.Height))
+	if err := r.readbackBuffer(&s.buffer, n, "recording readback"); err != nil {
+		return err
+	}
+	recordReadback(buffer, r.images[imageIdx], r.presentLayout(), s.buffer.buffer, r.swapChainExtent)
+	s.frame, s.pending = r.currentFrame, true
+	s.format, s.extent = r.swapChainImageFormat, r.swapChainExtent
+	return nil
+}
+
+// readbackBuffer makes sure b is a host visible buffer of at least n bytes
+// that images can be copied into.
+func (r *RenderSystem) readbackBuffer(b *hostBuffer, n vk.DeviceSize, name string) error {
+	if b.size >= n {
+		return nil
+	}
+	r.destroyHostBuffer(b)
+	b.usage, b.name = vk.BufferUsageFlags(vk.BufferUsageTransferDstBit), name
+	var err error
+	b.buffer, b.memory, err = r.createBuffer(n, b.usage, vk.MemoryPropertyFlags(vk.MemoryPropertyHostVisibleBit|vk.MemoryPropertyHostCoherentBit))
+	if err != nil {
+		return err
+	}
+	b.size = n
+	r.setName(vk.ObjectTypeBuffer, unsafe.Pointer(b.buffer), b.name)
+	return nil
+}
+
+// encodeRecording writes the frames of a recording until the channel is
+// closed, then sends the first error there was to done.
+func encodeRecording(opts RecordingOptions, frames <-chan capturedFrame, done chan<- error) {
+	var err error
+	var anim gif.GIF
+	n := 0
+	for f := range frames {
+		if err != nil {
+			continue
+		}
+		img := toRGBA(f.data, f.format, f.extent)
+		switch opts.Format {
+		case RecordGIF:
+			p := image.NewPaletted(img.Bounds(), quantize(img, 256))
+			draw.FloydSteinberg.Draw(p, img.Bounds(), img, image.Point{})
+			anim.Image = append(anim.Image, p)
+			// delays are in hundredths of a second, so they're rounded from
+			// the time of each frame to add up to the right length
+			anim.Delay = append(anim.Delay, int(math.Round(float64(n+1)*100/float64(opts.FPS))-math.Round(float64(n)*100/float64(opts.FPS))))
+		default:
+			err = WritePNG(filepath.Join(opts.Path, fmt.Sprintf("frame%05d.png", n)), img)
+		}
+		n++
+	}
+	if err == nil && opts.Format == RecordGIF {
+		var f *os.File
+		if f, err = os.Create(opts.Path); err == nil {
+			if err = gif.EncodeAll(f, &anim); err != nil {
+				f.Close()
+			} else {
+				err = f.Close()
+			}
+		}
+	}
+	done <- err
+}
+
+// WritePNG encodes the image to a .png file at path, creating its directory.
+// It's how screenshots and recorded frames are written.
+func WritePNG(path string, img image.Image) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err = png.Encode(f, img); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// quantize returns a palette of at most n colors for the image, by median
+// cut: the colors are split in half along their widest channel until there
+// are n groups, and each group is averaged.
+func quantize(img *image.RGBA, n int) color.Palette {
+	// a sample of the pixels is enough to find the palette
+	step := len(img.Pix)/4/65536 + 1
+	var pixels [][3]uint8
+	for i := 0; i+3 < len(img.Pix); i += 4 * step {
+		pixels = append(pixels, [3]uint8{img.Pix[i], img.Pix[i+1], img.Pix[i+2]})
+	}
+	boxes := [][][3]uint8{pixels}
+	for len(boxes) < n {
+		split, channel, widest := -1, 0, 0
+		for i, box := range boxes {
+			for c := 0; c < 3; c++ {
+				lo, hi := 255, 0
+				for _, p := range box {
+					if int(p[c]) < lo {
+						lo = int(p[c])
+					}
+					if int(p[c]) > hi {
+						hi = int(p[c])
+					}
+				}
+				if hi-lo > widest {
+					split, channel, widest = i, c, hi-lo
+				}
+			}
+		}
+		if split < 0 {
+			break
+		}
+		box := boxes[split]
+		sort.Slice(box, func(i, j int) bool { return box[i][channel] < box[j][channel] })
+		boxes[split] = box[:len(box)/2]
+		boxes = append(boxes, box[len(box)/2:])
+	}
+	palette := color.Palette{}
+	for _, box := range boxes {
+		if len(box) == 0 {
+			continue
+		}
+		var sum [3]int
+		for _, p := range box {
+			for c := range sum {
+				sum[c] += int(p[c])
+			}
+		}
+		palette = append(palette, color.RGBA{uint8(sum[0] / len(box)), uint8(sum[1] / len(box)), uint8(sum[2] / len(box)), 0xff})
+	}
+	if len(palette) == 0 {
+		palette = append(palette, color.Black)
+	}
+	return palette
+}
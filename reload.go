@@ -0,0 +1,281 @@
+package vulkanRenderSystem
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// shaderPollInterval is how often watched shader files are checked for changes
+const shaderPollInterval = 500 * time.Millisecond
+
+// shaderWatch is a shader that's reloaded when its files change
+type shaderWatch struct {
+	// shader is the shader to reload, or nil for the built in one
+	shader *Shader
+	// paths are the vertex and fragment shader files. An empty path keeps
+	// that stage.
+	paths    [2]string
+	modTimes [2]time.Time
+	changed  bool
+}
+
+// shaderWatcher polls the files of the watched shaders in the background. The
+// shaders are reloaded by the render loop between frames.
+type shaderWatcher struct {
+	lock    sync.Mutex
+	watches []*shaderWatch
+	stop    chan struct{}
+	// reverts are the reloaded shaders that failed to build a pipeline, which
+	// go back to their previous code before the next frame. They're only used
+	// by the render loop.
+	reverts []*Shader
+}
+
+// WatchShader reloads a shader whenever its vertex or fragment shader file
+// changes. The files are either .spv, or GLSL that's compiled with the
+// ShaderCompiler. An empty path keeps the current shader for that stage, and a
+// nil shader watches the built in one, whose sources are shader.vert and
+// shader.frag in internal/shaders. Shaders that fail to load or build a
+// pipeline are logged, and the previous version keeps being drawn.
+func (r *RenderSystem) WatchShader(s *Shader, vertPath, fragPath string) error {
+	if vertPath == "" && fragPath == "" {
+		return errors.New("no shader files to watch")
+	}
+	return r.watch(&shaderWatch{shader: s, paths: [2]string{vertPath, fragPath}})
+}
+
+// WatchEffect reloads the passes of an effect whenever their fragment shader
+// files change, like WatchShader. The paths are of the passes in order, and an
+// empty path keeps that pass.
+func (r *RenderSystem) WatchEffect(e *Effect, paths ...string) error {
+	if len(paths) > len(e.passes) {
+		return fmt.Errorf("%d shader files to watch, but the effect has %d passes", len(paths), len(e.passes))
+	}
+	var watches []*shaderWatch
+	for i, path := range paths {
+		if path != "" {
+			watches = append(watches, &shaderWatch{shader: e.passes[i].shader, paths: [2]string{"", path}})
+		}
+	}
+	if len(watches) == 0 {
+		return errors.New("no shader files to watch")
+	}
+	return r.watch(watches...)
+}
+
+// watch starts polling the files of the watches, once they've all been
+// found.
+func (r *RenderSystem) watch(watches ...*shaderWatch) error {
+	for _, w := range watches {
+		for i, path := range w.paths {
+			if path == "" {
+				continue
+			}
+			if filepath.Ext(path) != ".spv" && r.ShaderCompiler == "" {
+				return errors.New("watching GLSL shader " + path + " needs a ShaderCompiler")
+			}
+			info, err := os.Stat(path)
+			if err != nil {
+				return err
+			}
+			w.modTimes[i] = info.ModTime()
+		}
+	}
+	r.watcher.lock.Lock()
+	defer r.watcher.lock.Unlock()
+	r.watcher.watches = append(r.watcher.watches, watches...)
+	if r.watcher.stop == nil {
+		r.watcher.stop = make(chan struct{})
+		go r.watcher.poll(r.watcher.stop)
+	}
+	return nil
+}
+
+func (sw *shaderWatcher) poll(stop chan struct{}) {
+	ticker := time.NewTicker(shaderPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+		sw.lock.Lock()
+		for _, w := range sw.watches {
+			for i, path := range w.paths {
+				if path == "" {
+					continue
+				}
+				info, err := os.Stat(path)
+				if err != nil || info.ModTime().Equal(w.modTimes[i]) {
+					continue
+				}
+				w.modTimes[i] = info.ModTime()
+				w.changed = true
+			}
+		}
+		sw.lock.Unlock()
+	}
+}
+
+// close stops polling the watched files.
+func (sw *shaderWatcher) close() {
+	sw.lock.Lock()
+	defer sw.lock.Unlock()
+	if sw.stop != nil {
+		close(sw.stop)
+		sw.stop = nil
+	}
+}
+
+// reloadShaders reloads the watched shaders whose files changed, and puts
+// back the previous code of the ones that failed.
+func (r *RenderSystem) reloadShaders() {
+	for _, s := range r.watcher.reverts {
+		r.revertShader(s)
+	}
+	r.watcher.reverts = nil
+	var changed []*shaderWatch
+	r.watcher.lock.Lock()
+	for _, w := range r.watcher.watches {
+		if w.changed {
+			w.changed = false
+			changed = append(changed, w)
+		}
+	}
+	r.watcher.lock.Unlock()
+	for _, w := range changed {
+		if err := r.reloadShader(w); err != nil {
+			log.Println("[VULKAN RENDER SYSTEM]: unable to reload shader " + strings.TrimSpace(strings.Join(w.paths[:], " ")) + ": " + err.Error())
+		}
+	}
+}
+
+// reloadShader loads the files of the watch and rebuilds the pipelines of its
+// shader. The shader is only changed once all the new pipelines are built.
+func (r *RenderSystem) reloadShader(w *shaderWatch) error {
+	target := w.shader
+	if target == nil {
+		target = r.defaultShader
+	}
+	code := [2][]byte{target.vert, target.frag}
+	for i, path := range w.paths {
+		if path == "" {
+			continue
+		}
+		var err error
+		if code[i], err = r.readShader(path); err != nil {
+			return err
+		}
+	}
+	// effects are reflected against the images and constants they're given
+	var s *Shader
+	var err error
+	if target.effect {
+		s, err = newEffectShader(code[1])
+	} else {
+		s, err = NewShader(code[0], code[1])
+	}
+	if err != nil {
+		return err
+	}
+	var created []cachedPipeline
+	for _, bucket := range r.pipelines {
+		for _, c := range bucket {
+			if c.state.shader != target {
+				continue
+			}
+			state := c.state
+			state.shader = s
+			p, err := r.createPipeline(state)
+			if err != nil {
+				for _, c := range created {
+					vk.DestroyPipeline(r.device, c.pipeline, nil)
+				}
+				return err
+			}
+			created = append(created, cachedPipeline{c.state, p})
+		}
+	}
+	// the old pipelines can still be in use by frames in flight
+	vk.DeviceWaitIdle(r.device)
+	r.invalidatePipelines(func(state renderState) bool { return state.shader == target })
+	for _, c := range created {
+		h := c.state.hash()
+		r.pipelines[h] = append(r.pipelines[h], c)
+	}
+	target.previous = &Shader{vert: target.vert, frag: target.frag, iface: target.iface}
+	r.replaceCode(target, s)
+	return nil
+}
+
+// replaceCode gives the shader the code of another. The descriptor sets of
+// its old layout are freed if the layout changes, as they'd otherwise stay
+// allocated from the pool, and effect passes allocate theirs again.
+func (r *RenderSystem) replaceCode(target, s *Shader) {
+	if key := target.iface.key(); key != s.iface.key() {
+		if l, ok := r.layouts[key]; ok {
+			r.releaseSets(func(k textureSetKey) bool { return k.layout == l })
+		}
+		for _, e := range r.post.effects {
+			for i := range e.passes {
+				if e.passes[i].shader == target {
+					r.freeEffectSets(&e.passes[i])
+				}
+			}
+		}
+	}
+	target.vert, target.frag, target.iface = s.vert, s.frag, s.iface
+}
+
+// failedReload is called when a reloaded shader fails to build a pipeline.
+// The draws with it are skipped, and it goes back to its previous code
+// before the next frame.
+func (r *RenderSystem) failedReload(s *Shader, err error) {
+	for _, revert := range r.watcher.reverts {
+		if revert == s {
+			return
+		}
+	}
+	log.Println("[VULKAN RENDER SYSTEM]: reloaded shader failed to build a pipeline, going back to its previous code: " + err.Error())
+	r.watcher.reverts = append(r.watcher.reverts, s)
+}
+
+// revertShader puts back the code a shader had before it was reloaded.
+func (r *RenderSystem) revertShader(s *Shader) {
+	if s.previous == nil {
+		return
+	}
+	vk.DeviceWaitIdle(r.device)
+	r.invalidatePipelines(func(state renderState) bool { return state.shader == s })
+	r.replaceCode(s, s.previous)
+	s.previous = nil
+}
+
+// readShader reads a .spv file, or compiles a GLSL file with the
+// ShaderCompiler.
+func (r *RenderSystem) readShader(path string) ([]byte, error) {
+	if filepath.Ext(path) == ".spv" {
+		return ioutil.ReadFile(path)
+	}
+	out, err := ioutil.TempFile("", "shader-*.spv")
+	if err != nil {
+		return nil, err
+	}
+	out.Close()
+	defer os.Remove(out.Name())
+	if msg, err := exec.Command(r.ShaderCompiler, "-V", path, "-o", out.Name()).CombinedOutput(); err != nil {
+		return nil, errors.New("compiling failed: " + err.Error() + "\n" + string(msg))
+	}
+	return ioutil.ReadFile(out.Name())
+}
@@ -2,6 +2,8 @@ package vulkanRenderSystem
 
 import (
 	"errors"
+	"fmt"
+	"image"
 	"image/color"
 	"log"
 	"sync"
@@ -14,7 +16,6 @@ import (
 	"github.com/EngoEngine/engo"
 	"github.com/EngoEngine/systems/physics"
 
-	"github.com/Noofbiz/vulkanRenderSystem/internal/shaders"
 	"github.com/go-gl/mathgl/mgl32"
 
 	vk "github.com/vulkan-go/vulkan"
@@ -37,9 +38,21 @@ type RenderComponent struct {
 	// Color defines how much of the color-components of the texture get used
 	Color color.Color
 	// Drawable refers to the Texture that should be drawn
-	// Drawable Drawable
+	Drawable Drawable
 	// ZIndex is the drawing order for the entities
 	Zindex int
+	// Shader is the shader the entity is drawn with. Nil uses the built in one.
+	Shader *Shader
+}
+
+// Drawable is something that can be drawn by the RenderSystem
+type Drawable interface {
+	// Texture is the texture the drawable is sampled from
+	Texture() *Texture
+	Width() float32
+	Height() float32
+	// View is the region of the texture to draw, as u1, v1, u2, v2
+	View() (float32, float32, float32, float32)
 }
 
 type renderEntity struct {
@@ -49,6 +62,19 @@ type renderEntity struct {
 }
 
 type RenderSystem struct {
+	// ShaderCompiler is the command that compiles watched GLSL shaders into
+	// SPIR-V, like glslangValidator. It's run with -V <source> -o <output>.
+	ShaderCompiler string
+	// Headless renders frames into offscreen images rather than a window. It
+	// has to be set before the RenderSystem is added to the world, and engo
+	// should be run in HeadlessMode.
+	Headless *HeadlessOptions
+	// Debug turns on the validation layer and logs what it and the driver
+	// report. It has to be set before the RenderSystem is added to the world.
+	// Without it, debug mode can be turned on with the VULKAN_RENDER_DEBUG
+	// environment variable.
+	Debug *DebugOptions
+
 	entities                 []renderEntity
 	instance                 vk.Instance
 	surface                  vk.Surface
@@ -63,28 +89,52 @@ type RenderSystem struct {
 	swapChainImageFormat     vk.Format
 	swapChainExtent          vk.Extent2D
 	swapChainImageViews      []vk.ImageView
+	swapChainReadable        bool
+	offscreen                []offscreenImage
+	recording                *recording
+	debug                    *debugState
+	profiler                 profiler
+	screenshot               screenshotCapture
 	renderPass               vk.RenderPass
-	pipelineLayout           vk.PipelineLayout
-	graphicsPipelines        []vk.Pipeline
+	targetPasses             [2]vk.RenderPass
+	depthFormat              vk.Format
+	targets                  []*RenderTarget
+	post                     postChain
+	layouts                  map[string]*shaderLayout
+	pipelineCache            vk.PipelineCache
+	defaultShader            *Shader
+	watcher                  shaderWatcher
+	pipelines                map[uint64][]cachedPipeline
 	swapChainFramebuffers    []vk.Framebuffer
 	commandPool              vk.CommandPool
 	commandBuffers           []vk.CommandBuffer
 	imageAvailableSemaphores []vk.Semaphore
 	renderFinishedSemaphores []vk.Semaphore
 	inFlightFences           []vk.Fence
+	imagesInFlight           []vk.Fence
 	currentFrame             int
 	framebufferResized       bool
 	lock                     sync.Mutex
-	vertexBuffer             vk.Buffer
-	vertexBufferMemory       vk.DeviceMemory
-	indexBuffer              vk.Buffer
-	indexBufferMemory        vk.DeviceMemory
-	descriptorSetLayouts     []vk.DescriptorSetLayout
 	uniformBuffers           []vk.Buffer
 	uniformBuffersMemory     []vk.DeviceMemory
-	startTime                time.Time
 	descriptorPool           vk.DescriptorPool
-	descriptorSets           []vk.DescriptorSet
+	textureSets              map[textureSetKey][]vk.DescriptorSet
+	levels                   []*Level
+	camera                   Camera
+	whiteTexture             *Texture
+	batch                    geometryBatch
+	batchVertexBuffers       []hostBuffer
+	batchIndexBuffers        []hostBuffer
+	// chunkStaging are the buffers tile chunks are uploaded through, one per
+	// frame in flight, and chunkCopies the copies out of it the frame being
+	// recorded starts with. retiredChunks are the chunk buffers each frame in
+	// flight stopped using, destroyed once it has finished.
+	chunkStaging  []hostBuffer
+	chunkCopies   []chunkCopy
+	retiredChunks [][]deviceBuffer
+	// swapChainFailed is whether recreating the swap chain failed. It's tried
+	// again every frame, and nothing is drawn until it works.
+	swapChainFailed bool
 }
 
 var theRenderSystem *RenderSystem
@@ -95,15 +145,19 @@ func (r *RenderSystem) New(w *ecs.World) {
 		return
 	}
 	theRenderSystem = r
-	engo.Mailbox.Listen("WindowResizeMessage", func(m engo.Message) {
-		_, ok := m.(engo.WindowResizeMessage)
-		if !ok {
-			return
-		}
-		r.lock.Lock()
-		r.framebufferResized = true
-		r.lock.Unlock()
-	})
+	// headless there's no window to resize, and engo may not have been run,
+	// so there's no Mailbox either
+	if r.Headless == nil {
+		engo.Mailbox.Listen("WindowResizeMessage", func(m engo.Message) {
+			_, ok := m.(engo.WindowResizeMessage)
+			if !ok {
+				return
+			}
+			r.lock.Lock()
+			r.framebufferResized = true
+			r.lock.Unlock()
+		})
+	}
 	if err := r.initVulkan(); err != nil {
 		panic(err)
 	}
@@ -116,7 +170,7 @@ func (r *RenderSystem) New(w *ecs.World) {
 	if err := r.createRenderPass(); err != nil {
 		panic(err)
 	}
-	if err := r.createDescriptorSetLayout(); err != nil {
+	if err := r.createPipelineCache(); err != nil {
 		panic(err)
 	}
 	if err := r.createGraphicsPipeline(); err != nil {
@@ -137,50 +191,98 @@ func (r *RenderSystem) New(w *ecs.World) {
 	if err := r.createTextureSampler(); err != nil {
 		panic(err)
 	}
-	if err := r.createVertexBuffer(); err != nil {
-		panic(err)
-	}
-	if err := r.createIndexBuffer(); err != nil {
-		panic(err)
-	}
 	if err := r.createUniformBuffers(); err != nil {
 		panic(err)
 	}
 	if err := r.createDescriptorPool(); err != nil {
 		panic(err)
 	}
-	if err := r.createDescriptorSets(); err != nil {
+	if err := r.createBatchBuffers(); err != nil {
 		panic(err)
 	}
+	r.createChunkStaging()
 	if err := r.createCommandBuffers(); err != nil {
 		panic(err)
 	}
 	if err := r.createSyncObjects(); err != nil {
 		panic(err)
 	}
+	if err := r.createProfiler(); err != nil {
+		panic(err)
+	}
+	r.checkValidation()
 }
 
 func (r *RenderSystem) Update(dt float32) {
-	if r.startTime.IsZero() {
-		r.startTime = time.Now()
-	}
+	defer r.checkValidation()
 	var imageIndex uint32
 	r.lock.Lock()
 	if r.framebufferResized {
-		r.recreateSwapChain()
-		r.framebufferResized = false
+		err := r.recreateSwapChain()
+		if err != nil && !r.swapChainFailed {
+			log.Println("[VULKAN RENDER SYSTEM]: unable to recreate the swap chain: " + err.Error())
+		}
+		r.swapChainFailed = err != nil
+		r.framebufferResized = err != nil
 		r.lock.Unlock()
 		return
 	}
 	r.lock.Unlock()
-	vk.WaitForFences(r.device, 1, r.inFlightFences[r.currentFrame:r.currentFrame], vk.True, vk.MaxUint64)
-	if res := vk.AcquireNextImage(r.device, r.swapChain, vk.MaxUint64, r.imageAvailableSemaphores[r.currentFrame], vk.NullFence, &imageIndex); res != vk.Success {
-		panic("failed to aquire swap chain image")
+	r.reloadShaders()
+	r.post.time += dt
+	for _, l := range r.allLevels() {
+		l.elapsed += dt
+	}
+	r.buildBatch()
+	r.buildTargets()
+	vk.WaitForFences(r.device, 1, r.inFlightFences[r.currentFrame:r.currentFrame+1], vk.True, vk.MaxUint64)
+	r.collectCaptures()
+	r.collectScreenshot()
+	r.collectProfile()
+	r.destroyRetiredChunks(r.currentFrame)
+	r.prepareCapture(dt)
+	if r.Headless != nil {
+		// there's no swap chain, so there's an offscreen image per frame in
+		// flight
+		imageIndex = uint32(r.currentFrame)
+	} else {
+		res := vk.AcquireNextImage(r.device, r.swapChain, vk.MaxUint64, r.imageAvailableSemaphores[r.currentFrame], vk.NullFence, &imageIndex)
+		if res == vk.ErrorOutOfDate {
+			r.lock.Lock()
+			r.framebufferResized = true
+			r.lock.Unlock()
+			return
+		}
+		if res != vk.Success && res != vk.Suboptimal {
+			panic("failed to aquire swap chain image")
+		}
+	}
+	// the command buffer and vertex data of the image are rewritten, so wait
+	// for any frame that's still using them
+	if r.imagesInFlight[imageIndex] != vk.NullFence {
+		vk.WaitForFences(r.device, 1, r.imagesInFlight[imageIndex:imageIndex+1], vk.True, vk.MaxUint64)
 	}
+	r.imagesInFlight[imageIndex] = r.inFlightFences[r.currentFrame]
 	waitSemaphores := []vk.Semaphore{r.imageAvailableSemaphores[r.currentFrame]}
 	waitStages := []vk.PipelineStageFlags{vk.PipelineStageFlags(vk.PipelineStageColorAttachmentOutputBit)}
 	signalSemaphores := []vk.Semaphore{r.renderFinishedSemaphores[r.currentFrame]}
-	r.updateUniformBuffer(imageIndex)
+	if err := r.updateUniformBuffer(imageIndex); err != nil {
+		panic(err)
+	}
+	if err := r.uploadChunks(); err != nil {
+		panic(err)
+	}
+	if err := r.uploadBatch(imageIndex); err != nil {
+		panic(err)
+	}
+	if err := r.uploadTargets(imageIndex); err != nil {
+		panic(err)
+	}
+	recordStart := time.Now()
+	if err := r.recordCommandBuffer(imageIndex); err != nil {
+		panic(err)
+	}
+	record := time.Since(recordStart)
 	submitInfo := []vk.SubmitInfo{vk.SubmitInfo{
 		SType:                vk.StructureTypeSubmitInfo,
 		WaitSemaphoreCount:   1,
@@ -191,10 +293,25 @@ func (r *RenderSystem) Update(dt float32) {
 		SignalSemaphoreCount: 1,
 		PSignalSemaphores:    signalSemaphores,
 	}}
-	vk.ResetFences(r.device, 1, r.inFlightFences[r.currentFrame:r.currentFrame])
+	if r.Headless != nil {
+		// offscreen images aren't acquired or presented
+		submitInfo[0].WaitSemaphoreCount = 0
+		submitInfo[0].SignalSemaphoreCount = 0
+	}
+	vk.ResetFences(r.device, 1, r.inFlightFences[r.currentFrame:r.currentFrame+1])
+	submitStart := time.Now()
 	if vk.QueueSubmit(r.graphicsQueue, 1, submitInfo, r.inFlightFences[r.currentFrame]) != vk.Success {
 		panic("failed to submit draw command buffer!")
 	}
+	r.finishProfile(record, time.Since(submitStart))
+	if r.Headless != nil {
+		if err := r.finishOffscreen(imageIndex); err != nil {
+			panic(err)
+		}
+		r.currentFrame++
+		r.currentFrame %= maxFramesInFlight
+		return
+	}
 	presentInfo := vk.PresentInfo{
 		SType:              vk.StructureTypePresentInfo,
 		WaitSemaphoreCount: 1,
@@ -203,14 +320,54 @@ func (r *RenderSystem) Update(dt float32) {
 		PSwapchains:        []vk.Swapchain{r.swapChain},
 		PImageIndices:      []uint32{imageIndex},
 	}
-	if res := vk.QueuePresent(r.presentQueue, &presentInfo); res != vk.Success {
+	res := vk.QueuePresent(r.presentQueue, &presentInfo)
+	if res == vk.ErrorOutOfDate || res == vk.Suboptimal {
+		r.lock.Lock()
+		r.framebufferResized = true
+		r.lock.Unlock()
+	} else if res != vk.Success {
 		panic("failed to present draw")
 	}
 	r.currentFrame++
 	r.currentFrame %= maxFramesInFlight
 }
 
-func (r *RenderSystem) Remove(e ecs.BasicEntity) {}
+// Add adds an entity to the RenderSystem. The entity needs a RenderComponent
+// with a Drawable and a SpaceComponent to be drawn.
+func (r *RenderSystem) Add(basic *ecs.BasicEntity, render *RenderComponent, space *physics.SpaceComponent) {
+	r.entities = append(r.entities, renderEntity{basic, space, render})
+}
+
+// Remove removes an entity from the RenderSystem.
+func (r *RenderSystem) Remove(e ecs.BasicEntity) {
+	delete := -1
+	for index, entity := range r.entities {
+		if entity.ID() == e.ID() {
+			delete = index
+			break
+		}
+	}
+	if delete >= 0 {
+		r.entities = append(r.entities[:delete], r.entities[delete+1:]...)
+	}
+}
+
+// AddLevel adds the layers of a level to the RenderSystem. The tile
+// animations of the level are advanced while it's added.
+func (r *RenderSystem) AddLevel(l *Level) {
+	r.levels = append(r.levels, l)
+}
+
+// RemoveLevel removes a level from the RenderSystem.
+func (r *RenderSystem) RemoveLevel(l *Level) {
+	for i, level := range r.levels {
+		if level == l {
+			r.levels = append(r.levels[:i], r.levels[i+1:]...)
+			r.releaseLevel(l)
+			return
+		}
+	}
+}
 
 func (r *RenderSystem) initVulkan() error {
 	version := engo.GetApplicationVersion()
@@ -228,20 +385,38 @@ func (r *RenderSystem) initVulkan() error {
 	createInfo := vk.InstanceCreateInfo{}
 	createInfo.SType = vk.StructureTypeInstanceCreateInfo
 	createInfo.PApplicationInfo = &appInfo
-	exts := engo.Window.GetRequiredInstanceExtensions()
-	createInfo.EnabledExtensionCount = uint32(len(exts))
-	createInfo.PpEnabledExtensionNames = exts
+	if r.Headless != nil {
+		// there's no window to load vulkan and nothing is presented, so
+		// there's no swap chain
+		wantedExtensions = nil
+		if err := vk.SetDefaultGetInstanceProcAddr(); err != nil {
+			return err
+		}
+		if err := vk.Init(); err != nil {
+			return err
+		}
+	} else {
+		exts := engo.Window.GetRequiredInstanceExtensions()
+		createInfo.EnabledExtensionCount = uint32(len(exts))
+		createInfo.PpEnabledExtensionNames = exts
+	}
+	r.enableDebug(&createInfo)
 	if res := vk.CreateInstance(&createInfo, nil, &r.instance); res != vk.Success {
 		return errors.New("unable to create vulkan instance")
 	}
 	if err := vk.InitInstance(r.instance); err != nil {
 		return err
 	}
-	surfPtr, err := engo.Window.CreateWindowSurface(r.instance, nil)
-	r.surface = vk.SurfaceFromPointer(surfPtr)
-	if err != nil {
+	if err := r.createDebugMessenger(); err != nil {
 		return err
 	}
+	if r.Headless == nil {
+		surfPtr, err := engo.Window.CreateWindowSurface(r.instance, nil)
+		r.surface = vk.SurfaceFromPointer(surfPtr)
+		if err != nil {
+			return err
+		}
+	}
 	var deviceCount uint32
 	if res := vk.EnumeratePhysicalDevices(r.instance, &deviceCount, nil); res != vk.Success {
 		return errors.New("unable to get physical devices")
@@ -268,6 +443,9 @@ deviceLoop:
 				r.graphicsIdx = uint32(i)
 				graphicsSupport = true
 			}
+			if r.Headless != nil {
+				continue
+			}
 			var b32PresentSupport vk.Bool32
 			vk.GetPhysicalDeviceSurfaceSupport(device, uint32(i), r.surface, &b32PresentSupport)
 			if b32PresentSupport.B() {
@@ -275,6 +453,14 @@ deviceLoop:
 				r.presentIdx = uint32(i)
 			}
 		}
+		if graphicsSupport && r.Headless != nil {
+			// without a surface, any device that can draw will do
+			r.presentIdx = r.graphicsIdx
+			deviceSelected = true
+			physicalDevice = device
+			r.gpu = device
+			break
+		}
 		if !graphicsSupport || !presentSupport {
 			continue
 		}
@@ -352,6 +538,9 @@ deviceLoop:
 }
 
 func (r *RenderSystem) createSwapChain() error {
+	if r.Headless != nil {
+		return r.createOffscreenImages()
+	}
 	surfaceFormat := r.chooseSwapSurfaceFormat()
 	surfaceFormat.Deref()
 	presentMode := r.chooseSwapPresentMode()
@@ -374,6 +563,11 @@ func (r *RenderSystem) createSwapChain() error {
 		ImageArrayLayers: 1,
 		ImageUsage:       vk.ImageUsageFlags(vk.ImageUsageColorAttachmentBit),
 	}
+	// screenshots copy from the swap chain images, if the surface lets them
+	r.swapChainReadable = details.capabilities.SupportedUsageFlags&vk.ImageUsageFlags(vk.ImageUsageTransferSrcBit) != 0
+	if r.swapChainReadable {
+		createInfo.ImageUsage |= vk.ImageUsageFlags(vk.ImageUsageTransferSrcBit)
+	}
 	if r.graphicsIdx != r.presentIdx {
 		createInfo.ImageSharingMode = vk.SharingModeConcurrent
 		createInfo.QueueFamilyIndexCount = 2
@@ -481,13 +675,13 @@ func (r *RenderSystem) createImageViews() error {
 func (r *RenderSystem) createRenderPass() error {
 	colorAttachment := vk.AttachmentDescription{
 		Format:         r.swapChainImageFormat,
-		Samples:        vk.SampleCount1Bit,
+		Samples:        passSamples,
 		LoadOp:         vk.AttachmentLoadOpClear,
 		StoreOp:        vk.AttachmentStoreOpStore,
 		StencilLoadOp:  vk.AttachmentLoadOpDontCare,
 		StencilStoreOp: vk.AttachmentStoreOpDontCare,
 		InitialLayout:  vk.ImageLayoutUndefined,
-		FinalLayout:    vk.ImageLayoutPresentSrc,
+		FinalLayout:    r.presentLayout(),
 	}
 
 	colorAttachmentRef := vk.AttachmentReference{
@@ -524,27 +718,51 @@ func (r *RenderSystem) createRenderPass() error {
 		return errors.New("failed to create render pass")
 	}
 	r.renderPass = renderPass
+	r.setName(vk.ObjectTypeRenderPass, unsafe.Pointer(renderPass), "screen render pass")
 
 	return nil
 }
 
+// createGraphicsPipeline creates the pipeline of the built in shader.
+// Pipelines of other shaders are created when they're first drawn with.
 func (r *RenderSystem) createGraphicsPipeline() error {
-	vertShaderData, err := shaders.Asset("vert.spv")
+	if r.defaultShader == nil {
+		s, err := NewShader(nil, nil)
+		if err != nil {
+			return err
+		}
+		r.defaultShader = s
+	}
+	if r.pipelines == nil {
+		r.pipelines = make(map[uint64][]cachedPipeline)
+	}
+
+	_, err := r.pipeline(r.drawState(nil))
+	return err
+}
+
+// createPipeline creates a pipeline for the render state.
+func (r *RenderSystem) createPipeline(state renderState) (vk.Pipeline, error) {
+	var pipeline vk.Pipeline
+	s := state.shader
+	pass, err := r.compatiblePass(state)
 	if err != nil {
-		return err
+		return pipeline, err
 	}
-	fragShaderData, err := shaders.Asset("frag.spv")
+	layout, err := r.layout(s)
 	if err != nil {
-		return err
+		return pipeline, err
 	}
-	vertShaderModule, err := r.loadShaderModule(vertShaderData)
+	vertShaderModule, err := r.loadShaderModule(s.vert)
 	if err != nil {
-		return err
+		return pipeline, err
 	}
-	fragShaderModule, err := r.loadShaderModule(fragShaderData)
+	defer vk.DestroyShaderModule(r.device, vertShaderModule, nil)
+	fragShaderModule, err := r.loadShaderModule(s.frag)
 	if err != nil {
-		return err
+		return pipeline, err
 	}
+	defer vk.DestroyShaderModule(r.device, fragShaderModule, nil)
 
 	vertShaderStageInfo := vk.PipelineShaderStageCreateInfo{
 		SType:  vk.StructureTypePipelineShaderStageCreateInfo,
@@ -565,8 +783,9 @@ func (r *RenderSystem) createGraphicsPipeline() error {
 		fragShaderStageInfo,
 	}
 
-	a := vertices.getAttributeDescriptions()
-	b := vertices.getBindingDescription()
+	var v vertex
+	a := s.iface.attributes
+	b := v.getBindingDescription()
 
 	vertexInputInfo := vk.PipelineVertexInputStateCreateInfo{
 		SType:                           vk.StructureTypePipelineVertexInputStateCreateInfo,
@@ -575,36 +794,31 @@ func (r *RenderSystem) createGraphicsPipeline() error {
 		PVertexBindingDescriptions:      []vk.VertexInputBindingDescription{b},
 		PVertexAttributeDescriptions:    a,
 	}
+	// full screen passes make their vertices in the shader
+	if len(a) == 0 {
+		vertexInputInfo.VertexBindingDescriptionCount = 0
+		vertexInputInfo.PVertexBindingDescriptions = nil
+	}
 
 	inputAssembly := vk.PipelineInputAssemblyStateCreateInfo{
 		SType:                  vk.StructureTypePipelineInputAssemblyStateCreateInfo,
-		Topology:               vk.PrimitiveTopologyTriangleList,
+		Topology:               state.topology,
 		PrimitiveRestartEnable: vk.False,
 	}
 
-	viewport := vk.Viewport{
-		X:        0,
-		Y:        0,
-		Width:    float32(r.swapChainExtent.Width),
-		Height:   float32(r.swapChainExtent.Height),
-		MinDepth: 0,
-		MaxDepth: 1,
-	}
-
-	scissor := vk.Rect2D{
-		Offset: vk.Offset2D{
-			X: 0,
-			Y: 0,
-		},
-		Extent: r.swapChainExtent,
-	}
-
+	// the viewport and scissor are set when drawing, so the pipeline doesn't
+	// have to be built again when the window is resized
 	viewportState := vk.PipelineViewportStateCreateInfo{
 		SType:         vk.StructureTypePipelineViewportStateCreateInfo,
 		ViewportCount: 1,
-		PViewports:    []vk.Viewport{viewport},
 		ScissorCount:  1,
-		PScissors:     []vk.Rect2D{scissor},
+	}
+
+	dynamicStates := []vk.DynamicState{vk.DynamicStateViewport, vk.DynamicStateScissor}
+	dynamicState := vk.PipelineDynamicStateCreateInfo{
+		SType:             vk.StructureTypePipelineDynamicStateCreateInfo,
+		DynamicStateCount: uint32(len(dynamicStates)),
+		PDynamicStates:    dynamicStates,
 	}
 
 	rasterizer := vk.PipelineRasterizationStateCreateInfo{
@@ -613,7 +827,7 @@ func (r *RenderSystem) createGraphicsPipeline() error {
 		RasterizerDiscardEnable: vk.False,
 		PolygonMode:             vk.PolygonModeFill,
 		LineWidth:               1,
-		CullMode:                vk.CullModeFlags(vk.CullModeBackBit),
+		CullMode:                vk.CullModeFlags(vk.CullModeNone),
 		FrontFace:               vk.FrontFaceCounterClockwise,
 		DepthBiasEnable:         vk.False,
 	}
@@ -621,7 +835,7 @@ func (r *RenderSystem) createGraphicsPipeline() error {
 	multisampling := vk.PipelineMultisampleStateCreateInfo{
 		SType:                 vk.StructureTypePipelineMultisampleStateCreateInfo,
 		SampleShadingEnable:   vk.False,
-		RasterizationSamples:  vk.SampleCount1Bit,
+		RasterizationSamples:  state.samples,
 		MinSampleShading:      1,
 		AlphaToCoverageEnable: vk.False,
 		AlphaToOneEnable:      vk.False,
@@ -630,14 +844,27 @@ func (r *RenderSystem) createGraphicsPipeline() error {
 	colorBlendAttachment := vk.PipelineColorBlendAttachmentState{
 		ColorWriteMask:      vk.ColorComponentFlags(vk.ColorComponentRBit | vk.ColorComponentGBit | vk.ColorComponentBBit | vk.ColorComponentABit),
 		BlendEnable:         vk.False,
-		SrcColorBlendFactor: vk.BlendFactorOne,
-		DstColorBlendFactor: vk.BlendFactorZero,
+		SrcColorBlendFactor: vk.BlendFactorSrcAlpha,
+		DstColorBlendFactor: vk.BlendFactorOneMinusSrcAlpha,
 		ColorBlendOp:        vk.BlendOpAdd,
 		SrcAlphaBlendFactor: vk.BlendFactorOne,
-		DstAlphaBlendFactor: vk.BlendFactorZero,
+		DstAlphaBlendFactor: vk.BlendFactorOneMinusSrcAlpha,
 		AlphaBlendOp:        vk.BlendOpAdd,
 	}
 
+	if state.blend {
+		colorBlendAttachment.BlendEnable = vk.True
+	}
+
+	// geometry has no depth, so testing with less or equal keeps the order
+	// it's drawn in
+	depthStencil := vk.PipelineDepthStencilStateCreateInfo{
+		SType:            vk.StructureTypePipelineDepthStencilStateCreateInfo,
+		DepthTestEnable:  vk.True,
+		DepthWriteEnable: vk.True,
+		DepthCompareOp:   vk.CompareOpLessOrEqual,
+	}
+
 	colorBlending := vk.PipelineColorBlendStateCreateInfo{
 		SType:           vk.StructureTypePipelineColorBlendStateCreateInfo,
 		LogicOpEnable:   vk.False,
@@ -645,17 +872,6 @@ func (r *RenderSystem) createGraphicsPipeline() error {
 		PAttachments:    []vk.PipelineColorBlendAttachmentState{colorBlendAttachment},
 	}
 
-	pipelineLayoutInfo := vk.PipelineLayoutCreateInfo{
-		SType:          vk.StructureTypePipelineLayoutCreateInfo,
-		SetLayoutCount: 1,
-		PSetLayouts:    r.descriptorSetLayouts,
-	}
-	var pipelineLayout vk.PipelineLayout
-	if res := vk.CreatePipelineLayout(r.device, &pipelineLayoutInfo, nil, &pipelineLayout); res != vk.Success {
-		return errors.New("failed to create pipeline layout")
-	}
-	r.pipelineLayout = pipelineLayout
-
 	pipelineInfo := vk.GraphicsPipelineCreateInfo{
 		SType:               vk.StructureTypeGraphicsPipelineCreateInfo,
 		StageCount:          2,
@@ -666,20 +882,21 @@ func (r *RenderSystem) createGraphicsPipeline() error {
 		PRasterizationState: &rasterizer,
 		PMultisampleState:   &multisampling,
 		PColorBlendState:    &colorBlending,
-		Layout:              r.pipelineLayout,
-		RenderPass:          r.renderPass,
+		PDynamicState:       &dynamicState,
+		Layout:              layout.pipelineLayout,
+		RenderPass:          pass,
 		Subpass:             0,
 	}
-
-	r.graphicsPipelines = make([]vk.Pipeline, 1)
-	if res := vk.CreateGraphicsPipelines(r.device, nil, 1, []vk.GraphicsPipelineCreateInfo{pipelineInfo}, nil, r.graphicsPipelines); res != vk.Success {
-		errors.New("failed to create graphics pipeline")
+	if state.depth {
+		pipelineInfo.PDepthStencilState = &depthStencil
 	}
 
-	vk.DestroyShaderModule(r.device, vertShaderModule, nil)
-	vk.DestroyShaderModule(r.device, fragShaderModule, nil)
-
-	return nil
+	pipelines := make([]vk.Pipeline, 1)
+	if res := vk.CreateGraphicsPipelines(r.device, r.pipelineCache, 1, []vk.GraphicsPipelineCreateInfo{pipelineInfo}, nil, pipelines); res != vk.Success {
+		return pipeline, errors.New("failed to create graphics pipeline")
+	}
+	r.setName(vk.ObjectTypePipeline, unsafe.Pointer(pipelines[0]), fmt.Sprintf("shader %d pipeline", s.id))
+	return pipelines[0], nil
 }
 
 func (r *RenderSystem) loadShaderModule(data []byte) (vk.ShaderModule, error) {
@@ -721,6 +938,7 @@ func (r *RenderSystem) createFrameBuffers() error {
 func (r *RenderSystem) createCommandPool() error {
 	poolInfo := vk.CommandPoolCreateInfo{
 		SType:            vk.StructureTypeCommandPoolCreateInfo,
+		Flags:            vk.CommandPoolCreateFlags(vk.CommandPoolCreateResetCommandBufferBit),
 		QueueFamilyIndex: r.graphicsIdx,
 	}
 
@@ -741,6 +959,17 @@ func (r *RenderSystem) createTextureImage() error {
 		}
 	}
 	imagesToAdd = make([]string, 0)
+	for _, url := range lutsToAdd {
+		if err := engo.Files.Load(url); err != nil {
+			return err
+		}
+	}
+	lutsToAdd = nil
+	// shapes are drawn with a plain white texture tinted by their colors
+	white := image.NewNRGBA(image.Rect(0, 0, 1, 1))
+	white.Set(0, 0, color.White)
+	r.whiteTexture = NewTextureResource(white, "").Texture
+	r.nameTexture(r.whiteTexture, "white")
 	return nil
 }
 
@@ -763,39 +992,10 @@ func (r *RenderSystem) createCommandBuffers() error {
 	}
 
 	if res := vk.AllocateCommandBuffers(r.device, &allocInfo, r.commandBuffers); res != vk.Success {
-		errors.New("failed to allocate command buffers")
+		return errors.New("failed to allocate command buffers")
 	}
-
-	for idx, buffer := range r.commandBuffers {
-		beginInfo := vk.CommandBufferBeginInfo{
-			SType: vk.StructureTypeCommandBufferBeginInfo,
-			Flags: vk.CommandBufferUsageFlags(vk.CommandBufferUsageSimultaneousUseBit),
-		}
-		if res := vk.BeginCommandBuffer(buffer, &beginInfo); res != vk.Success {
-			return errors.New("failed to begin recording command buffers")
-		}
-		clearValue := vk.NewClearValue([]float32{0, 0, 0, 1})
-		renderPassInfo := vk.RenderPassBeginInfo{
-			SType:           vk.StructureTypeRenderPassBeginInfo,
-			RenderPass:      r.renderPass,
-			Framebuffer:     r.swapChainFramebuffers[idx],
-			ClearValueCount: 1,
-			PClearValues:    []vk.ClearValue{clearValue},
-		}
-		renderPassInfo.RenderArea.Offset = vk.Offset2D{X: 0, Y: 0}
-		renderPassInfo.RenderArea.Extent = r.swapChainExtent
-		vk.CmdBeginRenderPass(buffer, &renderPassInfo, vk.SubpassContentsInline)
-		vk.CmdBindPipeline(buffer, vk.PipelineBindPointGraphics, r.graphicsPipelines[0])
-		buffers := []vk.Buffer{r.vertexBuffer}
-		offsets := []vk.DeviceSize{0}
-		vk.CmdBindVertexBuffers(buffer, 0, 1, buffers, offsets)
-		vk.CmdBindIndexBuffer(buffer, r.indexBuffer, 0, vk.IndexTypeUint16)
-		vk.CmdBindDescriptorSets(buffer, vk.PipelineBindPointGraphics, r.pipelineLayout, 0, 1, r.descriptorSets, 0, nil)
-		vk.CmdDrawIndexed(buffer, uint32(len(indices)), 1, 0, 0, 0)
-		vk.CmdEndRenderPass(buffer)
-		if vk.EndCommandBuffer(buffer) != vk.Success {
-			return errors.New("failed to record command buffer!")
-		}
+	for i, buffer := range r.commandBuffers {
+		r.setName(vk.ObjectTypeCommandBuffer, unsafe.Pointer(buffer), fmt.Sprintf("frame %d commands", i))
 	}
 
 	return nil
@@ -805,6 +1005,7 @@ func (r *RenderSystem) createSyncObjects() error {
 	r.imageAvailableSemaphores = make([]vk.Semaphore, maxFramesInFlight)
 	r.renderFinishedSemaphores = make([]vk.Semaphore, maxFramesInFlight)
 	r.inFlightFences = make([]vk.Fence, maxFramesInFlight)
+	r.imagesInFlight = make([]vk.Fence, len(r.images))
 
 	semaphoreInfo := vk.SemaphoreCreateInfo{
 		SType: vk.StructureTypeSemaphoreCreateInfo,
@@ -842,54 +1043,69 @@ func (r *RenderSystem) recreateSwapChain() error {
 	vk.DeviceWaitIdle(r.device)
 
 	r.cleanupSwapChain()
+	r.destroyPostImages()
 
+	format := r.swapChainImageFormat
 	if err := r.createSwapChain(); err != nil {
 		return err
 	}
 	if err := r.createImageViews(); err != nil {
 		return err
 	}
-	if err := r.createRenderPass(); err != nil {
-		return err
+	if len(r.uniformBuffers) != len(r.images) {
+		if err := r.recreateImageResources(); err != nil {
+			return err
+		}
 	}
-	if err := r.createGraphicsPipeline(); err != nil {
-		return err
+	// the viewport is dynamic, so pipelines only have to be built again when
+	// the format changes
+	if r.swapChainImageFormat != format {
+		r.invalidatePipelines(func(s renderState) bool { return s.format == format })
+		vk.DestroyRenderPass(r.device, r.renderPass, nil)
+		if err := r.createRenderPass(); err != nil {
+			return err
+		}
+		if err := r.createGraphicsPipeline(); err != nil {
+			return err
+		}
 	}
 	if err := r.createFrameBuffers(); err != nil {
 		return err
 	}
-	if err := r.createCommandBuffers(); err != nil {
-		return err
+	if len(r.commandBuffers) != len(r.swapChainFramebuffers) {
+		vk.FreeCommandBuffers(r.device, r.commandPool, uint32(len(r.commandBuffers)), r.commandBuffers)
+		if err := r.createCommandBuffers(); err != nil {
+			return err
+		}
 	}
+	r.imagesInFlight = make([]vk.Fence, len(r.images))
 	return nil
 }
 
-func (r *RenderSystem) createVertexBuffer() error {
-	var err error
-	bufferSize := vk.DeviceSize(4 * uint64(len(vertices)))
-	stagingBuffer, stagingBufferMemory, err := r.createBuffer(bufferSize, vk.BufferUsageFlags(vk.BufferUsageTransferSrcBit), vk.MemoryPropertyFlags(vk.MemoryPropertyHostVisibleBit|vk.MemoryPropertyHostCoherentBit))
-	if err != nil {
-		return err
+// recreateImageResources makes the buffers and descriptor sets there's one of
+// per swap chain image again, for when the number of images changes.
+func (r *RenderSystem) recreateImageResources() error {
+	r.releaseSets(func(textureSetKey) bool { return true })
+	for _, e := range r.post.effects {
+		for i := range e.passes {
+			r.freeEffectSets(&e.passes[i])
+		}
 	}
-
-	var data unsafe.Pointer
-	vk.MapMemory(r.device, stagingBufferMemory, 0, bufferSize, 0, &data)
-	n := vk.Memcopy(data, vertexData(vertices))
-	if n != len(vertices)*4 {
-		return errors.New("failed to copy vertex buffer data")
+	r.freeEffectSets(&r.post.blit)
+	r.destroyUniformBuffers()
+	r.destroyBatchBuffers()
+	if err := r.createUniformBuffers(); err != nil {
+		return err
 	}
-	vk.UnmapMemory(r.device, stagingBufferMemory)
-
-	r.vertexBuffer, r.vertexBufferMemory, err = r.createBuffer(bufferSize, vk.BufferUsageFlags(vk.BufferUsageTransferDstBit|vk.BufferUsageVertexBufferBit), vk.MemoryPropertyFlags(vk.MemoryPropertyDeviceLocalBit))
-	if err != nil {
+	if err := r.createBatchBuffers(); err != nil {
 		return err
 	}
-
-	r.copyBuffer(stagingBuffer, r.vertexBuffer, bufferSize)
-
-	vk.DestroyBuffer(r.device, stagingBuffer, nil)
-	vk.FreeMemory(r.device, stagingBufferMemory, nil)
-
+	for _, t := range r.targets {
+		r.destroyTargetBuffers(t)
+		if err := r.createTargetBuffers(t); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -1010,6 +1226,22 @@ func (r *RenderSystem) transitionImageLayout(image vk.Image, format vk.Format, o
 		return err
 	}
 
+	var srcStage, dstStage vk.PipelineStageFlagBits
+	var srcAccess, dstAccess vk.AccessFlagBits
+	switch {
+	case oldLayout == vk.ImageLayoutUndefined && newLayout == vk.ImageLayoutTransferDstOptimal:
+		srcStage, dstStage = vk.PipelineStageTopOfPipeBit, vk.PipelineStageTransferBit
+		dstAccess = vk.AccessTransferWriteBit
+	case oldLayout == vk.ImageLayoutTransferDstOptimal && newLayout == vk.ImageLayoutShaderReadOnlyOptimal:
+		srcStage, dstStage = vk.PipelineStageTransferBit, vk.PipelineStageFragmentShaderBit
+		srcAccess, dstAccess = vk.AccessTransferWriteBit, vk.AccessShaderReadBit
+	case oldLayout == vk.ImageLayoutUndefined && newLayout == vk.ImageLayoutShaderReadOnlyOptimal:
+		srcStage, dstStage = vk.PipelineStageTopOfPipeBit, vk.PipelineStageFragmentShaderBit
+		dstAccess = vk.AccessShaderReadBit
+	default:
+		return errors.New("unsupported image layout transition")
+	}
+
 	barrier := []vk.ImageMemoryBarrier{
 		vk.ImageMemoryBarrier{
 			SType:               vk.StructureTypeImageMemoryBarrier,
@@ -1025,17 +1257,17 @@ func (r *RenderSystem) transitionImageLayout(image vk.Image, format vk.Format, o
 				BaseArrayLayer: 0,
 				LayerCount:     1,
 			},
-			SrcAccessMask: vk.AccessFlags(0),
-			DstAccessMask: vk.AccessFlags(0),
+			SrcAccessMask: vk.AccessFlags(srcAccess),
+			DstAccessMask: vk.AccessFlags(dstAccess),
 		},
 	}
 
-	vk.CmdPipelineBarrier(commandBuffers[0], 0, 0, 0, 0, nil, 0, nil, 0, barrier)
+	vk.CmdPipelineBarrier(commandBuffers[0], vk.PipelineStageFlags(srcStage), vk.PipelineStageFlags(dstStage), 0, 0, nil, 0, nil, 1, barrier)
 
 	return r.endSingleTimeCommands(commandBuffers)
 }
 
-func (r *RenderSystem) copyBufferToImage(buffer vk.Buffer, image vk.Image, width, height uint32) error {
+func (r *RenderSystem) copyBufferToImage(buffer vk.Buffer, image vk.Image, width, height, depth uint32) error {
 	commandBuffers, err := r.beginSingleTimeCommands()
 	if err != nil {
 		return err
@@ -1056,7 +1288,7 @@ func (r *RenderSystem) copyBufferToImage(buffer vk.Buffer, image vk.Image, width
 			ImageExtent: vk.Extent3D{
 				Width:  width,
 				Height: height,
-				Depth:  1,
+				Depth:  depth,
 			},
 		},
 	}
@@ -1066,68 +1298,86 @@ func (r *RenderSystem) copyBufferToImage(buffer vk.Buffer, image vk.Image, width
 	return r.endSingleTimeCommands(commandBuffers)
 }
 
-func (r *RenderSystem) createIndexBuffer() error {
-	var err error
-	bufferSize := vk.DeviceSize(2 * uint64(len(indices)))
-	stagingBuffer, stagingBufferMemory, err := r.createBuffer(bufferSize, vk.BufferUsageFlags(vk.BufferUsageTransferSrcBit), vk.MemoryPropertyFlags(vk.MemoryPropertyHostVisibleBit|vk.MemoryPropertyHostCoherentBit))
-	if err != nil {
-		return err
-	}
-
-	var data unsafe.Pointer
-	vk.MapMemory(r.device, stagingBufferMemory, 0, bufferSize, 0, &data)
-	n := vk.Memcopy(data, indexData(indices))
-	if n != len(indices)*2 {
-		return errors.New("failed to copy index buffer data")
-	}
-	vk.UnmapMemory(r.device, stagingBufferMemory)
-
-	r.indexBuffer, r.indexBufferMemory, err = r.createBuffer(bufferSize, vk.BufferUsageFlags(vk.BufferUsageTransferDstBit|vk.BufferUsageIndexBufferBit), vk.MemoryPropertyFlags(vk.MemoryPropertyDeviceLocalBit))
+// createImage creates a 2D image in device local memory.
+func (r *RenderSystem) createImage(width, height uint32, format vk.Format, usage vk.ImageUsageFlags) (vk.Image, vk.DeviceMemory, error) {
+	var image vk.Image
+	var memory vk.DeviceMemory
+	if res := vk.CreateImage(r.device, &vk.ImageCreateInfo{
+		SType:     vk.StructureTypeImageCreateInfo,
+		ImageType: vk.ImageType2d,
+		Extent: vk.Extent3D{
+			Width:  width,
+			Height: height,
+			Depth:  1,
+		},
+		MipLevels:     1,
+		ArrayLayers:   1,
+		Format:        format,
+		Tiling:        vk.ImageTilingOptimal,
+		InitialLayout: vk.ImageLayoutUndefined,
+		Usage:         usage,
+		SharingMode:   vk.SharingModeExclusive,
+		Samples:       vk.SampleCount1Bit,
+	}, nil, &image); res != vk.Success {
+		return nil, nil, errors.New("unable to create image")
+	}
+	memory, err := r.bindImageMemory(image)
 	if err != nil {
-		return err
+		vk.DestroyImage(r.device, image, nil)
+		return nil, nil, err
 	}
-
-	r.copyBuffer(stagingBuffer, r.indexBuffer, bufferSize)
-
-	vk.DestroyBuffer(r.device, stagingBuffer, nil)
-	vk.FreeMemory(r.device, stagingBufferMemory, nil)
-
-	return nil
+	return image, memory, nil
 }
 
-func (r *RenderSystem) createDescriptorSetLayout() error {
-	uboLayoutBinding := vk.DescriptorSetLayoutBinding{
-		Binding:            0,
-		DescriptorType:     vk.DescriptorTypeUniformBuffer,
-		DescriptorCount:    1,
-		StageFlags:         vk.ShaderStageFlags(vk.ShaderStageVertexBit),
-		PImmutableSamplers: []vk.Sampler{vk.NullSampler},
+// bindImageMemory allocates device local memory for the image and binds it.
+// No memory is left allocated if that fails, but the image is still the
+// caller's to destroy.
+func (r *RenderSystem) bindImageMemory(image vk.Image) (vk.DeviceMemory, error) {
+	var memory vk.DeviceMemory
+	var memRequirements vk.MemoryRequirements
+	vk.GetImageMemoryRequirements(r.device, image, &memRequirements)
+	memRequirements.Deref()
+	memType, err := r.findMemoryType(memRequirements.MemoryTypeBits, vk.MemoryPropertyFlags(vk.MemoryPropertyDeviceLocalBit))
+	if err != nil {
+		return nil, err
 	}
-	samplerLayoutBinding := vk.DescriptorSetLayoutBinding{
-		Binding:         1,
-		DescriptorCount: 1,
-		DescriptorType:  vk.DescriptorTypeCombinedImageSampler,
-		StageFlags:      vk.ShaderStageFlags(vk.ShaderStageFragmentBit),
+	if res := vk.AllocateMemory(r.device, &vk.MemoryAllocateInfo{
+		SType:           vk.StructureTypeMemoryAllocateInfo,
+		AllocationSize:  memRequirements.Size,
+		MemoryTypeIndex: memType,
+	}, nil, &memory); res != vk.Success {
+		return nil, errors.New("unable to allocate image memory")
 	}
-	bindings := []vk.DescriptorSetLayoutBinding{uboLayoutBinding, samplerLayoutBinding}
-
-	layoutInfo := vk.DescriptorSetLayoutCreateInfo{
-		SType:        vk.StructureTypeDescriptorSetLayoutCreateInfo,
-		BindingCount: uint32(len(bindings)),
-		PBindings:    bindings,
+	if res := vk.BindImageMemory(r.device, image, memory, 0); res != vk.Success {
+		vk.FreeMemory(r.device, memory, nil)
+		return nil, errors.New("unable to bind image memory")
 	}
+	return memory, nil
+}
 
-	var descriptorSetLayout vk.DescriptorSetLayout
-	if res := vk.CreateDescriptorSetLayout(r.device, &layoutInfo, nil, &descriptorSetLayout); res != vk.Success {
-		return errors.New("unable to create descriptor set layout")
+// createImageView creates a view of the whole of a 2D image.
+func (r *RenderSystem) createImageView(image vk.Image, format vk.Format, aspect vk.ImageAspectFlagBits) (vk.ImageView, error) {
+	var view vk.ImageView
+	if res := vk.CreateImageView(r.device, &vk.ImageViewCreateInfo{
+		SType:    vk.StructureTypeImageViewCreateInfo,
+		Image:    image,
+		ViewType: vk.ImageViewType2d,
+		Format:   format,
+		SubresourceRange: vk.ImageSubresourceRange{
+			AspectMask:     vk.ImageAspectFlags(aspect),
+			BaseMipLevel:   0,
+			LevelCount:     1,
+			BaseArrayLayer: 0,
+			LayerCount:     1,
+		},
+	}, nil, &view); res != vk.Success {
+		return view, errors.New("unable to create image view")
 	}
-	r.descriptorSetLayouts = append(r.descriptorSetLayouts, descriptorSetLayout)
-
-	return nil
+	return view, nil
 }
 
 func (r *RenderSystem) createUniformBuffers() error {
-	bufferSize := vk.DeviceSize(4 * 16 * 3)
+	bufferSize := vk.DeviceSize(uniformBufferSize)
 	var err error
 
 	numImages := len(r.images)
@@ -1141,50 +1391,62 @@ func (r *RenderSystem) createUniformBuffers() error {
 		if err != nil {
 			return err
 		}
+		r.setName(vk.ObjectTypeBuffer, unsafe.Pointer(r.uniformBuffers[i]), fmt.Sprintf("uniforms %d", i))
 	}
 
 	return nil
 }
 
+func (r *RenderSystem) destroyUniformBuffers() {
+	for i := range r.uniformBuffers {
+		vk.DestroyBuffer(r.device, r.uniformBuffers[i], nil)
+		vk.FreeMemory(r.device, r.uniformBuffersMemory[i], nil)
+	}
+	r.uniformBuffers, r.uniformBuffersMemory = nil, nil
+}
+
 func (r *RenderSystem) updateUniformBuffer(currentImageIdx uint32) error {
-	elapsed := time.Now().Sub(r.startTime)
+	return r.writeUniforms(r.uniformBuffersMemory[currentImageIdx], &r.camera, r.swapChainExtent)
+}
+
+// writeUniforms writes the UniformBufferObject of a camera drawing into an
+// image of the given size.
+func (r *RenderSystem) writeUniforms(memory vk.DeviceMemory, cam *Camera, extent vk.Extent2D) error {
+	// vulkan's clip space has y pointing down, so mapping top to -1 puts the
+	// origin in the top left of the screen like engo does
 	ubo := UniformBufferObject{
 		model:      mgl32.Ident4(),
-		view:       mgl32.Ident4(),
-		projection: mgl32.Ident4(),
+		view:       cam.viewMatrix(),
+		projection: mgl32.Ortho(0, float32(extent.Width), 0, float32(extent.Height), -1, 1),
 	}
-	ubo.model = ubo.model.Mul4(mgl32.HomogRotate3DZ(mgl32.DegToRad(90) * float32(elapsed.Seconds())))
-	ubo.view = ubo.view.Mul4(mgl32.LookAt(2, 2, 2, 0, 0, 0, 0, 0, 1))
-	aspect := float32(r.swapChainExtent.Width) / float32(r.swapChainExtent.Height)
-	ubo.projection = ubo.projection.Mul4(mgl32.Perspective(mgl32.DegToRad(45), aspect, 0.1, 10))
-	ubo.projection.Set(1, 1, ubo.projection.At(1, 1)*-1)
 
 	var data unsafe.Pointer
-	bufferSize := vk.DeviceSize(4 * 16 * 3)
-	vk.MapMemory(r.device, r.uniformBuffersMemory[currentImageIdx], 0, bufferSize, 0, &data)
+	bufferSize := vk.DeviceSize(uniformBufferSize)
+	vk.MapMemory(r.device, memory, 0, bufferSize, 0, &data)
 	n := vk.Memcopy(data, uniformData(ubo))
-	if n != 4*16*3 {
-		return errors.New("failed to copy vertex buffer data")
+	if n != uniformBufferSize {
+		return errors.New("failed to copy uniform buffer data")
 	}
-	vk.UnmapMemory(r.device, r.uniformBuffersMemory[currentImageIdx])
+	vk.UnmapMemory(r.device, memory)
 	return nil
 }
 
 func (r *RenderSystem) createDescriptorPool() error {
 	poolSize := vk.DescriptorPoolSize{
 		Type:            vk.DescriptorTypeUniformBuffer,
-		DescriptorCount: uint32(len(r.images)),
+		DescriptorCount: maxDescriptorSets,
 	}
 	imgSamplerSize := vk.DescriptorPoolSize{
 		Type:            vk.DescriptorTypeCombinedImageSampler,
-		DescriptorCount: uint32(len(r.images)),
+		DescriptorCount: maxDescriptorSets,
 	}
 	poolSizes := []vk.DescriptorPoolSize{poolSize, imgSamplerSize}
 	poolInfo := vk.DescriptorPoolCreateInfo{
 		SType:         vk.StructureTypeDescriptorPoolCreateInfo,
+		Flags:         vk.DescriptorPoolCreateFlags(vk.DescriptorPoolCreateFreeDescriptorSetBit),
 		PoolSizeCount: uint32(len(poolSizes)),
 		PPoolSizes:    poolSizes,
-		MaxSets:       uint32(len(r.images)),
+		MaxSets:       maxDescriptorSets,
 	}
 	var descriptorPool vk.DescriptorPool
 	if res := vk.CreateDescriptorPool(r.device, &poolInfo, nil, &descriptorPool); res != vk.Success {
@@ -1193,53 +1455,3 @@ func (r *RenderSystem) createDescriptorPool() error {
 	r.descriptorPool = descriptorPool
 	return nil
 }
-
-func (r *RenderSystem) createDescriptorSets() error {
-	r.descriptorSets = make([]vk.DescriptorSet, len(r.images))
-	for i := range r.images {
-		var set vk.DescriptorSet
-		if ret := vk.AllocateDescriptorSets(r.device, &vk.DescriptorSetAllocateInfo{
-			SType:              vk.StructureTypeDescriptorSetAllocateInfo,
-			DescriptorPool:     r.descriptorPool,
-			DescriptorSetCount: 1,
-			PSetLayouts:        r.descriptorSetLayouts,
-		}, &set); ret != vk.Success {
-			return errors.New("Unable to allocate descriptor set")
-		}
-		r.descriptorSets[i] = set
-		bufferInfo := vk.DescriptorBufferInfo{
-			Buffer: r.uniformBuffers[i],
-			Offset: 0,
-			Range:  vk.DeviceSize(int(unsafe.Sizeof(UniformBufferObject{}))),
-		}
-		imageInfos := make([]vk.DescriptorImageInfo, 0)
-		for _, tex := range theTextureLoader.images {
-			imageInfos = append(imageInfos, vk.DescriptorImageInfo{
-				ImageLayout: vk.ImageLayoutShaderReadOnlyOptimal,
-				ImageView:   tex.Texture.view,
-				Sampler:     tex.Texture.sampler,
-			})
-		}
-		descriptorWrite := vk.WriteDescriptorSet{
-			SType:           vk.StructureTypeWriteDescriptorSet,
-			DstSet:          r.descriptorSets[i],
-			DstBinding:      0,
-			DstArrayElement: 0,
-			DescriptorType:  vk.DescriptorTypeUniformBuffer,
-			DescriptorCount: 1,
-			PBufferInfo:     []vk.DescriptorBufferInfo{bufferInfo},
-		}
-		imgSamplerWrite := vk.WriteDescriptorSet{
-			SType:           vk.StructureTypeWriteDescriptorSet,
-			DstSet:          r.descriptorSets[i],
-			DstBinding:      1,
-			DstArrayElement: 0,
-			DescriptorType:  vk.DescriptorTypeCombinedImageSampler,
-			DescriptorCount: uint32(len(imageInfos)),
-			PImageInfo:      imageInfos,
-		}
-		writeDescriptorSets := []vk.WriteDescriptorSet{descriptorWrite, imgSamplerWrite}
-		vk.UpdateDescriptorSets(r.device, uint32(len(writeDescriptorSets)), writeDescriptorSets, 0, nil)
-	}
-	return nil
-}
@@ -0,0 +1,182 @@
+package vulkanRenderSystem
+
+import (
+	"errors"
+	"image"
+	"unsafe"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// ScreenshotFunc is called with a frame Screenshot read back, or why it
+// couldn't be
+type ScreenshotFunc func(img image.Image, err error)
+
+// screenshotCapture is the frame Screenshot reads back
+type screenshotCapture struct {
+	buffer hostBuffer
+	// requests are called with the next frame that's read back, and captured
+	// with the one the frame in flight frame is reading back
+	requests, captured []ScreenshotFunc
+	frame              int
+	format             vk.Format
+	extent             vk.Extent2D
+}
+
+// Screenshot reads back the next frame the RenderSystem draws. Nothing extra
+// is drawn, so it can be called from anywhere, like the Update of another
+// system. done is called from the Update of the RenderSystem once the GPU has
+// finished the frame, which is a couple of frames later.
+func (r *RenderSystem) Screenshot(done ScreenshotFunc) {
+	if !r.swapChainReadable {
+		done(nil, errors.New("the surface doesn't let the swap chain images be copied"))
+		return
+	}
+	if _, _, ok := pixelLayout(r.swapChainImageFormat); !ok {
+		done(nil, errors.New("unable to read images of the swap chain format"))
+		return
+	}
+	r.screenshot.requests = append(r.screenshot.requests, done)
+}
+
+// SaveScreenshot reads back the next frame like Screenshot and writes it to a
+// .png file at path, creating its directory. done is called with the error
+// there was, if it isn't nil.
+func (r *RenderSystem) SaveScreenshot(path string, done func(error)) {
+	r.Screenshot(func(img image.Image, err error) {
+		if err == nil {
+			err = WritePNG(path, img)
+		}
+		if done != nil {
+			done(err)
+		}
+	})
+}
+
+// recordScreenshot records reading the swap chain image back for Screenshot,
+// if a frame was asked for and the last one has been delivered.
+func (r *RenderSystem) recordScreenshot(buffer vk.CommandBuffer, imageIdx uint32) {
+	s := &r.screenshot
+	if len(s.requests) == 0 || len(s.captured) > 0 {
+		return
+	}
+	requests := s.requests
+	s.requests = nil
+	size, _, _ := pixelLayout(r.swapChainImageFormat)
+	n := vk.DeviceSize(size * int(r.swapChainExtent.Width) * int(r.swapChainExtent.Height))
+	if err := r.readbackBuffer(&s.buffer, n, "screenshot readback"); err != nil {
+		for _, done := range requests {
+			done(nil, err)
+		}
+		return
+	}
+	recordReadback(buffer, r.images[imageIdx], r.presentLayout(), s.buffer.buffer, r.swapChainExtent)
+	s.captured, s.frame = requests, r.currentFrame
+	s.format, s.extent = r.swapChainImageFormat, r.swapChainExtent
+}
+
+// collectScreenshot delivers the frame that was read back once the frame in
+// flight that read it has finished.
+func (r *RenderSystem) collectScreenshot() {
+	if s := &r.screenshot; len(s.captured) > 0 && s.frame == r.currentFrame {
+		r.deliverScreenshot()
+	}
+}
+
+// deliverScreenshot calls the requests that were captured with the frame. The
+// frame that read it back has to have finished.
+func (r *RenderSystem) deliverScreenshot() {
+	s := &r.screenshot
+	captured := s.captured
+	s.captured = nil
+	size, _, _ := pixelLayout(s.format)
+	n := vk.DeviceSize(size * int(s.extent.Width) * int(s.extent.Height))
+	var img image.Image
+	var err error
+	var data unsafe.Pointer
+	if res := vk.MapMemory(r.device, s.buffer.memory, 0, n, 0, &data); res != vk.Success {
+		err = errors.New("unable to map readback memory")
+	} else {
+		img = toRGBA((*[1 << 30]byte)(data)[:n:n], s.format, s.extent)
+		vk.UnmapMemory(r.device, s.buffer.memory)
+	}
+	for _, done := range captured {
+		done(img, err)
+	}
+}
+
+// recordReadback records copying an image that's in the layout into a
+// buffer, tightly packed, which the host can read once the commands finish.
+// The image is left in the layout.
+func recordReadback(buffer vk.CommandBuffer, img vk.Image, layout vk.ImageLayout, dst vk.Buffer, extent vk.Extent2D) {
+	subresource := vk.ImageSubresourceRange{
+		AspectMask: vk.ImageAspectFlags(vk.ImageAspectColorBit),
+		LevelCount: 1,
+		LayerCount: 1,
+	}
+	vk.CmdPipelineBarrier(buffer, vk.PipelineStageFlags(vk.PipelineStageColorAttachmentOutputBit), vk.PipelineStageFlags(vk.PipelineStageTransferBit), 0, 0, nil, 0, nil, 1, []vk.ImageMemoryBarrier{{
+		SType:               vk.StructureTypeImageMemoryBarrier,
+		SrcAccessMask:       vk.AccessFlags(vk.AccessColorAttachmentWriteBit),
+		DstAccessMask:       vk.AccessFlags(vk.AccessTransferReadBit),
+		OldLayout:           layout,
+		NewLayout:           vk.ImageLayoutTransferSrcOptimal,
+		SrcQueueFamilyIndex: vk.QueueFamilyIgnored,
+		DstQueueFamilyIndex: vk.QueueFamilyIgnored,
+		Image:               img,
+		SubresourceRange:    subresource,
+	}})
+	vk.CmdCopyImageToBuffer(buffer, img, vk.ImageLayoutTransferSrcOptimal, dst, 1, []vk.BufferImageCopy{{
+		ImageSubresource: vk.ImageSubresourceLayers{
+			AspectMask: vk.ImageAspectFlags(vk.ImageAspectColorBit),
+			LayerCount: 1,
+		},
+		ImageExtent: vk.Extent3D{Width: extent.Width, Height: extent.Height, Depth: 1},
+	}})
+	vk.CmdPipelineBarrier(buffer, vk.PipelineStageFlags(vk.PipelineStageTransferBit), vk.PipelineStageFlags(vk.PipelineStageBottomOfPipeBit), 0, 0, nil, 0, nil, 1, []vk.ImageMemoryBarrier{{
+		SType:               vk.StructureTypeImageMemoryBarrier,
+		SrcAccessMask:       vk.AccessFlags(vk.AccessTransferReadBit),
+		OldLayout:           vk.ImageLayoutTransferSrcOptimal,
+		NewLayout:           layout,
+		SrcQueueFamilyIndex: vk.QueueFamilyIgnored,
+		DstQueueFamilyIndex: vk.QueueFamilyIgnored,
+		Image:               img,
+		SubresourceRange:    subresource,
+	}})
+	vk.CmdPipelineBarrier(buffer, vk.PipelineStageFlags(vk.PipelineStageTransferBit), vk.PipelineStageFlags(vk.PipelineStageHostBit), 0, 1, []vk.MemoryBarrier{{
+		SType:         vk.StructureTypeMemoryBarrier,
+		SrcAccessMask: vk.AccessFlags(vk.AccessTransferWriteBit),
+		DstAccessMask: vk.AccessFlags(vk.AccessHostReadBit),
+	}}, 0, nil, 0, nil)
+}
+
+// pixelLayout returns the size in bytes of a pixel of the format, and whether
+// its blue comes before its red. ok is false for formats that can't be read.
+func pixelLayout(format vk.Format) (size int, bgr, ok bool) {
+	switch format {
+	case vk.FormatB8g8r8a8Unorm, vk.FormatB8g8r8a8Srgb:
+		return 4, true, true
+	case vk.FormatR8g8b8a8Unorm, vk.FormatR8g8b8a8Srgb:
+		return 4, false, true
+	case vk.FormatB8g8r8Unorm:
+		return 3, true, true
+	case vk.FormatR8g8b8Unorm:
+		return 3, false, true
+	}
+	return 0, false, false
+}
+
+// toRGBA converts tightly packed pixels of the format to an opaque RGBA
+// image. What's shown on the screen is opaque, so alpha is ignored.
+func toRGBA(data []byte, format vk.Format, extent vk.Extent2D) *image.RGBA {
+	size, bgr, _ := pixelLayout(format)
+	img := image.NewRGBA(image.Rect(0, 0, int(extent.Width), int(extent.Height)))
+	for i, j := 0, 0; j+size <= len(data) && i < len(img.Pix); i, j = i+4, j+size {
+		if bgr {
+			img.Pix[i], img.Pix[i+1], img.Pix[i+2] = data[j+2], data[j+1], data[j]
+		} else {
+			img.Pix[i], img.Pix[i+1], img.Pix[i+2] = data[j], data[j+1], data[j+2]
+		}
+		img.Pix[i+3] = 0xff
+	}
+	return img
+}
@@ -0,0 +1,127 @@
+package vulkanRenderSystem
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"sync/atomic"
+
+	"github.com/EngoEngine/engo"
+
+	"github.com/Noofbiz/vulkanRenderSystem/internal/shaders"
+	"github.com/Noofbiz/vulkanRenderSystem/internal/spirv"
+)
+
+// Shader is a vertex and fragment shader entities can be drawn with. Shaders
+// can read a vec2 position, vec4 color and vec2 texture coordinate from the
+// vertices at locations 0, 1 and 2. In descriptor set 0 they can have a
+// uniform buffer with the model, view and projection matrices, and a sampler2D
+// of the drawn texture, at any binding.
+type Shader struct {
+	// id identifies the shader in the pipeline cache
+	id         uint64
+	vert, frag []byte
+	iface      shaderInterface
+	// effect is whether the shader is an effect pass, which is reflected
+	// against what effects can use
+	effect bool
+	// previous has the code the shader had before it was last reloaded. It's
+	// put back if the new code fails to build a pipeline that's first used
+	// after the reload.
+	previous *Shader
+}
+
+// shaderIDs is the id of the last shader that was created
+var shaderIDs uint64
+
+// NewShader creates a shader from SPIR-V modules. A nil module uses the built
+// in shader for that stage. The modules are checked against each other and
+// what the RenderSystem provides.
+func NewShader(vert, frag []byte) (*Shader, error) {
+	var err error
+	if vert == nil {
+		if vert, err = shaders.Asset("vert.spv"); err != nil {
+			return nil, err
+		}
+	}
+	if frag == nil {
+		if frag, err = shaders.Asset("frag.spv"); err != nil {
+			return nil, err
+		}
+	}
+	iface, err := reflectShader(vert, frag)
+	if err != nil {
+		return nil, err
+	}
+	return &Shader{
+		id:    atomic.AddUint64(&shaderIDs, 1),
+		vert:  vert,
+		frag:  frag,
+		iface: iface,
+	}, nil
+}
+
+// LoadShader creates a shader from .spv files loaded through engo.Files. An
+// empty url uses the built in shader for that stage.
+func LoadShader(vertURL, fragURL string) (*Shader, error) {
+	var vert, frag []byte
+	if vertURL != "" {
+		res, err := engo.Files.Resource(vertURL)
+		if err != nil {
+			return nil, err
+		}
+		vert = res.(ShaderResource).Data
+	}
+	if fragURL != "" {
+		res, err := engo.Files.Resource(fragURL)
+		if err != nil {
+			return nil, err
+		}
+		frag = res.(ShaderResource).Data
+	}
+	return NewShader(vert, frag)
+}
+
+// ShaderResource is a SPIR-V module loaded from a .spv file
+type ShaderResource struct {
+	Data []byte
+	url  string
+}
+
+func (s ShaderResource) URL() string {
+	return s.url
+}
+
+type shaderLoader struct {
+	modules map[string]ShaderResource
+}
+
+var theShaderLoader = shaderLoader{modules: make(map[string]ShaderResource)}
+
+func (t *shaderLoader) Load(url string, data io.Reader) error {
+	spv, err := ioutil.ReadAll(data)
+	if err != nil {
+		return err
+	}
+	if _, err := spirv.Parse(spv); err != nil {
+		return errors.New("unable to load shader " + url + ": " + err.Error())
+	}
+	t.modules[url] = ShaderResource{spv, url}
+	return nil
+}
+
+func (t *shaderLoader) Unload(url string) error {
+	delete(t.modules, url)
+	return nil
+}
+
+func (t *shaderLoader) Resource(url string) (engo.Resource, error) {
+	if res, ok := t.modules[url]; ok {
+		return res, nil
+	}
+	return ShaderResource{}, errors.New("unable to locate resource with url: " + url)
+}
+
+func init() {
+	engo.Files.Register(".spv", &theShaderLoader)
+}
@@ -0,0 +1,379 @@
+package vulkanRenderSystem
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/EngoEngine/engo"
+)
+
+// TriangleType is the kind of triangle a Triangle draws
+type TriangleType uint8
+
+const (
+	// TriangleIsosceles has its top point in the middle of the top edge
+	TriangleIsosceles TriangleType = iota
+	// TriangleRight has its right angle in the bottom left corner
+	TriangleRight
+)
+
+// The shapes are Drawables that are tessellated into triangles every frame.
+// They're the size of the entity's SpaceComponent and filled with the
+// RenderComponent's Color. A transparent Color only draws the border.
+
+// Rectangle is a rectangle with an optional border
+type Rectangle struct {
+	shapeBase
+	BorderWidth float32
+	BorderColor color.Color
+}
+
+// Circle is an ellipse, or a slice of one, with an optional border
+type Circle struct {
+	shapeBase
+	BorderWidth float32
+	BorderColor color.Color
+	// Arc is the angle of the slice in degrees, starting from the right and
+	// going clockwise. Zero draws the whole circle.
+	Arc float32
+	// Segments is the number of segments a full circle is made of. Zero picks
+	// it from the size of the circle.
+	Segments int
+}
+
+// Triangle is a triangle with an optional border
+type Triangle struct {
+	shapeBase
+	TriangleType TriangleType
+	BorderWidth  float32
+	BorderColor  color.Color
+}
+
+// ComplexTriangles is a list of triangles with an optional border around
+// each of them
+type ComplexTriangles struct {
+	shapeBase
+	// Points are the corners of the triangles, three per triangle. They're
+	// relative to the size of the entity, so 1 is its width or height.
+	Points      []engo.Point
+	BorderWidth float32
+	BorderColor color.Color
+}
+
+// Polygon is a closed outline that can be concave, with an optional border
+type Polygon struct {
+	shapeBase
+	// Points are the corners of the outline in order. They're relative to the
+	// size of the entity, so 1 is its width or height.
+	Points      []engo.Point
+	BorderWidth float32
+	BorderColor color.Color
+}
+
+// shape is a Drawable that's made of triangles of the given size
+type shape interface {
+	Drawable
+	appendShape(b *geometryBatch, w, h float32, p placement, fill color.Color)
+}
+
+func shapeTexture() *Texture {
+	if theRenderSystem == nil {
+		return nil
+	}
+	return theRenderSystem.whiteTexture
+}
+
+// shapeBase is embedded in the shapes and lines to make them Drawables. They
+// have no size of their own, so they're drawn with the plain white texture at
+// the size of the SpaceComponent or through their points.
+type shapeBase struct{}
+
+// Texture returns the plain white texture shapes are drawn with
+func (shapeBase) Texture() *Texture { return shapeTexture() }
+
+// Width is zero, shapes are sized by the SpaceComponent or their points
+func (shapeBase) Width() float32 { return 0 }
+
+// Height is zero, shapes are sized by the SpaceComponent or their points
+func (shapeBase) Height() float32 { return 0 }
+
+// View returns the whole texture
+func (shapeBase) View() (float32, float32, float32, float32) { return 0, 0, 1, 1 }
+
+// placement moves points from the space of a drawable into the world,
+// rotating them around its position
+type placement struct {
+	x, y, sin, cos float32
+}
+
+func newPlacement(x, y, rotation float32) placement {
+	sin, cos := math.Sincos(float64(rotation) * math.Pi / 180)
+	return placement{x, y, float32(sin), float32(cos)}
+}
+
+func (p placement) apply(points [][2]float32) {
+	for i, pt := range points {
+		points[i] = [2]float32{
+			p.x + pt[0]*p.cos - pt[1]*p.sin,
+			p.y + pt[0]*p.sin + pt[1]*p.cos,
+		}
+	}
+}
+
+// transparent reports whether the color is fully transparent. Nil colors are
+// drawn white, so they aren't.
+func transparent(c color.Color) bool {
+	if c == nil {
+		return false
+	}
+	_, _, _, a := c.RGBA()
+	return a == 0
+}
+
+func (s Rectangle) appendShape(b *geometryBatch, w, h float32, p placement, fill color.Color) {
+	outline := [][2]float32{{0, 0}, {w, 0}, {w, h}, {0, h}}
+	appendOutline(b, outline, []uint32{0, 1, 2, 2, 3, 0}, s.BorderWidth, s.BorderColor, p, fill)
+}
+
+func (s Triangle) appendShape(b *geometryBatch, w, h float32, p placement, fill color.Color) {
+	outline := [][2]float32{{w / 2, 0}, {w, h}, {0, h}}
+	if s.TriangleType == TriangleRight {
+		outline[0][0] = 0
+	}
+	appendOutline(b, outline, []uint32{0, 1, 2}, s.BorderWidth, s.BorderColor, p, fill)
+}
+
+func (s Polygon) appendShape(b *geometryBatch, w, h float32, p placement, fill color.Color) {
+	outline := make([][2]float32, len(s.Points))
+	for i, pt := range s.Points {
+		outline[i] = [2]float32{pt.X * w, pt.Y * h}
+	}
+	appendOutline(b, outline, triangulate(outline), s.BorderWidth, s.BorderColor, p, fill)
+}
+
+func (s ComplexTriangles) appendShape(b *geometryBatch, w, h float32, p placement, fill color.Color) {
+	for i := 0; i+2 < len(s.Points); i += 3 {
+		outline := [][2]float32{
+			{s.Points[i].X * w, s.Points[i].Y * h},
+			{s.Points[i+1].X * w, s.Points[i+1].Y * h},
+			{s.Points[i+2].X * w, s.Points[i+2].Y * h},
+		}
+		appendOutline(b, outline, []uint32{0, 1, 2}, s.BorderWidth, s.BorderColor, p, fill)
+	}
+}
+
+func (s Circle) appendShape(b *geometryBatch, w, h float32, p placement, fill color.Color) {
+	rx, ry := w/2, h/2
+	arc := float64(s.Arc)
+	if arc <= 0 || arc >= 360 {
+		arc = 360
+	}
+	segments := s.Segments
+	if segments <= 0 {
+		// about one segment every 8 pixels around the edge
+		segments = int(2 * math.Pi * float64(max32(rx, ry)) / 8)
+		if segments < 12 {
+			segments = 12
+		} else if segments > 256 {
+			segments = 256
+		}
+	}
+	n := int(math.Ceil(float64(segments) * arc / 360))
+	if n < 1 {
+		n = 1
+	}
+	edge := func(rx, ry float32) [][2]float32 {
+		points := make([][2]float32, 0, n+1)
+		for i := 0; i <= n; i++ {
+			if arc == 360 && i == n {
+				break
+			}
+			sin, cos := math.Sincos(arc * math.Pi / 180 * float64(i) / float64(n))
+			points = append(points, [2]float32{rx + rx*float32(cos), ry + ry*float32(sin)})
+		}
+		return points
+	}
+	tex := shapeTexture()
+
+	if !transparent(fill) {
+		outer := edge(rx, ry)
+		points := append([][2]float32{{rx, ry}}, outer...)
+		indices := make([]uint32, 0, 3*len(outer))
+		for i := 1; i < len(points); i++ {
+			next := i + 1
+			if next == len(points) {
+				if arc != 360 {
+					break
+				}
+				next = 1
+			}
+			indices = append(indices, 0, uint32(i), uint32(next))
+		}
+		p.apply(points)
+		b.addTriangles(tex, points, indices, colorToVertex(fill))
+	}
+
+	if s.BorderWidth <= 0 || transparent(s.BorderColor) {
+		return
+	}
+	bw := s.BorderWidth
+	outer := edge(rx, ry)
+	inner := edge(max32(rx-bw, 0), max32(ry-bw, 0))
+	// the inner edge is centered in the same place as the outer one
+	for i := range inner {
+		inner[i][0] += min32(bw, rx)
+		inner[i][1] += min32(bw, ry)
+	}
+	appendRing(b, tex, outer, inner, arc == 360, p, colorToVertex(s.BorderColor))
+}
+
+// appendOutline fills the triangles of an outline and adds a border along the
+// inside of it.
+func appendOutline(b *geometryBatch, outline [][2]float32, indices []uint32, bw float32, bc color.Color, p placement, fill color.Color) {
+	if len(outline) < 3 {
+		return
+	}
+	tex := shapeTexture()
+	if !transparent(fill) && len(indices) > 0 {
+		points := append([][2]float32(nil), outline...)
+		p.apply(points)
+		b.addTriangles(tex, points, indices, colorToVertex(fill))
+	}
+	if bw <= 0 || transparent(bc) {
+		return
+	}
+	appendRing(b, tex, append([][2]float32(nil), outline...), insetOutline(outline, bw), true, p, colorToVertex(bc))
+}
+
+// appendRing adds the quads between the points of an outer and inner edge
+func appendRing(b *geometryBatch, tex *Texture, outer, inner [][2]float32, closed bool, p placement, c [4]float32) {
+	n := len(outer)
+	points := append(outer, inner...)
+	p.apply(points)
+	indices := make([]uint32, 0, 6*n)
+	for i := 0; i < n; i++ {
+		next := i + 1
+		if next == n {
+			if !closed {
+				break
+			}
+			next = 0
+		}
+		o0, o1 := uint32(i), uint32(next)
+		i0, i1 := uint32(n+i), uint32(n+next)
+		indices = append(indices, o0, o1, i1, i1, i0, o0)
+	}
+	b.addTriangles(tex, points, indices, c)
+}
+
+// insetOutline moves every point of the outline inwards by d, keeping the
+// edges parallel to the original ones.
+func insetOutline(outline [][2]float32, d float32) [][2]float32 {
+	n := len(outline)
+	// the inside is to the right of the edges of clockwise outlines on screen
+	sign := float32(1)
+	if signedArea(outline) < 0 {
+		sign = -1
+	}
+	inset := make([][2]float32, n)
+	for i := range outline {
+		prev, cur, next := outline[(i+n-1)%n], outline[i], outline[(i+1)%n]
+		n0 := edgeNormal(prev, cur, sign)
+		n1 := edgeNormal(cur, next, sign)
+		mx, my := n0[0]+n1[0], n0[1]+n1[1]
+		l := float32(math.Hypot(float64(mx), float64(my)))
+		if l < 1e-6 {
+			inset[i] = [2]float32{cur[0] + n0[0]*d, cur[1] + n0[1]*d}
+			continue
+		}
+		mx, my = mx/l, my/l
+		// sharp corners are limited so the border doesn't spike out
+		scale := d / max32(mx*n0[0]+my*n0[1], 0.25)
+		inset[i] = [2]float32{cur[0] + mx*scale, cur[1] + my*scale}
+	}
+	return inset
+}
+
+// edgeNormal returns the unit normal of the edge from a to b, pointing inside
+// of an outline with the given winding
+func edgeNormal(a, b [2]float32, sign float32) [2]float32 {
+	dx, dy := b[0]-a[0], b[1]-a[1]
+	l := float32(math.Hypot(float64(dx), float64(dy)))
+	if l == 0 {
+		return [2]float32{}
+	}
+	return [2]float32{-dy / l * sign, dx / l * sign}
+}
+
+// signedArea is positive for outlines that go clockwise on screen
+func signedArea(points [][2]float32) float32 {
+	var a float32
+	for i := range points {
+		j := (i + 1) % len(points)
+		a += points[i][0]*points[j][1] - points[j][0]*points[i][1]
+	}
+	return a / 2
+}
+
+// triangulate splits a simple polygon, which can be concave, into triangles
+// by clipping ears. Polygons that can't be clipped, because they intersect
+// themselves, are filled with a fan instead.
+func triangulate(points [][2]float32) []uint32 {
+	n := len(points)
+	if n < 3 {
+		return nil
+	}
+	remaining := make([]int, n)
+	for i := range remaining {
+		remaining[i] = i
+	}
+	sign := float32(1)
+	if signedArea(points) < 0 {
+		sign = -1
+	}
+	var indices []uint32
+	for len(remaining) > 3 {
+		clipped := false
+		for i := range remaining {
+			m := len(remaining)
+			a, b, c := remaining[(i+m-1)%m], remaining[i], remaining[(i+1)%m]
+			if !isEar(points, remaining, a, b, c, sign) {
+				continue
+			}
+			indices = append(indices, uint32(a), uint32(b), uint32(c))
+			remaining = append(remaining[:i], remaining[i+1:]...)
+			clipped = true
+			break
+		}
+		if !clipped {
+			for i := 1; i+1 < len(remaining); i++ {
+				indices = append(indices, uint32(remaining[0]), uint32(remaining[i]), uint32(remaining[i+1]))
+			}
+			return indices
+		}
+	}
+	return append(indices, uint32(remaining[0]), uint32(remaining[1]), uint32(remaining[2]))
+}
+
+func cross(a, b, c [2]float32) float32 {
+	return (b[0]-a[0])*(c[1]-a[1]) - (b[1]-a[1])*(c[0]-a[0])
+}
+
+// isEar reports whether the corner at b is convex and no other point of the
+// polygon is inside the triangle a, b, c.
+func isEar(points [][2]float32, remaining []int, a, b, c int, sign float32) bool {
+	pa, pb, pc := points[a], points[b], points[c]
+	if cross(pa, pb, pc)*sign <= 0 {
+		return false
+	}
+	for _, i := range remaining {
+		if i == a || i == b || i == c {
+			continue
+		}
+		p := points[i]
+		if cross(pa, pb, p)*sign >= 0 && cross(pb, pc, p)*sign >= 0 && cross(pc, pa, p)*sign >= 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,449 @@
+package vulkanRenderSystem
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"unsafe"
+
+	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/systems/physics"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// targetFormat is the format of the color images of render targets. It's unorm
+// like the swap chain, so what's drawn into a target looks the same as it does
+// on the screen.
+const targetFormat = vk.FormatR8g8b8a8Unorm
+
+// RenderTarget is an offscreen image that entities and levels are drawn into
+// before the screen is drawn. It's a Drawable, so what's drawn into it can be
+// drawn like any other texture, including into targets created after it.
+type RenderTarget struct {
+	// Camera is the view of the world drawn into the target
+	Camera Camera
+	// Scene draws the entities and levels of the RenderSystem into the target
+	// instead of its own, like a minimap. Entities that draw the target are
+	// left out.
+	Scene bool
+	// ClearColor is what the target is cleared to before drawing. Nil clears
+	// it to transparent.
+	ClearColor color.Color
+	// Hidden stops drawing into the target, keeping what was drawn last
+	Hidden bool
+
+	texture     Texture
+	extent      vk.Extent2D
+	depth       bool
+	depthImage  vk.Image
+	depthMem    vk.DeviceMemory
+	depthView   vk.ImageView
+	framebuffer vk.Framebuffer
+
+	entities []renderEntity
+	levels   []*Level
+
+	batch          geometryBatch
+	vertexBuffers  []hostBuffer
+	indexBuffers   []hostBuffer
+	uniformBuffers []vk.Buffer
+	uniformMemory  []vk.DeviceMemory
+}
+
+// NewRenderTarget creates a render target of the given size in pixels. With
+// depth the target gets a depth image, which is cleared every frame.
+func NewRenderTarget(width, height int, depth bool) (*RenderTarget, error) {
+	r := theRenderSystem
+	if r == nil {
+		return nil, errors.New("tried to create a RenderTarget without a vulkan render system setup")
+	}
+	if width <= 0 || height <= 0 {
+		return nil, errors.New("render targets need a positive width and height")
+	}
+	t := &RenderTarget{
+		extent: vk.Extent2D{Width: uint32(width), Height: uint32(height)},
+		depth:  depth,
+	}
+	if err := r.createTarget(t); err != nil {
+		r.destroyTarget(t)
+		return nil, err
+	}
+	r.targets = append(r.targets, t)
+	return t, nil
+}
+
+// createTarget creates the images, framebuffer and buffers of the target.
+func (r *RenderSystem) createTarget(t *RenderTarget) error {
+	pass, err := r.targetPass(t.depth)
+	if err != nil {
+		return err
+	}
+	if err = r.createColorImage(&t.texture, t.extent); err != nil {
+		return err
+	}
+	r.nameTexture(&t.texture, "render target")
+	// hidden targets are drawn before anything was drawn into them
+	if err = r.transitionImageLayout(t.texture.image, targetFormat, vk.ImageLayoutUndefined, vk.ImageLayoutShaderReadOnlyOptimal); err != nil {
+		return err
+	}
+	attachments := []vk.ImageView{t.texture.view}
+	if t.depth {
+		t.depthImage, t.depthMem, err = r.createImage(t.extent.Width, t.extent.Height, r.depthFormat,
+			vk.ImageUsageFlags(vk.ImageUsageDepthStencilAttachmentBit))
+		if err != nil {
+			return err
+		}
+		if t.depthView, err = r.createImageView(t.depthImage, r.depthFormat, vk.ImageAspectDepthBit); err != nil {
+			return err
+		}
+		r.setName(vk.ObjectTypeImage, unsafe.Pointer(t.depthImage), "render target depth")
+		r.setName(vk.ObjectTypeImageView, unsafe.Pointer(t.depthView), "render target depth")
+		attachments = append(attachments, t.depthView)
+	}
+	if res := vk.CreateFramebuffer(r.device, &vk.FramebufferCreateInfo{
+		SType:           vk.StructureTypeFramebufferCreateInfo,
+		RenderPass:      pass,
+		AttachmentCount: uint32(len(attachments)),
+		PAttachments:    attachments,
+		Width:           t.extent.Width,
+		Height:          t.extent.Height,
+		Layers:          1,
+	}, nil, &t.framebuffer); res != vk.Success {
+		return errors.New("failed to create render target framebuffer")
+	}
+	return r.createTargetBuffers(t)
+}
+
+// createTargetBuffers creates the buffers the target has one of per swap chain
+// image.
+func (r *RenderSystem) createTargetBuffers(t *RenderTarget) error {
+	var err error
+	t.vertexBuffers = make([]hostBuffer, len(r.images))
+	t.indexBuffers = make([]hostBuffer, len(r.images))
+	t.uniformBuffers = make([]vk.Buffer, len(r.images))
+	t.uniformMemory = make([]vk.DeviceMemory, len(r.images))
+	for i := range r.images {
+		t.vertexBuffers[i].usage = vk.BufferUsageFlags(vk.BufferUsageVertexBufferBit)
+		t.indexBuffers[i].usage = vk.BufferUsageFlags(vk.BufferUsageIndexBufferBit)
+		t.vertexBuffers[i].name = fmt.Sprintf("render target vertices %d", i)
+		t.indexBuffers[i].name = fmt.Sprintf("render target indices %d", i)
+		t.uniformBuffers[i], t.uniformMemory[i], err = r.createBuffer(vk.DeviceSize(uniformBufferSize),
+			vk.BufferUsageFlags(vk.BufferUsageUniformBufferBit),
+			vk.MemoryPropertyFlags(vk.MemoryPropertyHostVisibleBit|vk.MemoryPropertyHostCoherentBit))
+		if err != nil {
+			return err
+		}
+		r.setName(vk.ObjectTypeBuffer, unsafe.Pointer(t.uniformBuffers[i]), fmt.Sprintf("render target uniforms %d", i))
+	}
+	return nil
+}
+
+// createColorImage creates an image of the target format that can be drawn
+// into and then sampled, with its view and sampler.
+func (r *RenderSystem) createColorImage(tex *Texture, extent vk.Extent2D) error {
+	var err error
+	tex.texWidth, tex.texHeight = int32(extent.Width), int32(extent.Height)
+	tex.image, tex.mem, err = r.createImage(extent.Width, extent.Height, targetFormat,
+		vk.ImageUsageFlags(vk.ImageUsageColorAttachmentBit|vk.ImageUsageSampledBit))
+	if err != nil {
+		return err
+	}
+	if tex.view, err = r.createImageView(tex.image, targetFormat, vk.ImageAspectColorBit); err != nil {
+		return err
+	}
+	if res := vk.CreateSampler(r.device, &vk.SamplerCreateInfo{
+		SType:                   vk.StructureTypeSamplerCreateInfo,
+		MagFilter:               vk.FilterLinear,
+		MinFilter:               vk.FilterLinear,
+		AddressModeU:            vk.SamplerAddressModeClampToEdge,
+		AddressModeV:            vk.SamplerAddressModeClampToEdge,
+		AddressModeW:            vk.SamplerAddressModeClampToEdge,
+		BorderColor:             vk.BorderColorIntOpaqueBlack,
+		UnnormalizedCoordinates: vk.Bool32(vk.False),
+		CompareOp:               vk.CompareOpAlways,
+		MipmapMode:              vk.SamplerMipmapModeLinear,
+	}, nil, &tex.sampler); res != vk.Success {
+		return errors.New("failed to create sampler")
+	}
+	return nil
+}
+
+// Destroy frees the target and stops drawing into it. Entities shouldn't draw
+// it anymore.
+func (t *RenderTarget) Destroy() {
+	r := theRenderSystem
+	for i, target := range r.targets {
+		if target == t {
+			r.targets = append(r.targets[:i], r.targets[i+1:]...)
+			break
+		}
+	}
+	vk.DeviceWaitIdle(r.device)
+	r.releaseSets(func(key textureSetKey) bool { return key.target == t || key.texture == &t.texture })
+	r.destroyTarget(t)
+}
+
+// destroyTarget destroys whatever of the target was created.
+func (r *RenderSystem) destroyTarget(t *RenderTarget) {
+	r.destroyTargetBuffers(t)
+	vk.DestroyFramebuffer(r.device, t.framebuffer, nil)
+	vk.DestroyImageView(r.device, t.depthView, nil)
+	vk.DestroyImage(r.device, t.depthImage, nil)
+	vk.FreeMemory(r.device, t.depthMem, nil)
+	t.texture.Destroy(r.device)
+}
+
+// destroyTargetBuffers destroys the per swap chain image buffers of the target.
+func (r *RenderSystem) destroyTargetBuffers(t *RenderTarget) {
+	for i := range t.uniformBuffers {
+		r.destroyHostBuffer(&t.vertexBuffers[i])
+		r.destroyHostBuffer(&t.indexBuffers[i])
+		vk.DestroyBuffer(r.device, t.uniformBuffers[i], nil)
+		vk.FreeMemory(r.device, t.uniformMemory[i], nil)
+	}
+	t.vertexBuffers, t.indexBuffers = nil, nil
+	t.uniformBuffers, t.uniformMemory = nil, nil
+}
+
+// Texture returns the color image of the target, so it can be drawn
+func (t *RenderTarget) Texture() *Texture {
+	return &t.texture
+}
+
+func (t *RenderTarget) Width() float32 {
+	return float32(t.extent.Width)
+}
+
+func (t *RenderTarget) Height() float32 {
+	return float32(t.extent.Height)
+}
+
+// View returns the uvs of the whole target
+func (t *RenderTarget) View() (float32, float32, float32, float32) {
+	return 0, 0, 1, 1
+}
+
+// Add adds an entity that's drawn into the target. The entity can be in the
+// RenderSystem as well.
+func (t *RenderTarget) Add(basic *ecs.BasicEntity, render *RenderComponent, space *physics.SpaceComponent) {
+	t.entities = append(t.entities, renderEntity{basic, space, render})
+}
+
+// Remove removes an entity from the target.
+func (t *RenderTarget) Remove(e ecs.BasicEntity) {
+	for i, entity := range t.entities {
+		if entity.ID() == e.ID() {
+			t.entities = append(t.entities[:i], t.entities[i+1:]...)
+			return
+		}
+	}
+}
+
+// AddLevel adds the layers of a level that are drawn into the target.
+func (t *RenderTarget) AddLevel(l *Level) {
+	t.levels = append(t.levels, l)
+}
+
+// RemoveLevel removes a level from the target.
+func (t *RenderTarget) RemoveLevel(l *Level) {
+	for i, level := range t.levels {
+		if level == l {
+			t.levels = append(t.levels[:i], t.levels[i+1:]...)
+			// the chunks are built again if the level is still drawn elsewhere
+			theRenderSystem.releaseLevel(l)
+			return
+		}
+	}
+}
+
+// targetPass returns the render pass targets with or without depth are drawn
+// with, creating it the first time it's used. Once drawn, the color image can
+// be sampled by fragment shaders.
+func (r *RenderSystem) targetPass(depth bool) (vk.RenderPass, error) {
+	i := 0
+	if depth {
+		i = 1
+	}
+	if r.targetPasses[i] != nil {
+		return r.targetPasses[i], nil
+	}
+	attachments := []vk.AttachmentDescription{{
+		Format:         targetFormat,
+		Samples:        passSamples,
+		LoadOp:         vk.AttachmentLoadOpClear,
+		StoreOp:        vk.AttachmentStoreOpStore,
+		StencilLoadOp:  vk.AttachmentLoadOpDontCare,
+		StencilStoreOp: vk.AttachmentStoreOpDontCare,
+		InitialLayout:  vk.ImageLayoutUndefined,
+		FinalLayout:    vk.ImageLayoutShaderReadOnlyOptimal,
+	}}
+	subpass := vk.SubpassDescription{
+		PipelineBindPoint:    vk.PipelineBindPointGraphics,
+		ColorAttachmentCount: 1,
+		PColorAttachments: []vk.AttachmentReference{{
+			Attachment: 0,
+			Layout:     vk.ImageLayoutColorAttachmentOptimal,
+		}},
+	}
+	// the last frame can still be reading the image, and the next passes
+	// read what's written
+	srcStage := vk.PipelineStageFragmentShaderBit | vk.PipelineStageColorAttachmentOutputBit
+	dstStage := vk.PipelineStageColorAttachmentOutputBit
+	dstAccess := vk.AccessColorAttachmentWriteBit
+	if depth {
+		if r.depthFormat == vk.FormatUndefined {
+			format, err := r.findDepthFormat()
+			if err != nil {
+				return nil, err
+			}
+			r.depthFormat = format
+		}
+		attachments = append(attachments, vk.AttachmentDescription{
+			Format:         r.depthFormat,
+			Samples:        passSamples,
+			LoadOp:         vk.AttachmentLoadOpClear,
+			StoreOp:        vk.AttachmentStoreOpDontCare,
+			StencilLoadOp:  vk.AttachmentLoadOpDontCare,
+			StencilStoreOp: vk.AttachmentStoreOpDontCare,
+			InitialLayout:  vk.ImageLayoutUndefined,
+			FinalLayout:    vk.ImageLayoutDepthStencilAttachmentOptimal,
+		})
+		subpass.PDepthStencilAttachment = &vk.AttachmentReference{
+			Attachment: 1,
+			Layout:     vk.ImageLayoutDepthStencilAttachmentOptimal,
+		}
+		srcStage |= vk.PipelineStageEarlyFragmentTestsBit | vk.PipelineStageLateFragmentTestsBit
+		dstStage |= vk.PipelineStageEarlyFragmentTestsBit
+		dstAccess |= vk.AccessDepthStencilAttachmentWriteBit
+	}
+	dependencies := []vk.SubpassDependency{{
+		SrcSubpass:    vk.SubpassExternal,
+		DstSubpass:    0,
+		SrcStageMask:  vk.PipelineStageFlags(srcStage),
+		DstStageMask:  vk.PipelineStageFlags(dstStage),
+		DstAccessMask: vk.AccessFlags(dstAccess),
+	}, {
+		SrcSubpass:    0,
+		DstSubpass:    vk.SubpassExternal,
+		SrcStageMask:  vk.PipelineStageFlags(vk.PipelineStageColorAttachmentOutputBit),
+		SrcAccessMask: vk.AccessFlags(vk.AccessColorAttachmentWriteBit),
+		DstStageMask:  vk.PipelineStageFlags(vk.PipelineStageFragmentShaderBit),
+		DstAccessMask: vk.AccessFlags(vk.AccessShaderReadBit),
+	}}
+	if res := vk.CreateRenderPass(r.device, &vk.RenderPassCreateInfo{
+		SType:           vk.StructureTypeRenderPassCreateInfo,
+		AttachmentCount: uint32(len(attachments)),
+		PAttachments:    attachments,
+		SubpassCount:    1,
+		PSubpasses:      []vk.SubpassDescription{subpass},
+		DependencyCount: uint32(len(dependencies)),
+		PDependencies:   dependencies,
+	}, nil, &r.targetPasses[i]); res != vk.Success {
+		return nil, errors.New("failed to create render target render pass")
+	}
+	if depth {
+		r.setName(vk.ObjectTypeRenderPass, unsafe.Pointer(r.targetPasses[i]), "render target depth pass")
+	} else {
+		r.setName(vk.ObjectTypeRenderPass, unsafe.Pointer(r.targetPasses[i]), "render target pass")
+	}
+	return r.targetPasses[i], nil
+}
+
+// findDepthFormat returns the first depth format the device can draw into.
+// Formats with stencil would need their views to include it, so they're left
+// out.
+func (r *RenderSystem) findDepthFormat() (vk.Format, error) {
+	for _, format := range []vk.Format{vk.FormatD32Sfloat, vk.FormatD16Unorm} {
+		var props vk.FormatProperties
+		vk.GetPhysicalDeviceFormatProperties(r.gpu, format, &props)
+		props.Deref()
+		if props.OptimalTilingFeatures&vk.FormatFeatureFlags(vk.FormatFeatureDepthStencilAttachmentBit) != 0 {
+			return format, nil
+		}
+	}
+	return vk.FormatUndefined, errors.New("no supported depth format")
+}
+
+// allLevels returns the levels of the RenderSystem and its targets, once each.
+func (r *RenderSystem) allLevels() []*Level {
+	levels := r.levels
+	for _, t := range r.targets {
+	next:
+		for _, l := range t.levels {
+			for _, have := range levels {
+				if have == l {
+					continue next
+				}
+			}
+			levels = append(levels, l)
+		}
+	}
+	return levels
+}
+
+// buildTargets collects the geometry drawn into each target.
+func (r *RenderSystem) buildTargets() {
+	for _, t := range r.targets {
+		if t.Hidden {
+			continue
+		}
+		t.batch.reset()
+		view := t.Camera.view(t.Width(), t.Height())
+		if t.Scene {
+			appendScene(&t.batch, &t.Camera, view, r.entities, r.levels, &t.texture)
+		} else {
+			appendScene(&t.batch, &t.Camera, view, t.entities, t.levels, &t.texture)
+		}
+	}
+}
+
+// uploadTargets copies the geometry and uniforms of the targets into their
+// buffers for the swap chain image.
+func (r *RenderSystem) uploadTargets(imageIdx uint32) error {
+	for _, t := range r.targets {
+		if t.Hidden {
+			continue
+		}
+		if err := r.writeHostBuffer(&t.vertexBuffers[imageIdx], vertexData(t.batch.vertices)); err != nil {
+			return err
+		}
+		if err := r.writeHostBuffer(&t.indexBuffers[imageIdx], indexData(t.batch.indices)); err != nil {
+			return err
+		}
+		if err := r.writeUniforms(t.uniformMemory[imageIdx], &t.Camera, t.extent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordTarget records drawing the geometry of a target into it.
+func (r *RenderSystem) recordTarget(buffer vk.CommandBuffer, imageIdx uint32, t *RenderTarget) error {
+	clear := []float32{0, 0, 0, 0}
+	if t.ClearColor != nil {
+		cr, cg, cb, ca := t.ClearColor.RGBA()
+		clear = []float32{float32(cr) / 0xffff, float32(cg) / 0xffff, float32(cb) / 0xffff, float32(ca) / 0xffff}
+	}
+	clearValues := []vk.ClearValue{vk.NewClearValue(clear)}
+	if t.depth {
+		clearValues = append(clearValues, vk.NewClearDepthStencil(1, 0))
+	}
+	renderPassInfo := vk.RenderPassBeginInfo{
+		SType:           vk.StructureTypeRenderPassBeginInfo,
+		RenderPass:      r.targetPasses[0],
+		Framebuffer:     t.framebuffer,
+		ClearValueCount: uint32(len(clearValues)),
+		PClearValues:    clearValues,
+	}
+	if t.depth {
+		renderPassInfo.RenderPass = r.targetPasses[1]
+	}
+	renderPassInfo.RenderArea.Extent = t.extent
+	r.beginPass(buffer, fmt.Sprintf("render target %dx%d", t.extent.Width, t.extent.Height), targetLabelColor)
+	vk.CmdBeginRenderPass(buffer, &renderPassInfo, vk.SubpassContentsInline)
+	err := r.recordDraws(buffer, imageIdx, &t.batch, t.vertexBuffers[imageIdx].buffer, t.indexBuffers[imageIdx].buffer, t, targetFormat)
+	vk.CmdEndRenderPass(buffer)
+	r.endPass(buffer)
+	return err
+}
@@ -0,0 +1,479 @@
+package vulkanRenderSystem
+
+import (
+	"errors"
+	"image/color"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// TextAlign is the horizontal alignment of the lines in a TextBlock
+type TextAlign uint8
+
+const (
+	// TextAlignLeft lines up every line with the left edge of the block
+	TextAlignLeft TextAlign = iota
+	// TextAlignCenter centers every line within the block
+	TextAlignCenter
+	// TextAlignRight lines up every line with the right edge of the block
+	TextAlignRight
+	// TextAlignJustify stretches the spaces of wrapped lines so they fill the
+	// whole width. The last line of a paragraph is left aligned.
+	TextAlignJustify
+)
+
+// defaultTextSize is the font size of a TextBlock that has no Size
+const defaultTextSize = 12
+
+// FaceFunc returns the font face to use for text of the given size. It's
+// called once per size used by a TextBlock, so [size] spans can pick a
+// different face.
+type FaceFunc func(size float64) font.Face
+
+// TextBlock is a block of text that can be wrapped, aligned and styled with
+// inline markup. Markup is made of [color=#rrggbb]...[/color] and
+// [size=24]...[/size] spans, which can be nested. Use [[ for a literal [.
+type TextBlock struct {
+	// Text is the text to lay out, including markup
+	Text string
+	// Face provides the font face for each size used in the text
+	Face FaceFunc
+	// Size is the default font size. Not defining Size will default to 12.
+	Size float64
+	// Color is the default text color. Not defining Color will default to white.
+	Color color.Color
+	// MaxWidth is the width at which lines are wrapped. Zero disables wrapping.
+	MaxWidth float32
+	// Align is the horizontal alignment of the lines
+	Align TextAlign
+	// LineSpacing is multiplied with the line height. Not defining LineSpacing
+	// will default to 1.
+	LineSpacing float32
+}
+
+// TextLayout is the result of laying out a TextBlock. All positions are
+// relative to the top left of the block.
+type TextLayout struct {
+	Lines []TextLine
+	// Width and Height are the bounding box of the laid out text
+	Width, Height float32
+}
+
+// TextLine is a single line of laid out text.
+type TextLine struct {
+	Glyphs []Glyph
+	// Baseline is the y position of the line's baseline
+	Baseline float32
+	// Width is the width of the line, including justified spaces
+	Width float32
+	// Height is the height of the line, including line spacing
+	Height float32
+}
+
+// Glyph is a single positioned rune in a TextLayout.
+type Glyph struct {
+	Rune rune
+	// X and Y are the position of the glyph's origin on the baseline
+	X, Y    float32
+	Advance float32
+	Size    float64
+	Color   color.Color
+	Face    font.Face
+}
+
+type textStyle struct {
+	size  float64
+	color color.Color
+}
+
+type styledRune struct {
+	r rune
+	textStyle
+}
+
+type textWord struct {
+	runes []styledRune
+	// space is the width of the whitespace in front of the word
+	space    float32
+	width    float32
+	advances []float32
+}
+
+type lineBuilder struct {
+	words []textWord
+	width float32
+}
+
+// Measure lays out the block and returns the size of its bounding box, so
+// space can be reserved before anything is rendered.
+func (t *TextBlock) Measure() (float32, float32, error) {
+	l, err := t.Layout()
+	if err != nil {
+		return 0, 0, err
+	}
+	return l.Width, l.Height, nil
+}
+
+// Layout parses the markup in the block, wraps it at MaxWidth and aligns each
+// line.
+func (t *TextBlock) Layout() (*TextLayout, error) {
+	if t.Face == nil {
+		return nil, errors.New("text block has no font face")
+	}
+	def := textStyle{size: t.Size, color: t.Color}
+	if def.size <= 0 {
+		def.size = defaultTextSize
+	}
+	if def.color == nil {
+		def.color = color.White
+	}
+	runes, err := parseTextMarkup(t.Text, def)
+	if err != nil {
+		return nil, err
+	}
+	// every face is looked up before laying out, so a missing one is an
+	// error rather than a panic halfway through
+	faces := make(map[float64]font.Face)
+	for _, size := range append([]float64{def.size}, runeSizes(runes)...) {
+		if _, ok := faces[size]; ok {
+			continue
+		}
+		f := t.Face(size)
+		if f == nil {
+			return nil, errors.New("text block has no font face for size " + strconv.FormatFloat(size, 'g', -1, 64))
+		}
+		faces[size] = f
+	}
+	face := func(size float64) font.Face {
+		return faces[size]
+	}
+	spacing := t.LineSpacing
+	if spacing == 0 {
+		spacing = 1
+	}
+
+	layout := &TextLayout{}
+	var top float32
+	for _, paragraph := range splitParagraphs(runes) {
+		words := t.measureWords(paragraph, face)
+		lines := t.wrap(words)
+		if len(lines) == 0 {
+			// an empty paragraph still takes up a line
+			lines = append(lines, lineBuilder{})
+		}
+		for i, lb := range lines {
+			size := def.size
+			if len(paragraph) > 0 {
+				size = paragraph[0].size
+			}
+			for _, w := range lb.words {
+				for _, sr := range w.runes {
+					if sr.size > size {
+						size = sr.size
+					}
+				}
+			}
+			m := face(size).Metrics()
+			ascent, descent := fixedToFloat(m.Ascent), fixedToFloat(m.Descent)
+			height := fixedToFloat(m.Height) * spacing
+			line := TextLine{
+				Baseline: top + ascent,
+				Width:    lb.width,
+				Height:   height,
+			}
+			if ascent+descent > height {
+				line.Height = ascent + descent
+			}
+
+			var offset, extra float32
+			lastInParagraph := i == len(lines)-1
+			if t.MaxWidth > 0 {
+				switch t.Align {
+				case TextAlignCenter:
+					offset = (t.MaxWidth - lb.width) / 2
+				case TextAlignRight:
+					offset = t.MaxWidth - lb.width
+				case TextAlignJustify:
+					if !lastInParagraph && len(lb.words) > 1 {
+						extra = (t.MaxWidth - lb.width) / float32(len(lb.words)-1)
+						line.Width = t.MaxWidth
+					}
+				}
+			} else if t.Align == TextAlignCenter || t.Align == TextAlignRight {
+				// without a max width, lines are aligned with the widest one
+				// once it's known
+				offset = -lb.width
+				if t.Align == TextAlignCenter {
+					offset /= 2
+				}
+			}
+
+			x := offset
+			for j, w := range lb.words {
+				if j > 0 {
+					x += w.space + extra
+				}
+				for k, sr := range w.runes {
+					line.Glyphs = append(line.Glyphs, Glyph{
+						Rune:    sr.r,
+						X:       x,
+						Y:       line.Baseline,
+						Advance: w.advances[k],
+						Size:    sr.size,
+						Color:   sr.color,
+						Face:    face(sr.size),
+					})
+					x += w.advances[k]
+				}
+			}
+			if line.Width > layout.Width {
+				layout.Width = line.Width
+			}
+			layout.Lines = append(layout.Lines, line)
+			top += line.Height
+		}
+	}
+	layout.Height = top
+	if t.MaxWidth > 0 {
+		if t.Align != TextAlignLeft && layout.Width < t.MaxWidth {
+			layout.Width = t.MaxWidth
+		}
+		return layout, nil
+	}
+
+	// shift lines that were aligned against zero over by the widest line
+	var shift float32
+	switch t.Align {
+	case TextAlignCenter:
+		shift = layout.Width / 2
+	case TextAlignRight:
+		shift = layout.Width
+	}
+	if shift != 0 {
+		for i := range layout.Lines {
+			for j := range layout.Lines[i].Glyphs {
+				layout.Lines[i].Glyphs[j].X += shift
+			}
+		}
+	}
+	return layout, nil
+}
+
+// measureWords splits a paragraph into words and measures each of them with
+// the face for its size. Kerning is applied between the runes of a word.
+func (t *TextBlock) measureWords(paragraph []styledRune, face func(float64) font.Face) []textWord {
+	var words []textWord
+	var cur textWord
+	var space float32
+	prev := rune(-1)
+	flush := func() {
+		if len(cur.runes) == 0 {
+			return
+		}
+		cur.space = space
+		words = append(words, cur)
+		cur = textWord{}
+		space = 0
+	}
+	for _, sr := range paragraph {
+		f := face(sr.size)
+		adv, _ := f.GlyphAdvance(sr.r)
+		if unicode.IsSpace(sr.r) {
+			flush()
+			space += fixedToFloat(adv)
+			prev = -1
+			continue
+		}
+		a := fixedToFloat(adv)
+		if prev >= 0 && len(cur.advances) > 0 {
+			k := fixedToFloat(f.Kern(prev, sr.r))
+			cur.advances[len(cur.advances)-1] += k
+			cur.width += k
+		}
+		cur.runes = append(cur.runes, sr)
+		cur.advances = append(cur.advances, a)
+		cur.width += a
+		prev = sr.r
+	}
+	flush()
+	return words
+}
+
+// wrap greedily fills lines with words until MaxWidth is reached. Words that
+// are wider than MaxWidth on their own are broken between runes.
+func (t *TextBlock) wrap(words []textWord) []lineBuilder {
+	var lines []lineBuilder
+	var cur lineBuilder
+	for _, w := range words {
+		if t.MaxWidth <= 0 {
+			cur.add(w)
+			continue
+		}
+		if len(cur.words) > 0 && cur.width+w.space+w.width > t.MaxWidth {
+			lines = append(lines, cur)
+			cur = lineBuilder{}
+		}
+		for len(cur.words) == 0 && w.width > t.MaxWidth && len(w.runes) > 1 {
+			head, tail := splitWord(w, t.MaxWidth)
+			cur.add(head)
+			lines = append(lines, cur)
+			cur = lineBuilder{}
+			w = tail
+		}
+		cur.add(w)
+	}
+	if len(cur.words) > 0 {
+		lines = append(lines, cur)
+	}
+	return lines
+}
+
+func (l *lineBuilder) add(w textWord) {
+	if len(l.words) == 0 {
+		w.space = 0
+	}
+	l.width += w.space + w.width
+	l.words = append(l.words, w)
+}
+
+// splitWord breaks w after as many runes as fit in width, always keeping at
+// least one rune in the head.
+func splitWord(w textWord, width float32) (textWord, textWord) {
+	var used float32
+	n := 0
+	for n < len(w.runes)-1 && (n == 0 || used+w.advances[n] <= width) {
+		used += w.advances[n]
+		n++
+	}
+	head := textWord{
+		runes:    w.runes[:n],
+		advances: w.advances[:n],
+		width:    used,
+	}
+	tail := textWord{
+		runes:    w.runes[n:],
+		advances: w.advances[n:],
+		width:    w.width - used,
+	}
+	return head, tail
+}
+
+// runeSizes returns the sizes the runes are styled with, once each.
+func runeSizes(runes []styledRune) []float64 {
+	var sizes []float64
+	seen := make(map[float64]bool)
+	for _, sr := range runes {
+		if !seen[sr.size] {
+			seen[sr.size] = true
+			sizes = append(sizes, sr.size)
+		}
+	}
+	return sizes
+}
+
+func splitParagraphs(runes []styledRune) [][]styledRune {
+	var paragraphs [][]styledRune
+	start := 0
+	for i, sr := range runes {
+		if sr.r == '\n' {
+			paragraphs = append(paragraphs, runes[start:i])
+			start = i + 1
+		}
+	}
+	return append(paragraphs, runes[start:])
+}
+
+// parseTextMarkup turns marked up text into a list of runes with their style.
+func parseTextMarkup(text string, def textStyle) ([]styledRune, error) {
+	var out []styledRune
+	stack := []textStyle{def}
+	var tags []string
+	for i := 0; i < len(text); {
+		if strings.HasPrefix(text[i:], "[[") {
+			out = append(out, styledRune{'[', stack[len(stack)-1]})
+			i += 2
+			continue
+		}
+		if text[i] != '[' {
+			r, n := utf8.DecodeRuneInString(text[i:])
+			out = append(out, styledRune{r, stack[len(stack)-1]})
+			i += n
+			continue
+		}
+		end := strings.IndexByte(text[i:], ']')
+		if end < 0 {
+			return nil, errors.New("unterminated markup tag at offset " + strconv.Itoa(i))
+		}
+		tag := text[i+1 : i+end]
+		i += end + 1
+		if strings.HasPrefix(tag, "/") {
+			name := tag[1:]
+			if len(tags) == 0 || tags[len(tags)-1] != name {
+				return nil, errors.New("unexpected closing markup tag [" + tag + "]")
+			}
+			tags = tags[:len(tags)-1]
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		style := stack[len(stack)-1]
+		eq := strings.IndexByte(tag, '=')
+		if eq < 0 {
+			return nil, errors.New("markup tag [" + tag + "] has no value")
+		}
+		name, value := tag[:eq], tag[eq+1:]
+		switch name {
+		case "color":
+			c, err := parseHexColor(value)
+			if err != nil {
+				return nil, err
+			}
+			style.color = c
+		case "size":
+			s, err := strconv.ParseFloat(value, 64)
+			if err != nil || s <= 0 {
+				return nil, errors.New("invalid markup size: " + value)
+			}
+			style.size = s
+		default:
+			return nil, errors.New("unknown markup tag [" + name + "]")
+		}
+		tags = append(tags, name)
+		stack = append(stack, style)
+	}
+	if len(tags) > 0 {
+		return nil, errors.New("markup tag [" + tags[len(tags)-1] + "] was never closed")
+	}
+	return out, nil
+}
+
+// parseHexColor parses #rgb, #rrggbb and #rrggbbaa colors.
+func parseHexColor(s string) (color.Color, error) {
+	invalid := errors.New("invalid markup color: " + s)
+	if !strings.HasPrefix(s, "#") {
+		return nil, invalid
+	}
+	s = s[1:]
+	if len(s) == 3 {
+		s = string([]byte{s[0], s[0], s[1], s[1], s[2], s[2]})
+	}
+	if len(s) == 6 {
+		s += "ff"
+	}
+	if len(s) != 8 {
+		return nil, invalid
+	}
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return nil, invalid
+	}
+	return color.NRGBA{R: uint8(v >> 24), G: uint8(v >> 16), B: uint8(v >> 8), A: uint8(v)}, nil
+}
+
+func fixedToFloat(i fixed.Int26_6) float32 {
+	return float32(i) / 64
+}
@@ -0,0 +1,267 @@
+package vulkanRenderSystem
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// monoFace is a fixed width face for tests. Runes are half the size wide,
+// spaces a quarter, and lines are the size tall.
+type monoFace struct {
+	size float64
+}
+
+func testFace(size float64) font.Face {
+	return monoFace{size}
+}
+
+func (f monoFace) px(v float64) fixed.Int26_6 {
+	return fixed.Int26_6(v * 64)
+}
+
+func (f monoFace) Close() error { return nil }
+
+func (f monoFace) Glyph(dot fixed.Point26_6, r rune) (image.Rectangle, image.Image, image.Point, fixed.Int26_6, bool) {
+	adv, ok := f.GlyphAdvance(r)
+	return image.Rectangle{}, nil, image.Point{}, adv, ok
+}
+
+func (f monoFace) GlyphBounds(r rune) (fixed.Rectangle26_6, fixed.Int26_6, bool) {
+	adv, ok := f.GlyphAdvance(r)
+	return fixed.Rectangle26_6{}, adv, ok
+}
+
+func (f monoFace) GlyphAdvance(r rune) (fixed.Int26_6, bool) {
+	if r == ' ' {
+		return f.px(f.size / 4), true
+	}
+	return f.px(f.size / 2), true
+}
+
+func (f monoFace) Kern(r0, r1 rune) fixed.Int26_6 { return 0 }
+
+func (f monoFace) Metrics() font.Metrics {
+	return font.Metrics{
+		Height:  f.px(f.size),
+		Ascent:  f.px(f.size * 0.75),
+		Descent: f.px(f.size * 0.25),
+	}
+}
+
+func TestParseTextMarkup(t *testing.T) {
+	red := color.NRGBA{R: 0xff, A: 0xff}
+	def := textStyle{size: 12, color: color.White}
+	runes, err := parseTextMarkup("a[color=#f00]b[size=20]c[/size][/color][[d", def)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []styledRune{
+		{'a', def},
+		{'b', textStyle{12, red}},
+		{'c', textStyle{20, red}},
+		{'[', def},
+		{'d', def},
+	}
+	if len(runes) != len(want) {
+		t.Fatalf("got %d runes, want %d", len(runes), len(want))
+	}
+	for i := range want {
+		if runes[i] != want[i] {
+			t.Errorf("rune %d is %+v, want %+v", i, runes[i], want[i])
+		}
+	}
+}
+
+func TestParseTextMarkupErrors(t *testing.T) {
+	for _, text := range []string{
+		"[color=#fff",
+		"[color=#fff]a[/size]",
+		"[color=#fff]a",
+		"a[/color]",
+		"[bold=1]a[/bold]",
+		"[size]a[/size]",
+		"[size=-1]a[/size]",
+		"[size=big]a[/size]",
+		"[color=fff]a[/color]",
+		"[color=#ffff]a[/color]",
+		"[color=#ggg]a[/color]",
+	} {
+		if _, err := parseTextMarkup(text, textStyle{size: 12}); err == nil {
+			t.Errorf("%q parsed without an error", text)
+		}
+	}
+}
+
+func TestParseHexColor(t *testing.T) {
+	for s, want := range map[string]color.NRGBA{
+		"#f80":      {0xff, 0x88, 0x00, 0xff},
+		"#102030":   {0x10, 0x20, 0x30, 0xff},
+		"#10203040": {0x10, 0x20, 0x30, 0x40},
+	} {
+		c, err := parseHexColor(s)
+		if err != nil {
+			t.Errorf("%s: %v", s, err)
+			continue
+		}
+		if c != want {
+			t.Errorf("%s is %v, want %v", s, c, want)
+		}
+	}
+}
+
+// lineText returns the runes of the words of each line.
+func lineText(lines []lineBuilder) []string {
+	var out []string
+	for _, l := range lines {
+		var words []string
+		for _, w := range l.words {
+			var b strings.Builder
+			for _, sr := range w.runes {
+				b.WriteRune(sr.r)
+			}
+			words = append(words, b.String())
+		}
+		out = append(out, strings.Join(words, " "))
+	}
+	return out
+}
+
+func TestWrap(t *testing.T) {
+	for _, test := range []struct {
+		text     string
+		maxWidth float32
+		want     []string
+	}{
+		// runes are 6 wide and spaces 3 at size 12
+		{"aa bb cc", 0, []string{"aa bb cc"}},
+		{"aa bb cc", 27, []string{"aa bb", "cc"}},
+		{"aa bb cc", 26, []string{"aa", "bb", "cc"}},
+		{"aaaaa b", 15, []string{"aa", "aa", "a b"}},
+		{"aaaaa", 5, []string{"a", "a", "a", "a", "a"}},
+	} {
+		tb := &TextBlock{MaxWidth: test.maxWidth}
+		runes, err := parseTextMarkup(test.text, textStyle{size: 12})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := lineText(tb.wrap(tb.measureWords(runes, testFace)))
+		if strings.Join(got, "|") != strings.Join(test.want, "|") {
+			t.Errorf("%q wrapped at %v is %q, want %q", test.text, test.maxWidth, got, test.want)
+		}
+	}
+}
+
+func TestLayout(t *testing.T) {
+	tb := &TextBlock{Text: "ab cd\nef", Face: testFace, Size: 12, MaxWidth: 30, Align: TextAlignRight}
+	l, err := tb.Layout()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(l.Lines) != 2 {
+		t.Fatalf("got %d lines, want 2", len(l.Lines))
+	}
+	if l.Width != 30 || l.Height != 24 {
+		t.Errorf("layout is %vx%v, want 30x24", l.Width, l.Height)
+	}
+	first := l.Lines[0]
+	if first.Width != 27 || first.Baseline != 9 {
+		t.Errorf("first line is %v wide with its baseline at %v, want 27 and 9", first.Width, first.Baseline)
+	}
+	// right aligned lines start at MaxWidth less their width
+	if x := first.Glyphs[0].X; x != 3 {
+		t.Errorf("first glyph is at %v, want 3", x)
+	}
+	if x := first.Glyphs[2].X; x != 18 {
+		t.Errorf("the glyph after the space is at %v, want 18", x)
+	}
+	if x := l.Lines[1].Glyphs[0].X; x != 18 {
+		t.Errorf("the second line starts at %v, want 18", x)
+	}
+	if y := l.Lines[1].Glyphs[0].Y; y != 21 {
+		t.Errorf("the second line's glyphs are at %v, want 21", y)
+	}
+}
+
+func TestLayoutJustify(t *testing.T) {
+	tb := &TextBlock{Text: "aa bb cc", Face: testFace, Size: 12, MaxWidth: 30, Align: TextAlignJustify}
+	l, err := tb.Layout()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(l.Lines) != 2 {
+		t.Fatalf("got %d lines, want 2", len(l.Lines))
+	}
+	// the spare 3 pixels of the first line go into its only space
+	if first := l.Lines[0]; first.Width != 30 || first.Glyphs[2].X != 18 {
+		t.Errorf("the first line is %v wide with bb at %v, want 30 and 18", first.Width, first.Glyphs[2].X)
+	}
+	// the last line of a paragraph isn't stretched
+	if last := l.Lines[1]; last.Width != 12 || last.Glyphs[0].X != 0 {
+		t.Errorf("the last line is %v wide at %v, want 12 and 0", last.Width, last.Glyphs[0].X)
+	}
+}
+
+func TestLayoutCenterWithoutMaxWidth(t *testing.T) {
+	tb := &TextBlock{Text: "aaaa\naa", Face: testFace, Size: 12, Align: TextAlignCenter}
+	l, err := tb.Layout()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// lines are centered on the widest one
+	if l.Width != 24 || l.Lines[0].Glyphs[0].X != 0 || l.Lines[1].Glyphs[0].X != 6 {
+		t.Errorf("the lines start at %v and %v in %v, want 0 and 6 in 24", l.Lines[0].Glyphs[0].X, l.Lines[1].Glyphs[0].X, l.Width)
+	}
+}
+
+func TestLayoutSizes(t *testing.T) {
+	var sizes []float64
+	tb := &TextBlock{Text: "a[size=20]b[/size]", Face: func(size float64) font.Face {
+		sizes = append(sizes, size)
+		return testFace(size)
+	}}
+	l, err := tb.Layout()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sizes) != 2 || sizes[0] != defaultTextSize || sizes[1] != 20 {
+		t.Errorf("faces were made for sizes %v, want [%v 20]", sizes, defaultTextSize)
+	}
+	// the line is as tall as its largest size
+	if l.Height != 20 || l.Lines[0].Baseline != 15 {
+		t.Errorf("the line is %v tall with its baseline at %v, want 20 and 15", l.Height, l.Lines[0].Baseline)
+	}
+}
+
+func TestLayoutErrors(t *testing.T) {
+	if _, err := (&TextBlock{Text: "a"}).Layout(); err == nil {
+		t.Error("a block without a face laid out without an error")
+	}
+	tb := &TextBlock{Text: "a[size=20]b[/size]", Face: func(size float64) font.Face {
+		if size == 20 {
+			return nil
+		}
+		return testFace(size)
+	}}
+	if _, err := tb.Layout(); err == nil {
+		t.Error("a size without a face laid out without an error")
+	}
+	if _, err := (&TextBlock{Text: "[size=20]a", Face: testFace}).Layout(); err == nil {
+		t.Error("bad markup laid out without an error")
+	}
+}
+
+func TestMeasure(t *testing.T) {
+	tb := &TextBlock{Text: "ab cd", Face: testFace, Size: 12, LineSpacing: 1.5}
+	w, h, err := tb.Measure()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w != 27 || h != 18 {
+		t.Errorf("measured %vx%v, want 27x18", w, h)
+	}
+}
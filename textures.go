@@ -51,6 +51,16 @@ func (t *Texture) Height() float32 {
 	return float32(t.texHeight)
 }
 
+// Texture returns the texture itself, so a Texture can be used as a Drawable
+func (t *Texture) Texture() *Texture {
+	return t
+}
+
+// View returns the uvs of the whole texture
+func (t *Texture) View() (float32, float32, float32, float32) {
+	return 0, 0, 1, 1
+}
+
 type TextureResource struct {
 	Texture *Texture
 	url     string
@@ -61,9 +71,12 @@ func NewTextureResource(img image.Image, url string) TextureResource {
 		panic("tried to create NewTextureResource without a vulkan render system setup.")
 	}
 
-	tex := &Texture{}
-
 	bounds := img.Bounds()
+	tex := &Texture{
+		texWidth:  int32(bounds.Dx()),
+		texHeight: int32(bounds.Dy()),
+	}
+
 	nrgba := image.NewNRGBA(bounds)
 	draw.Draw(nrgba, bounds, img, image.ZP, draw.Src)
 	imgSize := vk.DeviceSize(4 * bounds.Dx() * bounds.Dy())
@@ -130,7 +143,7 @@ func NewTextureResource(img image.Image, url string) TextureResource {
 	if err != nil {
 		panic("[VULKAN RENDER SYSTEM] unable to do first layout transition for image with url: " + url + "\n The error was: " + err.Error())
 	}
-	err = theRenderSystem.copyBufferToImage(stagingBuffer, tex.image, uint32(bounds.Dx()), uint32(bounds.Dy()))
+	err = theRenderSystem.copyBufferToImage(stagingBuffer, tex.image, uint32(bounds.Dx()), uint32(bounds.Dy()), 1)
 	if err != nil {
 		panic("[VULKAN RENDER SYSTEM] unable to copy buffer to image for image with url: " + url + "\n The error was: " + err.Error())
 	}
@@ -186,6 +199,7 @@ func NewTextureResource(img image.Image, url string) TextureResource {
 		panic("[VULKAN RENDER SYSTEM] failed to create texture sampler for url: " + url)
 	}
 	tex.sampler = sampler
+	theRenderSystem.nameTexture(tex, url)
 
 	return TextureResource{tex, url}
 }
@@ -222,6 +236,7 @@ func (t *textureLoader) Load(url string, data io.Reader) error {
 
 func (t *textureLoader) Unload(url string) error {
 	texRes := t.images[url]
+	theRenderSystem.releaseTexture(texRes.Texture)
 	texRes.Texture.Destroy(theRenderSystem.device)
 	delete(t.images, url)
 	return nil
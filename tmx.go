@@ -0,0 +1,695 @@
+package vulkanRenderSystem
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/xml"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/EngoEngine/engo"
+)
+
+// Flags stored in the high bits of a tile's global id
+const (
+	tileFlippedHorizontally uint32 = 0x80000000
+	tileFlippedVertically   uint32 = 0x40000000
+	tileFlippedDiagonally   uint32 = 0x20000000
+	tileRotatedHexagonal120 uint32 = 0x10000000
+	tileGIDMask                    = ^(tileFlippedHorizontally | tileFlippedVertically | tileFlippedDiagonally | tileRotatedHexagonal120)
+)
+
+type tmxProperty struct {
+	Name  string `xml:"name,attr"`
+	Type  string `xml:"type,attr"`
+	Value string `xml:"value,attr"`
+	Text  string `xml:",chardata"`
+}
+
+type tmxImage struct {
+	Source string `xml:"source,attr"`
+	Width  int    `xml:"width,attr"`
+	Height int    `xml:"height,attr"`
+}
+
+type tmxTilesetTile struct {
+	ID         uint32        `xml:"id,attr"`
+	Image      *tmxImage     `xml:"image"`
+	Properties []tmxProperty `xml:"properties>property"`
+	Animation  []struct {
+		TileID   uint32 `xml:"tileid,attr"`
+		Duration int    `xml:"duration,attr"`
+	} `xml:"animation>frame"`
+}
+
+type tmxTileset struct {
+	FirstGID   uint32 `xml:"firstgid,attr"`
+	Source     string `xml:"source,attr"`
+	Name       string `xml:"name,attr"`
+	TileWidth  int    `xml:"tilewidth,attr"`
+	TileHeight int    `xml:"tileheight,attr"`
+	Spacing    int    `xml:"spacing,attr"`
+	Margin     int    `xml:"margin,attr"`
+	TileCount  int    `xml:"tilecount,attr"`
+	Columns    int    `xml:"columns,attr"`
+	TileOffset struct {
+		X int `xml:"x,attr"`
+		Y int `xml:"y,attr"`
+	} `xml:"tileoffset"`
+	Image      *tmxImage        `xml:"image"`
+	Tiles      []tmxTilesetTile `xml:"tile"`
+	Properties []tmxProperty    `xml:"properties>property"`
+}
+
+type tmxData struct {
+	Encoding    string `xml:"encoding,attr"`
+	Compression string `xml:"compression,attr"`
+	Tiles       []struct {
+		GID uint32 `xml:"gid,attr"`
+	} `xml:"tile"`
+	Text string `xml:",chardata"`
+}
+
+// tmxLayer is any of the layer elements of a map. XMLName tells which kind
+// of layer it is.
+type tmxLayer struct {
+	XMLName    xml.Name
+	Name       string        `xml:"name,attr"`
+	Width      int           `xml:"width,attr"`
+	Height     int           `xml:"height,attr"`
+	Opacity    *float32      `xml:"opacity,attr"`
+	Visible    *int          `xml:"visible,attr"`
+	OffsetX    float32       `xml:"offsetx,attr"`
+	OffsetY    float32       `xml:"offsety,attr"`
+	ParallaxX  *float32      `xml:"parallaxx,attr"`
+	ParallaxY  *float32      `xml:"parallaxy,attr"`
+	Color      string        `xml:"color,attr"`
+	DrawOrder  string        `xml:"draworder,attr"`
+	Data       tmxData       `xml:"data"`
+	Image      *tmxImage     `xml:"image"`
+	Objects    []tmxObject   `xml:"object"`
+	Properties []tmxProperty `xml:"properties>property"`
+}
+
+type tmxMap struct {
+	Orientation   string        `xml:"orientation,attr"`
+	RenderOrder   string        `xml:"renderorder,attr"`
+	StaggerAxis   string        `xml:"staggeraxis,attr"`
+	StaggerIndex  string        `xml:"staggerindex,attr"`
+	HexSideLength int           `xml:"hexsidelength,attr"`
+	Width         int           `xml:"width,attr"`
+	Height        int           `xml:"height,attr"`
+	TileWidth     int           `xml:"tilewidth,attr"`
+	TileHeight    int           `xml:"tileheight,attr"`
+	Infinite      int           `xml:"infinite,attr"`
+	Properties    []tmxProperty `xml:"properties>property"`
+	Tilesets      []tmxTileset  `xml:"tileset"`
+	Layers        []tmxLayer    `xml:",any"`
+}
+
+// Level is a map loaded from a .tmx or .ldtk file. Add it to the RenderSystem
+// with AddLevel to draw its layers.
+type Level struct {
+	// Name is the identifier of LDtk levels. It's empty for Tiled maps.
+	Name string
+	// Orientation is the orientation of the map, as set in Tiled
+	Orientation string
+	// RenderOrder is the order tiles are drawn in, such as "right-down". It's
+	// only used by orthogonal maps.
+	RenderOrder string
+	// StaggerAxis is "x" or "y" for staggered and hexagonal maps
+	StaggerAxis string
+	// StaggerIndex is "odd" or "even" for staggered and hexagonal maps
+	StaggerIndex string
+	// HexSideLength is the length of the flat sides of hexagonal tiles
+	HexSideLength int
+	// Width and Height are the size of the map in tiles
+	Width, Height int
+	// TileWidth and TileHeight are the size of a single map tile in pixels
+	TileWidth, TileHeight int
+	Properties            map[string]string
+	Tilesets              []*Tileset
+	TileLayers            []*TileLayer
+	ObjectLayers          []*ObjectLayer
+	ImageLayers           []*ImageLayer
+	// Zindex is the drawing order of the level compared to the entities of
+	// the RenderSystem
+	Zindex int
+
+	// layers are all the layers in the order they're drawn in
+	layers []interface{}
+	// elapsed is the time in seconds the level's animations have been running
+	elapsed float32
+}
+
+// Tileset is a set of tiles that share an image, or a collection of tiles
+// with an image each.
+type Tileset struct {
+	FirstGID              uint32
+	Name                  string
+	TileWidth, TileHeight int
+	Spacing, Margin       int
+	TileCount, Columns    int
+	// OffsetX and OffsetY are added to the position of every tile drawn from
+	// this set
+	OffsetX, OffsetY int
+	// Image is the url of the tileset's image. It's empty for image collection
+	// tilesets.
+	Image                   string
+	ImageWidth, ImageHeight int
+	Properties              map[string]string
+	TileProperties          map[uint32]map[string]string
+	// Animations are the frames of the animated tiles, by tile id
+	Animations map[uint32][]AnimationFrame
+	tileImages map[uint32]tmxImage
+}
+
+// AnimationFrame is a single frame of an animated tile.
+type AnimationFrame struct {
+	// ID is the id of the tile shown during the frame
+	ID uint32
+	// Duration is how long the frame is shown in seconds
+	Duration float32
+}
+
+// TileLayer is a layer of tiles in a Level.
+type TileLayer struct {
+	Name          string
+	Width, Height int
+	// Opacity is the alpha the tiles are drawn with, from 0 to 1
+	Opacity float32
+	Visible bool
+	// OffsetX and OffsetY are the offset of the layer in pixels
+	OffsetX, OffsetY float32
+	// ParallaxX and ParallaxY are how fast the layer scrolls compared to the
+	// camera. 1 moves with the camera, 0 stays on screen.
+	ParallaxX, ParallaxY float32
+	Properties           map[string]string
+	// Tiles are the tiles of the layer, row by row. Empty tiles have a nil
+	// Tileset. Use SetTile to change them once the layer has been drawn.
+	Tiles []Tile
+	// Values are the values of LDtk IntGrid layers, row by row. It's nil for
+	// other layers.
+	Values []int
+
+	chunks                 []*tileChunk
+	chunksWide, chunksHigh int
+}
+
+// Tile is a single tile of a TileLayer.
+type Tile struct {
+	// ID is the id of the tile within its tileset
+	ID      uint32
+	Tileset *Tileset
+	// FlipH, FlipV and FlipD are set when the tile is flipped horizontally,
+	// vertically or along its anti-diagonal
+	FlipH, FlipV, FlipD bool
+}
+
+// TMXResource is the resource of a loaded .tmx file
+type TMXResource struct {
+	Level *Level
+	url   string
+}
+
+// URL returns the url of the .tmx file
+func (r TMXResource) URL() string {
+	return r.url
+}
+
+// TilesetResource is the resource of a loaded .tsx file
+type TilesetResource struct {
+	Tileset *Tileset
+	url     string
+}
+
+// URL returns the url of the .tsx file
+func (r TilesetResource) URL() string {
+	return r.url
+}
+
+// TileAt returns the tile at the given tile coordinates, or nil if they're
+// outside the layer.
+func (l *TileLayer) TileAt(x, y int) *Tile {
+	if x < 0 || y < 0 || x >= l.Width || y >= l.Height {
+		return nil
+	}
+	return &l.Tiles[y*l.Width+x]
+}
+
+// Empty reports whether there's no tile placed here.
+func (t *Tile) Empty() bool {
+	return t.Tileset == nil
+}
+
+// region returns the url of the image the tile is drawn from, the uvs of the
+// tile within it and the size of the tile in pixels.
+func (t *Tileset) region(id uint32) (string, [4]float32, float32, float32) {
+	if img, ok := t.tileImages[id]; ok {
+		return img.Source, [4]float32{0, 0, 1, 1}, float32(img.Width), float32(img.Height)
+	}
+	if t.Image == "" || t.Columns == 0 {
+		return "", [4]float32{}, 0, 0
+	}
+	col, row := int(id)%t.Columns, int(id)/t.Columns
+	x := float32(t.Margin + col*(t.TileWidth+t.Spacing))
+	y := float32(t.Margin + row*(t.TileHeight+t.Spacing))
+	w, h := float32(t.ImageWidth), float32(t.ImageHeight)
+	return t.Image, [4]float32{
+		x / w,
+		y / h,
+		(x + float32(t.TileWidth)) / w,
+		(y + float32(t.TileHeight)) / h,
+	}, float32(t.TileWidth), float32(t.TileHeight)
+}
+
+// frame returns the id of the tile that's shown for the tile id after the
+// given time in seconds.
+func (t *Tileset) frame(id uint32, elapsed float32) uint32 {
+	frames, ok := t.Animations[id]
+	if !ok {
+		return id
+	}
+	var total float32
+	for _, f := range frames {
+		total += f.Duration
+	}
+	if total <= 0 {
+		return id
+	}
+	elapsed = float32(math.Mod(float64(elapsed), float64(total)))
+	for _, f := range frames {
+		if elapsed < f.Duration {
+			return f.ID
+		}
+		elapsed -= f.Duration
+	}
+	return frames[len(frames)-1].ID
+}
+
+// tileUVs returns the uvs of the corners of a tile, clockwise from the top
+// left, with its flip flags applied.
+func tileUVs(t *Tile, uv [4]float32) [4][2]float32 {
+	corners := [4][2]float32{{0, 0}, {1, 0}, {1, 1}, {0, 1}}
+	var out [4][2]float32
+	for i, c := range corners {
+		// tiled flips the image diagonally before flipping it horizontally
+		// and vertically, so the uvs are flipped in the opposite order
+		u, v := c[0], c[1]
+		if t.FlipH {
+			u = 1 - u
+		}
+		if t.FlipV {
+			v = 1 - v
+		}
+		if t.FlipD {
+			u, v = v, u
+		}
+		out[i] = [2]float32{uv[0] + u*(uv[2]-uv[0]), uv[1] + v*(uv[3]-uv[1])}
+	}
+	return out
+}
+
+// parallax returns how far a layer is moved so it scrolls at the given rate
+// compared to the camera.
+func parallax(cam *Camera, px, py float32) (float32, float32) {
+	return cam.X * (1 - px), cam.Y * (1 - py)
+}
+
+// appendTo adds the visible layers of the level to the batch. Tile layers are
+// culled against the view.
+func (l *Level) appendTo(b *geometryBatch, cam *Camera, view rect) {
+	for _, layer := range l.layers {
+		switch layer := layer.(type) {
+		case *TileLayer:
+			l.appendTileLayer(b, cam, view, layer)
+		case *ObjectLayer:
+			l.appendObjectLayer(b, cam, layer)
+		case *ImageLayer:
+			layer.appendTo(b, cam)
+		}
+	}
+}
+
+func tmxProperties(props []tmxProperty) map[string]string {
+	m := make(map[string]string, len(props))
+	for _, p := range props {
+		if p.Value == "" {
+			// multiline strings are stored as text
+			m[p.Name] = p.Text
+			continue
+		}
+		m[p.Name] = p.Value
+	}
+	return m
+}
+
+// decode returns the global tile ids stored in the data of a layer.
+func (d *tmxData) decode(count int) ([]uint32, error) {
+	gids := make([]uint32, 0, count)
+	switch d.Encoding {
+	case "":
+		for _, t := range d.Tiles {
+			gids = append(gids, t.GID)
+		}
+	case "csv":
+		for _, field := range strings.Split(d.Text, ",") {
+			field = strings.TrimSpace(field)
+			if field == "" {
+				continue
+			}
+			gid, err := strconv.ParseUint(field, 10, 32)
+			if err != nil {
+				return nil, err
+			}
+			gids = append(gids, uint32(gid))
+		}
+	case "base64":
+		raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(d.Text))
+		if err != nil {
+			return nil, err
+		}
+		var r io.Reader = bytes.NewReader(raw)
+		switch d.Compression {
+		case "":
+		case "zlib":
+			if r, err = zlib.NewReader(r); err != nil {
+				return nil, err
+			}
+		case "gzip":
+			if r, err = gzip.NewReader(r); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, errors.New("unsupported tmx layer compression: " + d.Compression)
+		}
+		if raw, err = ioutil.ReadAll(r); err != nil {
+			return nil, err
+		}
+		for i := 0; i+4 <= len(raw); i += 4 {
+			gids = append(gids, binary.LittleEndian.Uint32(raw[i:]))
+		}
+	default:
+		return nil, errors.New("unsupported tmx layer encoding: " + d.Encoding)
+	}
+	if len(gids) != count {
+		return nil, errors.New("tmx layer has " + strconv.Itoa(len(gids)) + " tiles, expected " + strconv.Itoa(count))
+	}
+	return gids, nil
+}
+
+// newTileset creates a tileset, loading its images relative to the url of
+// the file it was defined in.
+func newTileset(t *tmxTileset, url string) (*Tileset, error) {
+	dir := path.Dir(url)
+	ts := &Tileset{
+		FirstGID:       t.FirstGID,
+		Name:           t.Name,
+		TileWidth:      t.TileWidth,
+		TileHeight:     t.TileHeight,
+		Spacing:        t.Spacing,
+		Margin:         t.Margin,
+		TileCount:      t.TileCount,
+		Columns:        t.Columns,
+		OffsetX:        t.TileOffset.X,
+		OffsetY:        t.TileOffset.Y,
+		Properties:     tmxProperties(t.Properties),
+		TileProperties: make(map[uint32]map[string]string),
+		Animations:     make(map[uint32][]AnimationFrame),
+		tileImages:     make(map[uint32]tmxImage),
+	}
+	if t.Image != nil {
+		ts.Image = path.Join(dir, t.Image.Source)
+		ts.ImageWidth, ts.ImageHeight = t.Image.Width, t.Image.Height
+		if ts.Columns == 0 && ts.TileWidth > 0 {
+			ts.Columns = (ts.ImageWidth - 2*ts.Margin + ts.Spacing) / (ts.TileWidth + ts.Spacing)
+		}
+		if err := loadTilesetImage(ts.Image); err != nil {
+			return nil, err
+		}
+	}
+	for _, tile := range t.Tiles {
+		if len(tile.Properties) > 0 {
+			ts.TileProperties[tile.ID] = tmxProperties(tile.Properties)
+		}
+		if len(tile.Animation) > 0 {
+			frames := make([]AnimationFrame, len(tile.Animation))
+			for i, f := range tile.Animation {
+				frames[i] = AnimationFrame{ID: f.TileID, Duration: float32(f.Duration) / 1000}
+			}
+			ts.Animations[tile.ID] = frames
+		}
+		if tile.Image == nil {
+			continue
+		}
+		img := *tile.Image
+		img.Source = path.Join(dir, img.Source)
+		ts.tileImages[tile.ID] = img
+		if err := loadTilesetImage(img.Source); err != nil {
+			return nil, err
+		}
+	}
+	return ts, nil
+}
+
+// loadTilesetImage loads the image through the texture loader, unless it's
+// already been loaded.
+func loadTilesetImage(url string) error {
+	if _, ok := theTextureLoader.images[url]; ok {
+		return nil
+	}
+	for _, queued := range imagesToAdd {
+		if queued == url {
+			return nil
+		}
+	}
+	return engo.Files.Load(url)
+}
+
+type tsxLoader struct {
+	tilesets map[string]TilesetResource
+}
+
+var theTSXLoader = tsxLoader{tilesets: make(map[string]TilesetResource)}
+
+func (t *tsxLoader) Load(url string, data io.Reader) error {
+	var tileset tmxTileset
+	if err := xml.NewDecoder(data).Decode(&tileset); err != nil {
+		return err
+	}
+	ts, err := newTileset(&tileset, url)
+	if err != nil {
+		return err
+	}
+	t.tilesets[url] = TilesetResource{ts, url}
+	return nil
+}
+
+func (t *tsxLoader) Unload(url string) error {
+	delete(t.tilesets, url)
+	return nil
+}
+
+func (t *tsxLoader) Resource(url string) (engo.Resource, error) {
+	if res, ok := t.tilesets[url]; ok {
+		return res, nil
+	}
+	return TilesetResource{}, errors.New("unable to locate resource with url: " + url)
+}
+
+type tmxLoader struct {
+	levels map[string]TMXResource
+}
+
+var theTMXLoader = tmxLoader{levels: make(map[string]TMXResource)}
+
+func (t *tmxLoader) Load(url string, data io.Reader) error {
+	var m tmxMap
+	if err := xml.NewDecoder(data).Decode(&m); err != nil {
+		return err
+	}
+	level, err := newLevel(&m, url)
+	if err != nil {
+		return errors.New("unable to load tmx map " + url + ": " + err.Error())
+	}
+	t.levels[url] = TMXResource{level, url}
+	return nil
+}
+
+func (t *tmxLoader) Unload(url string) error {
+	delete(t.levels, url)
+	return nil
+}
+
+func (t *tmxLoader) Resource(url string) (engo.Resource, error) {
+	if res, ok := t.levels[url]; ok {
+		return res, nil
+	}
+	return TMXResource{}, errors.New("unable to locate resource with url: " + url)
+}
+
+func newLevel(m *tmxMap, url string) (*Level, error) {
+	if m.Infinite != 0 {
+		return nil, errors.New("infinite maps are not supported")
+	}
+	level := &Level{
+		Orientation:   m.Orientation,
+		RenderOrder:   m.RenderOrder,
+		StaggerAxis:   m.StaggerAxis,
+		StaggerIndex:  m.StaggerIndex,
+		HexSideLength: m.HexSideLength,
+		Width:         m.Width,
+		Height:        m.Height,
+		TileWidth:     m.TileWidth,
+		TileHeight:    m.TileHeight,
+		Properties:    tmxProperties(m.Properties),
+	}
+	if level.RenderOrder == "" {
+		level.RenderOrder = "right-down"
+	}
+	if err := validateOrientation(level); err != nil {
+		return nil, err
+	}
+
+	for i := range m.Tilesets {
+		t := &m.Tilesets[i]
+		if t.Source == "" {
+			ts, err := newTileset(t, url)
+			if err != nil {
+				return nil, err
+			}
+			level.Tilesets = append(level.Tilesets, ts)
+			continue
+		}
+		tsxURL := path.Join(path.Dir(url), t.Source)
+		if _, ok := theTSXLoader.tilesets[tsxURL]; !ok {
+			if err := engo.Files.Load(tsxURL); err != nil {
+				return nil, err
+			}
+		}
+		res, err := theTSXLoader.Resource(tsxURL)
+		if err != nil {
+			return nil, err
+		}
+		// the firstgid belongs to the map, so each map gets its own copy
+		ts := *res.(TilesetResource).Tileset
+		ts.FirstGID = t.FirstGID
+		level.Tilesets = append(level.Tilesets, &ts)
+	}
+	sort.Slice(level.Tilesets, func(i, j int) bool {
+		return level.Tilesets[i].FirstGID < level.Tilesets[j].FirstGID
+	})
+
+	for i := range m.Layers {
+		l := &m.Layers[i]
+		switch l.XMLName.Local {
+		case "layer":
+			layer, err := level.newTileLayer(l)
+			if err != nil {
+				return nil, err
+			}
+			level.TileLayers = append(level.TileLayers, layer)
+			level.layers = append(level.layers, layer)
+		case "objectgroup":
+			layer, err := level.newObjectLayer(l)
+			if err != nil {
+				return nil, err
+			}
+			level.ObjectLayers = append(level.ObjectLayers, layer)
+			level.layers = append(level.layers, layer)
+		case "imagelayer":
+			layer, err := newImageLayer(l, url)
+			if err != nil {
+				return nil, err
+			}
+			level.ImageLayers = append(level.ImageLayers, layer)
+			level.layers = append(level.layers, layer)
+		}
+	}
+	return level, nil
+}
+
+func (t *tmxLayer) opacity() float32 {
+	if t.Opacity == nil {
+		return 1
+	}
+	return *t.Opacity
+}
+
+func (t *tmxLayer) visible() bool {
+	return t.Visible == nil || *t.Visible != 0
+}
+
+// parallax returns the parallax factors of the layer, which default to 1
+func (t *tmxLayer) parallax() (float32, float32) {
+	x, y := float32(1), float32(1)
+	if t.ParallaxX != nil {
+		x = *t.ParallaxX
+	}
+	if t.ParallaxY != nil {
+		y = *t.ParallaxY
+	}
+	return x, y
+}
+
+func (l *Level) newTileLayer(t *tmxLayer) (*TileLayer, error) {
+	layer := &TileLayer{
+		Name:       t.Name,
+		Width:      t.Width,
+		Height:     t.Height,
+		Opacity:    t.opacity(),
+		Visible:    t.visible(),
+		OffsetX:    t.OffsetX,
+		OffsetY:    t.OffsetY,
+		Properties: tmxProperties(t.Properties),
+		Tiles:      make([]Tile, t.Width*t.Height),
+	}
+	layer.ParallaxX, layer.ParallaxY = t.parallax()
+	gids, err := t.Data.decode(t.Width * t.Height)
+	if err != nil {
+		return nil, errors.New("layer " + t.Name + ": " + err.Error())
+	}
+	for i, gid := range gids {
+		layer.Tiles[i] = l.tile(gid)
+	}
+	return layer, nil
+}
+
+// tile looks up the tileset of a global tile id and returns the tile.
+func (l *Level) tile(gid uint32) Tile {
+	id := gid & tileGIDMask
+	if id == 0 {
+		return Tile{}
+	}
+	var ts *Tileset
+	for _, t := range l.Tilesets {
+		if t.FirstGID > id {
+			break
+		}
+		ts = t
+	}
+	if ts == nil {
+		return Tile{}
+	}
+	return Tile{
+		ID:      id - ts.FirstGID,
+		Tileset: ts,
+		FlipH:   gid&tileFlippedHorizontally != 0,
+		FlipV:   gid&tileFlippedVertically != 0,
+		FlipD:   gid&tileFlippedDiagonally != 0,
+	}
+}
+
+func init() {
+	engo.Files.Register(".tmx", &theTMXLoader)
+	engo.Files.Register(".tsx", &theTSXLoader)
+}
@@ -0,0 +1,128 @@
+package vulkanRenderSystem
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/xml"
+	"io"
+	"testing"
+)
+
+// encodeGIDs returns the gids as base64 tile data, compressed by compression.
+func encodeGIDs(t *testing.T, compression string, gids ...uint32) string {
+	t.Helper()
+	var raw bytes.Buffer
+	var w io.WriteCloser
+	switch compression {
+	case "zlib":
+		w = zlib.NewWriter(&raw)
+	case "gzip":
+		w = gzip.NewWriter(&raw)
+	default:
+		w = nopCloser{&raw}
+	}
+	if err := binary.Write(w, binary.LittleEndian, gids); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return base64.StdEncoding.EncodeToString(raw.Bytes())
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error { return nil }
+
+func TestTMXDataDecode(t *testing.T) {
+	flipped := 3 | tileFlippedHorizontally | tileFlippedDiagonally
+	want := []uint32{1, 0, flipped, 42}
+	for _, test := range []struct {
+		name string
+		data string
+	}{
+		{"xml", `<data><tile gid="1"/><tile/><tile gid="2684354563"/><tile gid="42"/></data>`},
+		{"csv", "<data encoding=\"csv\">\n1,0,\n2684354563,42\n</data>"},
+		{"base64", `<data encoding="base64">` + encodeGIDs(t, "", want...) + `</data>`},
+		{"base64 with whitespace", "<data encoding=\"base64\">\n   " + encodeGIDs(t, "", want...) + "\n</data>"},
+		{"zlib", `<data encoding="base64" compression="zlib">` + encodeGIDs(t, "zlib", want...) + `</data>`},
+		{"gzip", `<data encoding="base64" compression="gzip">` + encodeGIDs(t, "gzip", want...) + `</data>`},
+	} {
+		var d tmxData
+		if err := xml.Unmarshal([]byte(test.data), &d); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		gids, err := d.decode(len(want))
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if len(gids) != len(want) {
+			t.Errorf("%s decoded %d gids, want %d", test.name, len(gids), len(want))
+			continue
+		}
+		for i := range want {
+			if gids[i] != want[i] {
+				t.Errorf("%s gid %d is %#x, want %#x", test.name, i, gids[i], want[i])
+			}
+		}
+	}
+}
+
+func TestTMXDataDecodeErrors(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		data tmxData
+	}{
+		{"bad csv", tmxData{Encoding: "csv", Text: "1,x"}},
+		{"negative csv", tmxData{Encoding: "csv", Text: "1,-1"}},
+		{"csv too big", tmxData{Encoding: "csv", Text: "1,4294967296"}},
+		{"few csv tiles", tmxData{Encoding: "csv", Text: "1"}},
+		{"many csv tiles", tmxData{Encoding: "csv", Text: "1,2,3"}},
+		{"bad base64", tmxData{Encoding: "base64", Text: "not base64!"}},
+		{"short base64", tmxData{Encoding: "base64", Text: encodeGIDs(t, "", 1)}},
+		{"bad zlib", tmxData{Encoding: "base64", Compression: "zlib", Text: encodeGIDs(t, "", 1, 2)}},
+		{"bad gzip", tmxData{Encoding: "base64", Compression: "gzip", Text: encodeGIDs(t, "zlib", 1, 2)}},
+		{"zstd", tmxData{Encoding: "base64", Compression: "zstd", Text: encodeGIDs(t, "", 1, 2)}},
+		{"unknown encoding", tmxData{Encoding: "hex", Text: "0102"}},
+	} {
+		if _, err := test.data.decode(2); err == nil {
+			t.Errorf("%s data decoded without an error", test.name)
+		}
+	}
+}
+
+func TestLevelTile(t *testing.T) {
+	first, second := &Tileset{FirstGID: 1}, &Tileset{FirstGID: 10}
+	l := &Level{Tilesets: []*Tileset{first, second}}
+	for _, test := range []struct {
+		gid  uint32
+		want Tile
+	}{
+		{0, Tile{}},
+		// flags on an empty tile leave it empty
+		{tileFlippedHorizontally, Tile{}},
+		{1, Tile{ID: 0, Tileset: first}},
+		{9, Tile{ID: 8, Tileset: first}},
+		{10, Tile{ID: 0, Tileset: second}},
+		{12 | tileFlippedHorizontally, Tile{ID: 2, Tileset: second, FlipH: true}},
+		{5 | tileFlippedVertically | tileFlippedDiagonally, Tile{ID: 4, Tileset: first, FlipV: true, FlipD: true}},
+		{11 | tileFlippedHorizontally | tileFlippedVertically | tileFlippedDiagonally, Tile{ID: 1, Tileset: second, FlipH: true, FlipV: true, FlipD: true}},
+		// the hexagonal rotation isn't part of the id
+		{2 | tileRotatedHexagonal120, Tile{ID: 1, Tileset: first}},
+	} {
+		if got := l.tile(test.gid); got != test.want {
+			t.Errorf("gid %#x is %+v, want %+v", test.gid, got, test.want)
+		}
+	}
+	// ids before the first tileset have none
+	l.Tilesets = []*Tileset{second}
+	if got := l.tile(3); got != (Tile{}) {
+		t.Errorf("a gid before the first tileset is %+v, want an empty tile", got)
+	}
+}
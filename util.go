@@ -50,17 +50,20 @@ var details swapChainSupportDetails
 
 func sliceUint32(data []byte) []uint32 {
 	const m = 0x7fffffff
-	return (*[m / 4]uint32)(unsafe.Pointer((*sliceHeader)(unsafe.Pointer(&data)).Data))[:len(data)/4]
+	n := len(data) / 4
+	return (*[m / 4]uint32)(unsafe.Pointer((*sliceHeader)(unsafe.Pointer(&data)).Data))[:n:n]
 }
 
 func vertexData(v vertex) []byte {
 	const m = 0x7fffffff
-	return (*[m]byte)(unsafe.Pointer((*sliceHeader)(unsafe.Pointer(&v)).Data))[:len(v)*4]
+	n := len(v) * 4
+	return (*[m]byte)(unsafe.Pointer((*sliceHeader)(unsafe.Pointer(&v)).Data))[:n:n]
 }
 
-func indexData(v []uint16) []byte {
+func indexData(v []uint32) []byte {
 	const m = 0x7fffffff
-	return (*[m]byte)(unsafe.Pointer((*sliceHeader)(unsafe.Pointer(&v)).Data))[:len(v)*2]
+	n := len(v) * 4
+	return (*[m]byte)(unsafe.Pointer((*sliceHeader)(unsafe.Pointer(&v)).Data))[:n:n]
 }
 
 func uniformData(v UniformBufferObject) []byte {
@@ -7,14 +7,14 @@ import (
 )
 
 // vec2 position
-// vec3 color
+// vec4 color
 // vec2 texcoord
 type vertex []float32
 
 func (v *vertex) getBindingDescription() vk.VertexInputBindingDescription {
 	return vk.VertexInputBindingDescription{
 		Binding:   0,
-		Stride:    7 * 4,
+		Stride:    vertexStride * 4,
 		InputRate: vk.VertexInputRateVertex,
 	}
 }
@@ -30,29 +30,18 @@ func (v *vertex) getAttributeDescriptions() []vk.VertexInputAttributeDescription
 	a = append(a, vk.VertexInputAttributeDescription{
 		Binding:  0,
 		Location: 1,
-		Format:   vk.FormatR32g32b32Sfloat,
+		Format:   vk.FormatR32g32b32a32Sfloat,
 		Offset:   2 * 4,
 	})
 	a = append(a, vk.VertexInputAttributeDescription{
 		Binding:  0,
 		Location: 2,
 		Format:   vk.FormatR32g32Sfloat,
-		Offset:   5 * 4,
+		Offset:   6 * 4,
 	})
 	return a
 }
 
-var vertices = vertex{
-	-0.5, -0.5, 1, 0, 0, 1, 0,
-	0.5, -0.5, 0, 1, 0, 0, 0,
-	0.5, 0.5, 0, 0, 1, 0, 1,
-	-0.5, 0.5, 1, 1, 1, 1, 1,
-}
-
-var indices = []uint16{
-	0, 1, 2, 2, 3, 0,
-}
-
 type UniformBufferObject struct {
 	model, view, projection mgl32.Mat4
 }